@@ -0,0 +1,142 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// ansiEscape matches the colour/style sequences written to views
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// stripANSI removes colour and cursor escape sequences from text
+func stripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
+
+// statusMarker returns the prefix shown before a file in the file list.
+// Accessible mode spells the state out so it is read by screen readers.
+// Both follow the configured labels and icons, see stateLabel and stateIcon.
+func statusMarker(app *AppState, status string) string {
+	if !slices.Contains(vocabularyStates, status) {
+		status = audit.StatusNoMatch
+	}
+	if app.Accessible {
+		return "[" + stateLabel(app, status) + "] "
+	}
+	return stateIcon(app, status) + " "
+}
+
+// announce records a short description of the current state. In accessible
+// mode it replaces the help bar text so screen readers pick up every change.
+func announce(app *AppState, format string, args ...interface{}) {
+	if !app.Accessible {
+		return
+	}
+	app.Announcement = fmt.Sprintf(format, args...)
+}
+
+// announceTreeSelection describes the selected directory or PURL
+func announceTreeSelection(app *AppState) {
+	if app.TreeState == nil || app.TreeState.selectedNode == nil {
+		return
+	}
+	node := app.TreeState.selectedNode
+	position := app.TreeList.GetSelectedIndex() + 1
+	total := len(app.TreeList.Items)
+
+	if app.TreeViewType == "purls" {
+		if node.IsDir {
+			state := "collapsed"
+			if app.TreeState.expandedDirs[node.Path] {
+				state = "expanded"
+			}
+			announce(app, "Component %s, %d files in several versions, %s, %d of %d", displayPURL(app, node.Name), len(node.Files), state, position, total)
+			return
+		}
+		announce(app, "PURL %s, %d files, %d of %d", displayPURL(app, node.Name), len(node.Files), position, total)
+		return
+	}
+	if app.TreeViewType == "upstream" {
+		upstreamFile := ""
+		if len(node.Files) > 0 {
+			if match := audit.FirstValidMatch(app.ScanData.Files[node.Files[0]]); match != nil {
+				upstreamFile = displayPath(app, match.File)
+			}
+		}
+		announce(app, "Upstream file %s of %s, %d files, %d of %d", upstreamFile, displayPURL(app, node.Name), len(node.Files), position, total)
+		return
+	}
+
+	state := "collapsed"
+	if app.TreeState.expandedDirs[node.Path] {
+		state = "expanded"
+	}
+	name, _ := dirLabel(app, chainTop(app, node))
+	announce(app, "Directory %s, %s, %d of %d", name, state, position, total)
+}
+
+// announceFileSelection describes the selected file and its audit state
+func announceFileSelection(app *AppState) {
+	if len(app.CurrentFileList) == 0 {
+		announce(app, "No files")
+		return
+	}
+	index := app.FileList.GetSelectedIndex()
+	if index < 0 || index >= len(app.CurrentFileList) {
+		return
+	}
+	filePath := app.CurrentFileList[index]
+	announce(app, "File %s, %s, %d of %d", displayPath(app, filePath), fileAuditState(app, filePath), index+1, len(app.CurrentFileList))
+}
+
+// focusedFile returns the file being viewed, or the one selected in the list
+func focusedFile(app *AppState) string {
+	if app.ViewMode == "content" {
+		return app.CurrentFile
+	}
+	if app.SelectedFileIndex >= 0 && app.SelectedFileIndex < len(app.CurrentFileList) {
+		return app.CurrentFileList[app.SelectedFileIndex]
+	}
+	return ""
+}
+
+// fileAuditState returns "identified", "ignored", "deferred", "pending" or
+// "no match"
+func fileAuditState(app *AppState, filePath string) string {
+	return stateLabel(app, audit.FileStatus(app.ScanData.Files[filePath]))
+}
+
+// placeAccessibleCursor moves the terminal cursor onto the selected line of
+// the active pane so screen readers track the focus
+func placeAccessibleCursor(g *gocui.Gui, app *AppState) {
+	if !app.Accessible {
+		return
+	}
+
+	// Leave the cursor alone while a dialog owns the focus
+	if cv := g.CurrentView(); cv != nil && cv.Name() != "tree" && cv.Name() != "files" {
+		return
+	}
+
+	list := app.TreeList
+	if app.ActivePane == "files" {
+		list = app.FileList
+	}
+	v, err := g.SetCurrentView(app.ActivePane)
+	if err != nil {
+		return
+	}
+	if app.ViewMode == "content" && app.ActivePane == "files" {
+		v.SetCursor(0, 0)
+		return
+	}
+	v.SetCursor(0, list.SelectedIndex-list.ScrollOffset)
+}
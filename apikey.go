@@ -9,16 +9,27 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strconv"
 	"strings"
-	"syscall"
+	"time"
 
 	"golang.org/x/term"
 )
 
 const configFileName = ".auditcmd"
 
+// windowsConfigFileName is used under %APPDATA%, where dotfiles aren't customary
+const windowsConfigFileName = "auditcmd.ini"
+
 func getConfigFilePath() string {
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "auditcmd", windowsConfigFileName)
+		}
+	}
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return configFileName
@@ -27,9 +38,59 @@ func getConfigFilePath() string {
 }
 
 type Config struct {
-	APIKey        string
-	PaneWidth     float64
-	ViewFilter     string
+	APIKey              string
+	APIKeyEncrypted     string            // api_key_encrypted: the API key, see encryptAPIKey
+	APIKeyEncryption    string            // api_key_encryption: how a new API key is stored ("" = plain text)
+	HostKeys            map[string]string // api_key.<host>: the API key for requests to that host
+	HostKeysEncrypted   map[string]string // api_key_encrypted.<host>: the same, see encryptAPIKey
+	PaneWidth           float64
+	ViewFilter          string
+	TreeOrder           string // "name" or "pending"
+	TreeFiles           bool   // tree_files: list files as leaves of the directory tree
+	FlattenDirs         bool   // flatten_dirs: show chains of single subdirectories on one line
+	FileLayout          string // "paths" or "columns"
+	LayoutPreset        string // See layoutPresets
+	SimilarityThreshold int    // Path similarity percentage flagged by [m] (0 = default)
+	QualityThreshold    int    // Quality score percentage highlighted and flagged by [M] (0 = default)
+	Accessible          bool
+	HideIdentified      bool   // Hide identified and ignored files, toggled with [G]
+	OnDecision          string // Command run with each saved decision as JSON on stdin
+	Commands            map[rune]CustomCommand
+	Tracker             TrackerConfig
+	Push                PushConfig
+	Webhook             WebhookConfig
+	GitCommitEvery      int               // Commit the results file to git after N decisions (0 = off)
+	RescanCommand       string            // Scanner command template used by [R]
+	ProjectLicense      string            // Outbound SPDX license, for compatibility checks
+	StaleReleaseYears   int               // Years after its release a component is stale (0 = default, -1 = off)
+	StalePushYears      int               // Years without a push after which it is stale (0 = default, -1 = off)
+	QuotaURL            string            // Queried at startup for the remaining API quota
+	ProvenanceURL       string            // Geoprovenance endpoint for contributor countries
+	ComponentPageURL    string            // Template of component pages on the SCANOSS platform, with {purl}
+	NoContentCache      bool              // content_cache=false: don't keep fetched contents on disk
+	Prefetch            int               // Files after the viewed one fetched in the background (0 = off)
+	BatchContentURL     string            // Endpoint returning several file contents in one request
+	MaxFetches          int               // SCANOSS API requests run at once (0 = default)
+	PURLOrder           string            // purl_ranking: "files", "size" or "pending"
+	Reviewer            string            // Identity recorded as the auditor of decisions and with second opinions
+	TimeZone            string            // IANA zone timestamps are shown in ("" = local time)
+	TimestampFormat     string            // Go layout timestamps are shown with ("" = default)
+	ExportOnQuit        []string          // Exports regenerated on exit when decisions changed
+	RecordDuration      bool              // Record how long a file was open with its decision
+	ExportHistory       bool              // export_history: add every decision as JSON to the CSV export
+	ExportStale         bool              // export_stale: add the Stale Component column to the CSV export
+	WriteStatus         bool              // write_status: give every match a status field on save
+	BOMFile             string            // bom_file: SCANOSS settings file whose BOM follows the decisions ("off" = none)
+	QuickActions        string            // quick_actions: instant, confirm or off ("" = instant)
+	CollapseCompleted   string            // collapse_completed: ask, always or never ("" = ask)
+	AcceptReasons       Reasons           // accept_reason.<n>: comments picked with 1-9 in the accept dialog
+	Labels              map[string]string // label.<state>: what a state is called, see stateLabel
+	Icons               map[string]string // icon.<state>: its marker in the file list
+	IgnoreReasons       Reasons           // ignore_reason.<n>: the same for the ignore dialog
+	Exclude             []string          // Globs of paths left out of the audit, see audit.CompileExcludes
+	IgnoreList          []string          // always_ignore: PURLs whose matches are ignored in every scan
+	Checklist           []string          // review_checklist: points ticked in the accept dialog
+	Warnings            []string          // Problems found while parsing, reported at startup
 }
 
 func loadConfig() (*Config, error) {
@@ -37,9 +98,15 @@ func loadConfig() (*Config, error) {
 	
 	// Default config
 	config := &Config{
-		APIKey:        "",
-		PaneWidth:     0.5,
-		ViewFilter:     "all",
+		APIKey:            "",
+		PaneWidth:         0.5,
+		ViewFilter:        "all",
+		Commands:          make(map[rune]CustomCommand),
+		Labels:            make(map[string]string),
+		Icons:             make(map[string]string),
+		HostKeys:          make(map[string]string),
+		HostKeysEncrypted: make(map[string]string),
+		Webhook:           WebhookConfig{Milestones: defaultMilestones},
 	}
 	
 	// Check if config file exists
@@ -64,19 +131,251 @@ func loadConfig() (*Config, error) {
 		if strings.Contains(line, "=") {
 			parts := strings.SplitN(line, "=", 2)
 			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
+			value := unquoteConfigValue(strings.TrimSpace(parts[1]))
 			
 			switch key {
 			case "api_key":
 				config.APIKey = value
+			case "api_key_encrypted":
+				config.APIKeyEncrypted = value
+			case "api_key_encryption":
+				if slices.Contains(encryptionModes, value) {
+					config.APIKeyEncryption = value
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("unknown api_key_encryption %q (use %s)", value, strings.Join(encryptionModes, " or ")))
+				}
 			case "pane_width":
 				if width, err := strconv.ParseFloat(value, 64); err == nil {
 					config.PaneWidth = width
 				}
 			case "view_filter":
-				if value == "all" || value == "matched" || value == "pending" {
+				if slices.Contains(viewFilters, value) {
 					config.ViewFilter = value
 				}
+			case "tree_order":
+				if value == "name" || value == "pending" {
+					config.TreeOrder = value
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("unknown tree_order %q (use name or pending)", value))
+				}
+			case "tree_files":
+				config.TreeFiles = value == "true"
+			case "flatten_dirs":
+				config.FlattenDirs = value == "true"
+			case "file_layout":
+				if value == "paths" || value == "columns" {
+					config.FileLayout = value
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("unknown file_layout %q (use paths or columns)", value))
+				}
+			case "layout":
+				if findLayoutPreset(value).name == value {
+					config.LayoutPreset = value
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("unknown layout %q (use custom, wide-tree, wide-files, content or zen)", value))
+				}
+			case "similarity_threshold":
+				if threshold, err := strconv.Atoi(value); err == nil && threshold >= 1 && threshold <= 100 {
+					config.SimilarityThreshold = threshold
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("invalid similarity_threshold %q (use a percentage from 1 to 100)", value))
+				}
+			case "stale_release_years", "stale_push_years":
+				years := 0
+				if value == "off" || value == "0" {
+					years = -1
+				} else if n, err := strconv.Atoi(value); err == nil && n > 0 {
+					years = n
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("invalid %s %q (use a number of years, or off)", key, value))
+					break
+				}
+				if key == "stale_release_years" {
+					config.StaleReleaseYears = years
+				} else {
+					config.StalePushYears = years
+				}
+			case "quality_threshold":
+				if threshold, err := strconv.Atoi(value); err == nil && threshold >= 1 && threshold <= 100 {
+					config.QualityThreshold = threshold
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("invalid quality_threshold %q (use a percentage from 1 to 100)", value))
+				}
+			case "accessible":
+				config.Accessible = value == "true"
+			case "hide_identified":
+				config.HideIdentified = value == "true"
+			case "on_decision":
+				config.OnDecision = value
+			case "tracker_type":
+				config.Tracker.Type = strings.ToLower(value)
+			case "tracker_url":
+				config.Tracker.URL = value
+			case "tracker_project":
+				config.Tracker.Project = value
+			case "tracker_user":
+				config.Tracker.User = value
+			case "tracker_token":
+				config.Tracker.Token = value
+			case "tracker_issue_type":
+				config.Tracker.IssueType = value
+			case "tracker_title_template":
+				config.Tracker.TitleTemplate = value
+			case "tracker_body_template":
+				// Templates are single config lines; \n starts a new line
+				config.Tracker.BodyTemplate = strings.ReplaceAll(value, `\n`, "\n")
+			case "webhook_url":
+				config.Webhook.URL = value
+			case "webhook_format":
+				switch format := strings.ToLower(value); format {
+				case "json", "slack", "teams":
+					config.Webhook.Format = format
+				default:
+					config.Warnings = append(config.Warnings, fmt.Sprintf("unknown webhook_format %q (use json, slack or teams)", value))
+				}
+			case "webhook_milestones":
+				if milestones, err := parseMilestones(value); err == nil {
+					config.Webhook.Milestones = milestones
+				} else {
+					config.Warnings = append(config.Warnings, err.Error())
+				}
+			case "rescan_command":
+				config.RescanCommand = value
+			case "sw360_url":
+				config.Push.SW360URL = value
+			case "sw360_token":
+				config.Push.SW360Token = value
+			case "sw360_project":
+				config.Push.SW360Project = value
+			case "fossology_url":
+				config.Push.FossologyURL = value
+			case "fossology_token":
+				config.Push.FossologyToken = value
+			case "fossology_upload":
+				config.Push.FossologyUpload = value
+			case "project_license":
+				config.ProjectLicense = value
+			case "quota_url":
+				config.QuotaURL = value
+			case "provenance_url":
+				config.ProvenanceURL = value
+			case "component_page_url":
+				config.ComponentPageURL = value
+			case "content_cache":
+				config.NoContentCache = value == "false"
+			case "prefetch":
+				if count, err := strconv.Atoi(value); err == nil && count >= 0 {
+					config.Prefetch = count
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("invalid prefetch %q (use a number of files, 0 to disable)", value))
+				}
+			case "batch_content_url":
+				config.BatchContentURL = value
+			case "reviewer":
+				config.Reviewer = value
+			case "exclude":
+				// Comma-separated, and repeated lines add up
+				for _, glob := range strings.Split(value, ",") {
+					if glob = strings.TrimSpace(glob); glob != "" {
+						config.Exclude = append(config.Exclude, glob)
+					}
+				}
+			case "review_checklist":
+				// Comma-separated, and repeated lines add up
+				for _, item := range strings.Split(value, ",") {
+					if item = strings.TrimSpace(item); item == "" {
+						continue
+					}
+					if len(config.Checklist) == maxChecklistItems {
+						config.Warnings = append(config.Warnings, fmt.Sprintf("review_checklist item %q left out (at most %d items)", item, maxChecklistItems))
+						continue
+					}
+					config.Checklist = append(config.Checklist, item)
+				}
+			case "always_ignore":
+				// Comma-separated, and repeated lines add up
+				for _, purl := range strings.Split(value, ",") {
+					if purl = strings.TrimSpace(purl); purl != "" {
+						config.IgnoreList = append(config.IgnoreList, purl)
+					}
+				}
+			case "record_duration":
+				config.RecordDuration = value == "true"
+			case "export_history":
+				config.ExportHistory = value == "true"
+			case "export_stale":
+				config.ExportStale = value == "true"
+			case "write_status":
+				config.WriteStatus = value == "true"
+			case "bom_file":
+				config.BOMFile = value
+			case "quick_actions":
+				if slices.Contains(quickActionModes, value) {
+					config.QuickActions = value
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("unknown quick_actions %q (use %s)", value, strings.Join(quickActionModes, ", ")))
+				}
+			case "collapse_completed":
+				if slices.Contains(collapseModes, value) {
+					config.CollapseCompleted = value
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("unknown collapse_completed %q (use %s)", value, strings.Join(collapseModes, ", ")))
+				}
+			case "export_on_quit":
+				if exports, err := parseAutoExports(value); err == nil {
+					config.ExportOnQuit = exports
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("invalid export_on_quit: %v", err))
+				}
+			case "timezone":
+				if _, err := time.LoadLocation(value); err == nil {
+					config.TimeZone = value
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("unknown timezone %q (use an IANA name such as Europe/Madrid, UTC or Local)", value))
+				}
+			case "timestamp_format":
+				// A layout without any element of the reference time formats as itself
+				if layout := timestampLayout(value); time.Unix(0, 0).Format(layout) != layout {
+					config.TimestampFormat = value
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("invalid timestamp_format %q (use rfc3339 or a Go layout such as 2006-01-02 15:04 MST)", value))
+				}
+			case "purl_ranking":
+				if value == "files" || value == "size" || value == "pending" {
+					config.PURLOrder = value
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("unknown purl_ranking %q (use files, size or pending)", value))
+				}
+			case "max_fetches":
+				if limit, err := strconv.Atoi(value); err == nil && limit >= 1 {
+					config.MaxFetches = limit
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("invalid max_fetches %q (use a number of requests, at least 1)", value))
+				}
+			case "git_commit_every":
+				if every, err := strconv.Atoi(value); err == nil && every >= 0 {
+					config.GitCommitEvery = every
+				} else {
+					config.Warnings = append(config.Warnings, fmt.Sprintf("invalid git_commit_every %q (use a number of decisions, 0 to disable)", value))
+				}
+			default:
+				if name, ok := strings.CutPrefix(key, "command."); ok {
+					addConfigCommand(config, name, value, false)
+				} else if name, ok := strings.CutPrefix(key, "command_bg."); ok {
+					addConfigCommand(config, name, value, true)
+				} else if name, ok := strings.CutPrefix(key, "accept_reason."); ok {
+					addConfigReason(config, &config.AcceptReasons, key, name, value)
+				} else if name, ok := strings.CutPrefix(key, "ignore_reason."); ok {
+					addConfigReason(config, &config.IgnoreReasons, key, name, value)
+				} else if state, ok := strings.CutPrefix(key, "label."); ok {
+					addConfigVocabulary(config, config.Labels, key, state, value)
+				} else if state, ok := strings.CutPrefix(key, "icon."); ok {
+					addConfigVocabulary(config, config.Icons, key, state, value)
+				} else if host, ok := strings.CutPrefix(key, "api_key."); ok {
+					addConfigHostKey(config, config.HostKeys, "api_key", host, value)
+				} else if host, ok := strings.CutPrefix(key, "api_key_encrypted."); ok {
+					addConfigHostKey(config, config.HostKeysEncrypted, "api_key_encrypted", host, value)
+				}
 			}
 		}
 	}
@@ -84,12 +383,48 @@ func loadConfig() (*Config, error) {
 	return config, nil
 }
 
+// unquoteConfigValue strips one pair of surrounding double quotes so both
+// on_decision=./notify.sh and on_decision = "./notify.sh" are accepted
+func unquoteConfigValue(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// addConfigCommand records a command.<key> or command_bg.<key> entry
+func addConfigCommand(config *Config, name, template string, background bool) {
+	key, err := parseCommandKey(name)
+	if err != nil {
+		config.Warnings = append(config.Warnings, err.Error())
+		return
+	}
+	config.Commands[key] = CustomCommand{Key: key, Template: template, Background: background}
+}
+
+// decryptError is returned by loadAPIKey when api_key_encrypted can't be
+// decrypted
+type decryptError struct {
+	err error
+}
+
+func (e *decryptError) Error() string {
+	return fmt.Sprintf("could not decrypt the API key: %v", e.err)
+}
+
 func loadAPIKey() (string, error) {
 	config, err := loadConfig()
 	if err != nil {
 		return "", err
 	}
 	
+	if config.APIKey == "" && config.APIKeyEncrypted != "" {
+		apiKey, err := decryptAPIKey(config.APIKeyEncrypted)
+		if err != nil {
+			return "", &decryptError{err}
+		}
+		return apiKey, nil
+	}
 	if config.APIKey == "" {
 		return "", fmt.Errorf("API key not found")
 	}
@@ -109,15 +444,172 @@ func saveConfig(config *Config) error {
 	// Create INI content
 	content := "# AuditCmd Configuration\n"
 	content += "# This file stores settings for the AuditCmd application\n\n"
-	content += fmt.Sprintf("api_key=%s\n", config.APIKey)
+	if config.APIKey != "" || config.APIKeyEncrypted == "" {
+		content += fmt.Sprintf("api_key=%s\n", config.APIKey)
+	}
+	if config.APIKeyEncrypted != "" {
+		content += fmt.Sprintf("api_key_encrypted=%s\n", config.APIKeyEncrypted)
+	}
+	if config.APIKeyEncryption != "" {
+		content += fmt.Sprintf("api_key_encryption=%s\n", config.APIKeyEncryption)
+	}
+	content += configHostKeyLines("api_key", config.HostKeys)
+	content += configHostKeyLines("api_key_encrypted", config.HostKeysEncrypted)
 	content += fmt.Sprintf("pane_width=%.2f\n", config.PaneWidth)
 	content += fmt.Sprintf("view_filter=%s\n", config.ViewFilter)
+	if config.TreeOrder == "pending" {
+		content += "tree_order=pending\n"
+	}
+	if config.TreeFiles {
+		content += "tree_files=true\n"
+	}
+	if config.FlattenDirs {
+		content += "flatten_dirs=true\n"
+	}
+	if config.FileLayout == "columns" {
+		content += "file_layout=columns\n"
+	}
+	if config.LayoutPreset != "" && config.LayoutPreset != "custom" {
+		content += fmt.Sprintf("layout=%s\n", config.LayoutPreset)
+	}
+	if config.SimilarityThreshold != 0 {
+		content += fmt.Sprintf("similarity_threshold=%d\n", config.SimilarityThreshold)
+	}
+	if config.QualityThreshold != 0 {
+		content += fmt.Sprintf("quality_threshold=%d\n", config.QualityThreshold)
+	}
+	if config.StaleReleaseYears != 0 {
+		content += fmt.Sprintf("stale_release_years=%s\n", staleYearsSetting(config.StaleReleaseYears))
+	}
+	if config.StalePushYears != 0 {
+		content += fmt.Sprintf("stale_push_years=%s\n", staleYearsSetting(config.StalePushYears))
+	}
+	content += fmt.Sprintf("accessible=%t\n", config.Accessible)
+	content += fmt.Sprintf("hide_identified=%t\n", config.HideIdentified)
+	if config.OnDecision != "" {
+		content += fmt.Sprintf("on_decision=%s\n", config.OnDecision)
+	}
+	trackerSettings := []struct{ key, value string }{
+		{"tracker_type", config.Tracker.Type},
+		{"tracker_url", config.Tracker.URL},
+		{"tracker_project", config.Tracker.Project},
+		{"tracker_user", config.Tracker.User},
+		{"tracker_token", config.Tracker.Token},
+		{"tracker_issue_type", config.Tracker.IssueType},
+		{"tracker_title_template", config.Tracker.TitleTemplate},
+		{"tracker_body_template", strings.ReplaceAll(config.Tracker.BodyTemplate, "\n", `\n`)},
+		{"sw360_url", config.Push.SW360URL},
+		{"sw360_token", config.Push.SW360Token},
+		{"sw360_project", config.Push.SW360Project},
+		{"fossology_url", config.Push.FossologyURL},
+		{"fossology_token", config.Push.FossologyToken},
+		{"fossology_upload", config.Push.FossologyUpload},
+	}
+	for _, setting := range trackerSettings {
+		if setting.value != "" {
+			content += fmt.Sprintf("%s=%s\n", setting.key, setting.value)
+		}
+	}
+	if config.Webhook.URL != "" {
+		content += fmt.Sprintf("webhook_url=%s\n", config.Webhook.URL)
+		if config.Webhook.Format != "" {
+			content += fmt.Sprintf("webhook_format=%s\n", config.Webhook.Format)
+		}
+		content += fmt.Sprintf("webhook_milestones=%s\n", formatMilestones(config.Webhook.Milestones))
+	}
+	if config.RescanCommand != "" {
+		content += fmt.Sprintf("rescan_command=%s\n", config.RescanCommand)
+	}
+	if config.GitCommitEvery > 0 {
+		content += fmt.Sprintf("git_commit_every=%d\n", config.GitCommitEvery)
+	}
+	if config.ProjectLicense != "" {
+		content += fmt.Sprintf("project_license=%s\n", config.ProjectLicense)
+	}
+	if config.QuotaURL != "" {
+		content += fmt.Sprintf("quota_url=%s\n", config.QuotaURL)
+	}
+	if config.ProvenanceURL != "" {
+		content += fmt.Sprintf("provenance_url=%s\n", config.ProvenanceURL)
+	}
+	if config.ComponentPageURL != "" {
+		content += fmt.Sprintf("component_page_url=%s\n", config.ComponentPageURL)
+	}
+	if config.NoContentCache {
+		content += "content_cache=false\n"
+	}
+	if config.Prefetch != 0 {
+		content += fmt.Sprintf("prefetch=%d\n", config.Prefetch)
+	}
+	if config.BatchContentURL != "" {
+		content += fmt.Sprintf("batch_content_url=%s\n", config.BatchContentURL)
+	}
+	if config.PURLOrder != "" {
+		content += fmt.Sprintf("purl_ranking=%s\n", config.PURLOrder)
+	}
+	if config.MaxFetches != 0 {
+		content += fmt.Sprintf("max_fetches=%d\n", config.MaxFetches)
+	}
+	if config.Reviewer != "" {
+		content += fmt.Sprintf("reviewer=%s\n", config.Reviewer)
+	}
+	if len(config.Exclude) > 0 {
+		content += fmt.Sprintf("exclude=%s\n", strings.Join(config.Exclude, ","))
+	}
+	if len(config.Checklist) > 0 {
+		content += fmt.Sprintf("review_checklist=%s\n", strings.Join(config.Checklist, ","))
+	}
+	if len(config.IgnoreList) > 0 {
+		content += fmt.Sprintf("always_ignore=%s\n", strings.Join(config.IgnoreList, ","))
+	}
+	if config.RecordDuration {
+		content += "record_duration=true\n"
+	}
+	if config.ExportHistory {
+		content += "export_history=true\n"
+	}
+	if config.ExportStale {
+		content += "export_stale=true\n"
+	}
+	if config.WriteStatus {
+		content += "write_status=true\n"
+	}
+	if config.BOMFile != "" {
+		content += fmt.Sprintf("bom_file=%s\n", config.BOMFile)
+	}
+	if config.QuickActions != "" && config.QuickActions != quickInstant {
+		content += fmt.Sprintf("quick_actions=%s\n", config.QuickActions)
+	}
+	if config.CollapseCompleted != "" && config.CollapseCompleted != collapseAsk {
+		content += fmt.Sprintf("collapse_completed=%s\n", config.CollapseCompleted)
+	}
+	if len(config.ExportOnQuit) > 0 {
+		content += fmt.Sprintf("export_on_quit=%s\n", strings.Join(config.ExportOnQuit, ","))
+	}
+	if config.TimeZone != "" {
+		content += fmt.Sprintf("timezone=%s\n", config.TimeZone)
+	}
+	if config.TimestampFormat != "" {
+		content += fmt.Sprintf("timestamp_format=%s\n", config.TimestampFormat)
+	}
+	content += configReasonLines("accept_reason", config.AcceptReasons)
+	content += configReasonLines("ignore_reason", config.IgnoreReasons)
+	content += configVocabularyLines("label", config.Labels)
+	content += configVocabularyLines("icon", config.Icons)
+	for _, command := range sortedCommands(config.Commands) {
+		prefix := "command"
+		if command.Background {
+			prefix = "command_bg"
+		}
+		content += fmt.Sprintf("%s.%c=%s\n", prefix, command.Key, command.Template)
+	}
 	
 	// Write config to file with secure permissions
 	err := ioutil.WriteFile(configPath, []byte(content), 0600)
 	if err != nil {
 		return fmt.Errorf("failed to save config: %v", err)
 	}
+	savedConfig = content
 	
 	return nil
 }
@@ -125,7 +617,14 @@ func saveConfig(config *Config) error {
 func saveAPIKey(apiKey string) error {
 	// Load existing config
 	config, _ := loadConfig()
-	config.APIKey = apiKey
+	config.APIKey, config.APIKeyEncrypted = apiKey, ""
+	if config.APIKeyEncryption != "" && apiKey != "" {
+		encrypted, err := encryptAPIKey(apiKey, config.APIKeyEncryption)
+		if err != nil {
+			return err
+		}
+		config.APIKey, config.APIKeyEncrypted = "", encrypted
+	}
 	
 	return saveConfig(config)
 }
@@ -151,7 +650,8 @@ func promptForAPIKey() (string, error) {
 		fmt.Print("Enter your SCANOSS API key (or 'skip' to continue without): ")
 		
 		// Try to read securely first
-		byteInput, err := term.ReadPassword(int(syscall.Stdin))
+		// os.Stdin.Fd() is portable; syscall.Stdin is a Handle on Windows
+		byteInput, err := term.ReadPassword(int(os.Stdin.Fd()))
 		var input string
 		
 		if err != nil {
@@ -181,18 +681,28 @@ func promptForAPIKey() (string, error) {
 	}
 }
 
-func getOrPromptAPIKey() (string, error) {
+// getOrPromptAPIKey returns the configured API key, asking for one when
+// there is none. The warning explains a key that couldn't be decrypted.
+func getOrPromptAPIKey() (apiKey, warning string, err error) {
 	// Try to load existing API key
-	apiKey, err := loadAPIKey()
+	apiKey, err = loadAPIKey()
 	if err == nil {
-		return apiKey, nil
+		return apiKey, "", nil
+	}
+	// Asking for a new key would replace the encrypted one
+	if _, ok := err.(*decryptError); ok {
+		return "", err.Error(), nil
+	}
+	// Keys for each host may be all that is needed
+	if config, _ := loadConfig(); len(config.HostKeys) > 0 || len(config.HostKeysEncrypted) > 0 {
+		return "", "", nil
 	}
 	
 	// If not found, prompt user
 	fmt.Printf("Error loading API key: %v\n", err)
 	apiKey, err = promptForAPIKey()
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	
 	// Save the API key for future use
@@ -203,7 +713,7 @@ func getOrPromptAPIKey() (string, error) {
 		fmt.Println("API key saved to", getConfigFilePath())
 	}
 	
-	return apiKey, nil
+	return apiKey, "", nil
 }
 
 func savePaneWidth(width float64) error {
@@ -235,6 +745,94 @@ func loadViewFilter() string {
 	return config.ViewFilter
 }
 
+func saveTreeOrder(treeOrder string) error {
+	config, _ := loadConfig()
+	config.TreeOrder = treeOrder
+
+	return saveConfig(config)
+}
+
+func savePURLOrder(purlOrder string) error {
+	config, _ := loadConfig()
+	config.PURLOrder = purlOrder
+
+	return saveConfig(config)
+}
+
+func saveIgnoreList(purls []string) error {
+	config, _ := loadConfig()
+	config.IgnoreList = purls
+
+	return saveConfig(config)
+}
+
+func saveTreeFiles(treeFiles bool) error {
+	config, _ := loadConfig()
+	config.TreeFiles = treeFiles
+
+	return saveConfig(config)
+}
+
+func loadTreeFiles() bool {
+	config, _ := loadConfig()
+	return config.TreeFiles
+}
+
+func loadTreeOrder() string {
+	config, _ := loadConfig()
+	if config.TreeOrder == "" {
+		return "name"
+	}
+	return config.TreeOrder
+}
+
+func saveFileLayout(fileLayout string) error {
+	config, _ := loadConfig()
+	config.FileLayout = fileLayout
+
+	return saveConfig(config)
+}
+
+func loadFileLayout() string {
+	config, _ := loadConfig()
+	if config.FileLayout == "" {
+		return "paths"
+	}
+	return config.FileLayout
+}
+
+func saveLayoutPreset(layoutPreset string) error {
+	config, _ := loadConfig()
+	config.LayoutPreset = layoutPreset
+
+	return saveConfig(config)
+}
+
+func loadLayoutPreset() string {
+	config, _ := loadConfig()
+	if config.LayoutPreset == "" {
+		return "custom"
+	}
+	return config.LayoutPreset
+}
+
+func loadAccessible() bool {
+	config, _ := loadConfig()
+	return config.Accessible
+}
+
+func saveHideIdentified(hide bool) error {
+	config, _ := loadConfig()
+	config.HideIdentified = hide
+
+	return saveConfig(config)
+}
+
+func loadHideIdentified() bool {
+	config, _ := loadConfig()
+	return config.HideIdentified
+}
+
 // validateAPIKey tests the API key by making a simple request
 func validateAPIKey(apiKey string) error {
 	// This could be enhanced to make a test API call
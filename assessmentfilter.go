@@ -0,0 +1,97 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// compileAssessmentFilter turns the text entered after [/] into a case
+// insensitive pattern: "/.../" is a regular expression, anything else a
+// substring
+func compileAssessmentFilter(text string) (*regexp.Regexp, error) {
+	if len(text) >= 2 && strings.HasPrefix(text, "/") && strings.HasSuffix(text, "/") {
+		expression := text[1 : len(text)-1]
+		// Compiled as entered first so errors quote the user's expression
+		if _, err := regexp.Compile(expression); err != nil {
+			return nil, err
+		}
+		return regexp.Compile("(?i)" + expression)
+	}
+	return regexp.Compile("(?i)" + regexp.QuoteMeta(text))
+}
+
+// assessmentInScope reports whether a file passes the assessment filter
+func assessmentInScope(app *AppState, filePath string) bool {
+	return app.AssessmentPattern == nil || audit.MatchesAssessment(app.ScanData.Files[filePath], app.AssessmentPattern)
+}
+
+// showAssessmentFilterInput asks for the text to filter assessments by. An
+// empty filter shows all files again.
+func showAssessmentFilterInput(g *gocui.Gui, app *AppState) error {
+	v, err := setDialogView(g, "assessment_filter")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "Filter by assessment, text or /regex/ (ENTER: Apply, ESC: Cancel)"
+	v.Frame = true
+	v.Editable = true
+	v.Clear()
+	fmt.Fprint(v, app.AssessmentFilter)
+	v.SetCursor(len(app.AssessmentFilter), 0)
+
+	if _, err := g.SetCurrentView("assessment_filter"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("assessment_filter")
+	g.SetKeybinding("assessment_filter", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		text := strings.TrimSpace(v.Buffer())
+		closeAssessmentFilterInput(g, app)
+		return applyAssessmentFilter(g, app, text)
+	})
+	g.SetKeybinding("assessment_filter", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeAssessmentFilterInput(g, app)
+	})
+	return nil
+}
+
+// applyAssessmentFilter shows only files whose assessment matches text
+func applyAssessmentFilter(g *gocui.Gui, app *AppState, text string) error {
+	if text == "" {
+		app.AssessmentFilter = ""
+		app.AssessmentPattern = nil
+		refreshScope(g, app)
+		announce(app, "Assessment filter cleared, %d items", len(app.TreeList.Items))
+		return nil
+	}
+
+	pattern, err := compileAssessmentFilter(text)
+	if err != nil {
+		return showErrorDialog(g, app, "Assessment Filter", fmt.Sprintf("Invalid regular expression: %v", err))
+	}
+	app.AssessmentFilter = text
+	app.AssessmentPattern = pattern
+	refreshScope(g, app)
+	announce(app, "Showing files with assessments matching %s, %d items", text, len(app.TreeList.Items))
+	return nil
+}
+
+func closeAssessmentFilterInput(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("assessment_filter")
+	g.DeleteView("assessment_filter")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
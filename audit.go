@@ -4,12 +4,12 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"strings"
 	"time"
 
+	"auditcmd/pkg/audit"
+
 	"github.com/awesome-gocui/gocui"
 )
 
@@ -18,23 +18,14 @@ func showAcceptDialog(g *gocui.Gui, app *AppState) error {
 	if app.CurrentMatch == nil {
 		if app.ActivePane == "files" && len(app.CurrentFileList) > 0 && app.SelectedFileIndex >= 0 && app.SelectedFileIndex < len(app.CurrentFileList) {
 			selectedFile := app.CurrentFileList[app.SelectedFileIndex]
-			matches, exists := app.ScanData.Files[selectedFile]
-			if exists && len(matches) > 0 {
-				// Find the first valid match (file or snippet)
-				for i, m := range matches {
-					if m.ID == "file" || m.ID == "snippet" {
-						app.CurrentMatch = &matches[i]
-						break
-					}
-				}
-			}
+			// Find the first valid match (file or snippet)
+			app.CurrentMatch = audit.FirstValidMatch(app.ScanData.Files[selectedFile])
 		}
 	}
 	
 	if app.CurrentMatch == nil {
 		// Show a message if no auditable file is selected
-		maxX, maxY := g.Size()
-		if v, err := g.SetView("audit_error", maxX/4, maxY/3, 3*maxX/4, maxY/3+4, 0); err != nil {
+		if v, err := setDialogView(g, "audit_error"); err != nil {
 			if err != gocui.ErrUnknownView {
 				return err
 			}
@@ -60,17 +51,15 @@ func showAcceptDialog(g *gocui.Gui, app *AppState) error {
 		return nil
 	}
 
-	maxX, maxY := g.Size()
-	
 	// Set decision to identified for accept dialog
-	app.PendingDecision = "identified"
+	app.PendingDecision = audit.DecisionIdentified
 	
 	// Main dialog frame - fixed 4-line height
-	if v, err := g.SetView("audit_dialog", maxX/4, maxY/3, 3*maxX/4, maxY/3+5, 0); err != nil {
+	if v, err := setDialogView(g, "audit_dialog"); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
-		v.Title = "ACCEPT Identification"
+		v.Title = decisionTitle(app, audit.DecisionIdentified)
 		v.Frame = true
 		v.Editable = false
 		v.TitleColor = gocui.ColorYellow
@@ -79,7 +68,7 @@ func showAcceptDialog(g *gocui.Gui, app *AppState) error {
 	}
 	
 	// Input field - 2 lines in the middle (lines 2-3)
-	if v, err := g.SetView("audit_input", maxX/4+1, maxY/3+1, 3*maxX/4-1, maxY/3+3, 0); err != nil {
+	if v, err := setDialogView(g, "audit_input"); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
@@ -110,7 +99,11 @@ func showAcceptDialog(g *gocui.Gui, app *AppState) error {
 		return closeAuditDialog(g, app)
 	})
 
-	return nil
+	bindCommentEditing(g, app)
+	if err := showChecklist(g, app); err != nil {
+		return err
+	}
+	return showReasonList(g, app)
 }
 
 func showIgnoreDialog(g *gocui.Gui, app *AppState) error {
@@ -118,23 +111,14 @@ func showIgnoreDialog(g *gocui.Gui, app *AppState) error {
 	if app.CurrentMatch == nil {
 		if app.ActivePane == "files" && len(app.CurrentFileList) > 0 && app.SelectedFileIndex >= 0 && app.SelectedFileIndex < len(app.CurrentFileList) {
 			selectedFile := app.CurrentFileList[app.SelectedFileIndex]
-			matches, exists := app.ScanData.Files[selectedFile]
-			if exists && len(matches) > 0 {
-				// Find the first valid match (file or snippet)
-				for i, m := range matches {
-					if m.ID == "file" || m.ID == "snippet" {
-						app.CurrentMatch = &matches[i]
-						break
-					}
-				}
-			}
+			// Find the first valid match (file or snippet)
+			app.CurrentMatch = audit.FirstValidMatch(app.ScanData.Files[selectedFile])
 		}
 	}
 	
 	if app.CurrentMatch == nil {
 		// Show a message if no auditable file is selected
-		maxX, maxY := g.Size()
-		if v, err := g.SetView("audit_error", maxX/4, maxY/3, 3*maxX/4, maxY/3+4, 0); err != nil {
+		if v, err := setDialogView(g, "audit_error"); err != nil {
 			if err != gocui.ErrUnknownView {
 				return err
 			}
@@ -160,17 +144,15 @@ func showIgnoreDialog(g *gocui.Gui, app *AppState) error {
 		return nil
 	}
 
-	maxX, maxY := g.Size()
-	
 	// Set decision to ignored for ignore dialog
-	app.PendingDecision = "ignored"
+	app.PendingDecision = audit.DecisionIgnored
 	
 	// Main dialog frame - fixed 4-line height
-	if v, err := g.SetView("audit_dialog", maxX/4, maxY/3, 3*maxX/4, maxY/3+5, 0); err != nil {
+	if v, err := setDialogView(g, "audit_dialog"); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
-		v.Title = "IGNORE Identification"
+		v.Title = decisionTitle(app, audit.DecisionIgnored)
 		v.Frame = true
 		v.Editable = false
 		v.TitleColor = gocui.ColorYellow
@@ -179,7 +161,7 @@ func showIgnoreDialog(g *gocui.Gui, app *AppState) error {
 	}
 	
 	// Input field - 2 lines in the middle (lines 2-3)
-	if v, err := g.SetView("audit_input", maxX/4+1, maxY/3+1, 3*maxX/4-1, maxY/3+3, 0); err != nil {
+	if v, err := setDialogView(g, "audit_input"); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
@@ -210,7 +192,8 @@ func showIgnoreDialog(g *gocui.Gui, app *AppState) error {
 		return closeAuditDialog(g, app)
 	})
 
-	return nil
+	bindCommentEditing(g, app)
+	return showReasonList(g, app)
 }
 
 func updateAcceptDialog(g *gocui.Gui, app *AppState) error {
@@ -224,7 +207,11 @@ func updateAcceptDialog(g *gocui.Gui, app *AppState) error {
 	fmt.Fprintf(v, " Comment (Optional)\n")
 	fmt.Fprintf(v, "\n")
 	fmt.Fprintf(v, "\n")
-	fmt.Fprintf(v, " ENTER: Accept  ESC: Cancel")
+	if len(app.Checklist) > 0 {
+		fmt.Fprintf(v, " ENTER: %s  TAB: Checklist  Ctrl+E: Larger editor  ESC: Cancel", decisionVerb(app, audit.DecisionIdentified))
+	} else {
+		fmt.Fprintf(v, " ENTER: %s  Ctrl+E: Larger editor  ESC: Cancel", decisionVerb(app, audit.DecisionIdentified))
+	}
 	
 	// Clear input field
 	if iv, err := g.View("audit_input"); err == nil {
@@ -246,7 +233,7 @@ func updateIgnoreDialog(g *gocui.Gui, app *AppState) error {
 	fmt.Fprintf(v, " Comment (Optional)\n")
 	fmt.Fprintf(v, "\n")
 	fmt.Fprintf(v, "\n")
-	fmt.Fprintf(v, " ENTER: Ignore  ESC: Cancel")
+	fmt.Fprintf(v, " ENTER: %s  Ctrl+E: Larger editor  ESC: Cancel", decisionVerb(app, audit.DecisionIgnored))
 	
 	// Clear input field
 	if iv, err := g.View("audit_input"); err == nil {
@@ -261,9 +248,7 @@ func updateIgnoreDialog(g *gocui.Gui, app *AppState) error {
 func promptAssessment(g *gocui.Gui, app *AppState, decision string) error {
 	app.PendingDecision = decision
 	
-	maxX, maxY := g.Size()
-	
-	if v, err := g.SetView("assessment_input", maxX/4, maxY/3+5, 3*maxX/4, 2*maxY/3, 0); err != nil {
+	if v, err := setDialogView(g, "assessment_input"); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
@@ -302,19 +287,23 @@ func saveAuditDecision(g *gocui.Gui, app *AppState) error {
 		return err
 	}
 	assessment := strings.TrimSpace(v.Buffer())
+	checklist := pendingChecklist(app)
 
-	decision := AuditDecision{
-		Decision:   app.PendingDecision,
-		Assessment: assessment,
-		Timestamp:  time.Now(),
+	if files := app.DecisionGroup; len(files) > 0 {
+		decision := app.PendingDecision
+		closeAuditDialog(g, app)
+		return decideGroup(g, app, files, decision, assessment, checklist)
 	}
 
-	app.CurrentMatch.AuditCmd = append(app.CurrentMatch.AuditCmd, decision)
+	decidedFile := focusedFile(app)
+	decidedMatch := app.CurrentMatch
+	app.RestoreMatches = nil // The chosen match is kept once decided
+	decision := withChecklist(decidedMatch, recordDecision(app, decidedMatch, app.PendingDecision, assessment), checklist)
+	announce(app, "Marked %s as %s", displayPath(app, decidedFile), decisionLabel(app, decision.Decision))
 
 	if err := saveToFile(app); err != nil {
 		// Show error dialog instead of printf
-		maxX, maxY := g.Size()
-		if v, errView := g.SetView("save_error", maxX/4, maxY/2-2, 3*maxX/4, maxY/2+2, 0); errView != nil {
+		if v, errView := setDialogView(g, "save_error"); errView != nil {
 			if errView != gocui.ErrUnknownView {
 				return errView
 			}
@@ -333,6 +322,8 @@ func saveAuditDecision(g *gocui.Gui, app *AppState) error {
 		return nil
 	}
 
+	afterDecisionSaved(g, app, decidedFile, decidedMatch, decision)
+
 	app.PendingDecision = ""
 	app.PendingAssessment = ""
 	
@@ -349,6 +340,7 @@ func closeAuditDialog(g *gocui.Gui, app *AppState) error {
 	g.DeleteKeybindings("audit_dialog")
 	g.DeleteKeybindings("audit_input")
 	g.DeleteKeybindings("assessment_input")
+	g.DeleteKeybindings("audit_checklist")
 	
 	if err := g.DeleteView("audit_dialog"); err != nil && err != gocui.ErrUnknownView {
 		return err
@@ -362,9 +354,26 @@ func closeAuditDialog(g *gocui.Gui, app *AppState) error {
 		return err
 	}
 
+	if err := g.DeleteView("audit_reasons"); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+
+	if err := g.DeleteView("audit_checklist"); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+
+	// A cancelled accept leaves the matches in their order
+	if app.RestoreMatches != nil {
+		app.RestoreMatches()
+		app.RestoreMatches = nil
+	}
+
 	// Reset pending decision and assessment
+	app.CommentExpanded = false
 	app.PendingDecision = ""
 	app.PendingAssessment = ""
+	app.DecisionGroup = nil
+	app.ChecklistTicks = nil
 	
 	// Clear current match so status pane returns to directory info
 	app.CurrentMatch = nil
@@ -379,8 +388,8 @@ func closeAuditDialog(g *gocui.Gui, app *AppState) error {
 }
 
 func quickAccept(g *gocui.Gui, app *AppState) error {
-	// Only allow when in files pane and in list mode
-	if app.ActivePane != "files" || app.ViewMode != "list" {
+	// Only allow when in files pane, or on a tree leaf, and in list mode
+	if (app.ActivePane != "files" && selectedTreeFile(app) == "") || app.ViewMode != "list" {
 		return nil
 	}
 
@@ -410,16 +419,8 @@ func quickAccept(g *gocui.Gui, app *AppState) error {
 			var matchToUpdate *FileMatch
 			if len(app.CurrentFileList) > 0 && app.SelectedFileIndex >= 0 && app.SelectedFileIndex < len(app.CurrentFileList) {
 				selectedFile := app.CurrentFileList[app.SelectedFileIndex]
-				matches, exists := app.ScanData.Files[selectedFile]
-				if exists && len(matches) > 0 {
-					// Find the first valid match (file or snippet)
-					for i, m := range matches {
-						if m.ID == "file" || m.ID == "snippet" {
-							matchToUpdate = &matches[i]
-							break
-						}
-					}
-				}
+				// Find the first valid match (file or snippet)
+				matchToUpdate = audit.FirstValidMatch(app.ScanData.Files[selectedFile])
 			}
 
 			if matchToUpdate == nil {
@@ -427,20 +428,17 @@ func quickAccept(g *gocui.Gui, app *AppState) error {
 			}
 
 			// Create decision without comment
-			decision := AuditDecision{
-				Decision:   "identified",
-				Assessment: "",
-				Timestamp:  time.Now(),
-			}
-
-			matchToUpdate.AuditCmd = append(matchToUpdate.AuditCmd, decision)
+			app.RestoreMatches = nil
+			decision := recordDecision(app, matchToUpdate, audit.DecisionIdentified, "")
 
 			if err := saveToFile(app); err != nil {
 				return err
 			}
+			afterDecisionSaved(g, app, app.CurrentFileList[app.SelectedFileIndex], matchToUpdate, decision)
 
 			// Clear current match
 			app.CurrentMatch = nil
+			announce(app, "Marked %s as %s", displayPath(app, app.CurrentFileList[app.SelectedFileIndex]), decisionLabel(app, decision.Decision))
 
 			// Update the entire UI to reflect the new status
 			updateFileList(g, app)
@@ -449,7 +447,7 @@ func quickAccept(g *gocui.Gui, app *AppState) error {
 
 			// In filtered views (pending/matched), the next file automatically takes the current position
 			// In "all" view, we need to navigate to the next file
-			if app.ViewFilter == "all" && app.SelectedFileIndex < len(app.CurrentFileList)-1 {
+			if app.ViewFilter == "all" && !app.ReviewMode && app.SelectedFileIndex < len(app.CurrentFileList)-1 {
 				navigateFileList(g, app, "down")
 			}
 
@@ -461,8 +459,8 @@ func quickAccept(g *gocui.Gui, app *AppState) error {
 }
 
 func quickIgnore(g *gocui.Gui, app *AppState) error {
-	// Only allow when in files pane and in list mode
-	if app.ActivePane != "files" || app.ViewMode != "list" {
+	// Only allow when in files pane, or on a tree leaf, and in list mode
+	if (app.ActivePane != "files" && selectedTreeFile(app) == "") || app.ViewMode != "list" {
 		return nil
 	}
 
@@ -492,16 +490,8 @@ func quickIgnore(g *gocui.Gui, app *AppState) error {
 			var matchToUpdate *FileMatch
 			if len(app.CurrentFileList) > 0 && app.SelectedFileIndex >= 0 && app.SelectedFileIndex < len(app.CurrentFileList) {
 				selectedFile := app.CurrentFileList[app.SelectedFileIndex]
-				matches, exists := app.ScanData.Files[selectedFile]
-				if exists && len(matches) > 0 {
-					// Find the first valid match (file or snippet)
-					for i, m := range matches {
-						if m.ID == "file" || m.ID == "snippet" {
-							matchToUpdate = &matches[i]
-							break
-						}
-					}
-				}
+				// Find the first valid match (file or snippet)
+				matchToUpdate = audit.FirstValidMatch(app.ScanData.Files[selectedFile])
 			}
 
 			if matchToUpdate == nil {
@@ -509,20 +499,16 @@ func quickIgnore(g *gocui.Gui, app *AppState) error {
 			}
 
 			// Create decision without comment
-			decision := AuditDecision{
-				Decision:   "ignored",
-				Assessment: "",
-				Timestamp:  time.Now(),
-			}
-
-			matchToUpdate.AuditCmd = append(matchToUpdate.AuditCmd, decision)
+			decision := recordDecision(app, matchToUpdate, audit.DecisionIgnored, "")
 
 			if err := saveToFile(app); err != nil {
 				return err
 			}
+			afterDecisionSaved(g, app, app.CurrentFileList[app.SelectedFileIndex], matchToUpdate, decision)
 
 			// Clear current match
 			app.CurrentMatch = nil
+			announce(app, "Marked %s as %s", displayPath(app, app.CurrentFileList[app.SelectedFileIndex]), decisionLabel(app, decision.Decision))
 
 			// Update the entire UI to reflect the new status
 			updateFileList(g, app)
@@ -531,7 +517,7 @@ func quickIgnore(g *gocui.Gui, app *AppState) error {
 
 			// In filtered views (pending/matched), the next file automatically takes the current position
 			// In "all" view, we need to navigate to the next file
-			if app.ViewFilter == "all" && app.SelectedFileIndex < len(app.CurrentFileList)-1 {
+			if app.ViewFilter == "all" && !app.ReviewMode && app.SelectedFileIndex < len(app.CurrentFileList)-1 {
 				navigateFileList(g, app, "down")
 			}
 
@@ -543,10 +529,102 @@ func quickIgnore(g *gocui.Gui, app *AppState) error {
 }
 
 func saveToFile(app *AppState) error {
-	data, err := json.MarshalIndent(app.ScanData.Files, "", "  ")
-	if err != nil {
+	app.Unexported = true
+	if app.Dependencies != nil {
+		app.Dependencies.Apply(&app.ScanData)
+		return app.Dependencies.Source.Save(app.FilePath)
+	}
+	if app.WriteStatus {
+		app.ScanData.AddStatus()
+	}
+	// Excluded files and those outside --dir are out of the audit, not out
+	// of the results
+	results := app.ScanData.WithFiles(app.Excluded).WithFiles(app.OutsideDir).WithDuplicates(app.Duplicates)
+	if err := results.Save(app.FilePath); err != nil {
 		return err
 	}
+	return syncBOM(app, results)
+}
+
+// afterDecisionSaved runs the integrations that follow a saved decision
+func afterDecisionSaved(g *gocui.Gui, app *AppState, filePath string, match *FileMatch, decision AuditDecision) {
+	integrateDecision(g, app, filePath, match, decision)
+	recordSessionDecisions(g, app, 1)
+	refreshTreeFiles(g, app)
+	refreshPendingOrder(g, app)
+	checkMilestones(g, app)
+}
+
+// integrateDecision passes a saved decision on to the decision hook and the
+// git commit
+func integrateDecision(g *gocui.Gui, app *AppState, filePath string, match *FileMatch, decision AuditDecision) {
+	fireDecisionHook(g, app, filePath, match, decision)
+	if err := recordDecisionForCommit(app, filePath, match, decision); err != nil {
+		showErrorDialog(g, app, "Git Error", fmt.Sprintf("Decision saved but not committed: %v", err))
+	}
+}
+
+// recordSessionDecisions counts saved decisions in the session history,
+// which is written once for all of them
+func recordSessionDecisions(g *gocui.Gui, app *AppState, count int) {
+	if err := recordSessionDecision(app, count); err != nil {
+		showErrorDialog(g, app, "State Error", fmt.Sprintf("Decisions saved but the session history wasn't: %v", err))
+	}
+}
+
+// savedDecision is one decision of a bulk operation, already saved
+type savedDecision struct {
+	filePath string
+	match    *FileMatch
+	decision AuditDecision
+}
+
+// Bulk operations with at least bulkProgressThreshold decisions run the
+// integrations bulkChunkSize decisions at a time behind the progress dialog
+const (
+	bulkProgressThreshold = 100
+	bulkChunkSize         = 25
+)
+
+// afterDecisionsSaved passes every decision of a bulk operation on to the
+// integrations, repaints once with refreshDecisions and then runs done.
+// Hooks and commits can take a while for large groups, so those are
+// processed in chunks while the progress dialog shows how far it got. The
+// session history is written once at the end.
+func afterDecisionsSaved(g *gocui.Gui, app *AppState, title, detail string, saved []savedDecision, done func(g *gocui.Gui) error) error {
+	if len(saved) < bulkProgressThreshold {
+		for _, d := range saved {
+			integrateDecision(g, app, d.filePath, d.match, d.decision)
+		}
+		recordSessionDecisions(g, app, len(saved))
+		refreshDecisions(g, app)
+		return done(g)
+	}
 
-	return ioutil.WriteFile(app.FilePath, data, 0644)
+	task := startBackgroundTask(g, app, detail)
+	task.Message = "Saving decisions..."
+	if err := showProgressDialog(g, app, title, detail, task); err != nil {
+		return err
+	}
+	var step func(start int)
+	step = func(start int) {
+		g.Update(func(g *gocui.Gui) error {
+			end := min(start+bulkChunkSize, len(saved))
+			for _, d := range saved[start:end] {
+				integrateDecision(g, app, d.filePath, d.match, d.decision)
+			}
+			if end < len(saved) {
+				step(end)
+				updateTask(g, app, task, fmt.Sprintf("Processing decision %d of %d...", end, len(saved)), end, len(saved))
+				return nil
+			}
+			finishTask(g, app, task, nil)
+			closeProgressDialog(g, app, task)
+			recordSessionDecisions(g, app, len(saved))
+			refreshDecisions(g, app)
+			return done(g)
+		})
+	}
+	step(0)
+	return nil
 }
\ No newline at end of file
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"auditcmd/pkg/audit"
+)
+
+// autoExports are the artifacts export_on_quit can regenerate, with the
+// suffix replacing the extension of the results file
+var autoExports = []struct {
+	name   string
+	suffix string
+}{
+	{"csv", ".csv"},
+	{"components", "-components.csv"},
+	{"obligations", "-obligations.md"},
+	{"copyrights", "-copyrights.txt"},
+	{"sarif", ".sarif"},
+}
+
+// autoExportPath returns where export_on_quit writes the given artifact,
+// next to the results file
+func autoExportPath(resultsPath, name string) string {
+	base := strings.TrimSuffix(resultsPath, filepath.Ext(resultsPath))
+	for _, export := range autoExports {
+		if export.name == name {
+			return base + export.suffix
+		}
+	}
+	return ""
+}
+
+// parseAutoExports reads a comma-separated export_on_quit value such as
+// "csv,sarif"
+func parseAutoExports(value string) ([]string, error) {
+	names := make([]string, 0)
+	for _, part := range strings.Split(value, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+		if autoExportPath("", name) == "" {
+			return nil, fmt.Errorf("unknown export %q (use csv, components, obligations, copyrights or sarif)", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// exportOnQuit regenerates the export_on_quit artifacts when decisions were
+// saved during the session, so they never drift from the results file. It
+// runs after the UI has closed and returns false if any export failed.
+func exportOnQuit(app *AppState) bool {
+	if len(app.ExportOnQuit) == 0 || !app.Unexported {
+		return true
+	}
+
+	ok := true
+	for _, name := range app.ExportOnQuit {
+		path := autoExportPath(app.FilePath, name)
+		if name != "csv" && name != "components" && app.Redact {
+			// Only the CSV exports know how to redact
+			fmt.Fprintf(os.Stderr, "Warning: not regenerating %s in redacted mode\n", path)
+			continue
+		}
+
+		report := func(format string) *Options {
+			return &Options{Command: name, CommandArgs: []string{app.FilePath}, Format: format, Output: path, Exclude: app.ExcludeGlobs, Dir: app.RootDir}
+		}
+		var code int
+		switch name {
+		case "csv":
+			code = exportCSVOnQuit(app, path, audit.ExportCSV)
+		case "components":
+			code = exportCSVOnQuit(app, path, audit.ExportComponentsCSV)
+		case "obligations":
+			code = runObligations(report("md"))
+		case "copyrights":
+			code = runCopyrights(report("txt"))
+		case "sarif":
+			code = runSARIF(report("sarif"))
+		}
+		// Pending work and policy violations are outcomes, not failures
+		if code == exitError || code == exitParseError {
+			ok = false
+			continue
+		}
+		fmt.Printf("Exported %s to %s\n", name, path)
+	}
+	return ok
+}
+
+// exportCSVOnQuit writes a CSV export, the full one or the SBOM-ready
+// components, without the progress dialog
+func exportCSVOnQuit(app *AppState, path string, export func(io.Writer, *ScanResult, audit.CSVOptions) error) int {
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to create file: %v\n", err)
+		return exitError
+	}
+	defer file.Close()
+
+	if err := export(file, &app.ScanData, csvExportOptions(app)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	return exitClean
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// BlameLine is one line of git blame output
+type BlameLine struct {
+	Line    int
+	Commit  string
+	Author  string
+	Email   string
+	Date    time.Time
+	Summary string
+	Text    string
+}
+
+// blameRangeArgs turns a matched lines field such as "10-20,35-40" into
+// git blame -L arguments. "all" or an empty field blames the whole file.
+func blameRangeArgs(lines string) []string {
+	args := make([]string, 0)
+	if lines == "" || lines == "all" {
+		return args
+	}
+	for _, part := range strings.Split(lines, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		start, end, found := strings.Cut(part, "-")
+		if !found {
+			end = start
+		}
+		if _, err := strconv.Atoi(start); err != nil {
+			continue
+		}
+		if _, err := strconv.Atoi(end); err != nil {
+			continue
+		}
+		args = append(args, "-L", start+","+end)
+	}
+	return args
+}
+
+// gitBlame runs git blame on a file below sourceDir for the given line ranges
+func gitBlame(sourceDir, filePath, lines string) ([]BlameLine, error) {
+	args := []string{"-C", sourceDir, "blame", "--line-porcelain"}
+	args = append(args, blameRangeArgs(lines)...)
+	args = append(args, "--", filepath.FromSlash(filePath))
+
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s", msg)
+		}
+		return nil, err
+	}
+	return parseLinePorcelain(out), nil
+}
+
+// parseLinePorcelain reads the output of git blame --line-porcelain, where
+// every line carries its full commit header
+func parseLinePorcelain(out []byte) []BlameLine {
+	result := make([]BlameLine, 0)
+	var current BlameLine
+	expectHeader := true
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if expectHeader {
+			fields := strings.Fields(line)
+			current = BlameLine{}
+			if len(fields) >= 3 {
+				current.Commit = fields[0]
+				current.Line, _ = strconv.Atoi(fields[2])
+			}
+			expectHeader = false
+			continue
+		}
+		if strings.HasPrefix(line, "\t") {
+			current.Text = strings.TrimPrefix(line, "\t")
+			result = append(result, current)
+			expectHeader = true
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			current.Author = value
+		case "author-mail":
+			current.Email = strings.Trim(value, "<>")
+		case "author-time":
+			if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+				current.Date = time.Unix(ts, 0)
+			}
+		case "summary":
+			current.Summary = value
+		}
+	}
+	return result
+}
+
+// writeBlameReport renders an authorship summary followed by the blamed lines
+func writeBlameReport(b *strings.Builder, lines []BlameLine) {
+	type authorStats struct {
+		name   string
+		lines  int
+		oldest time.Time
+		newest time.Time
+	}
+	byAuthor := make(map[string]*authorStats)
+	for _, line := range lines {
+		name := line.Author
+		if line.Email != "" && line.Email != "not.committed.yet" {
+			name = fmt.Sprintf("%s <%s>", line.Author, line.Email)
+		}
+		stats, ok := byAuthor[name]
+		if !ok {
+			stats = &authorStats{name: name, oldest: line.Date, newest: line.Date}
+			byAuthor[name] = stats
+		}
+		stats.lines++
+		if line.Date.Before(stats.oldest) {
+			stats.oldest = line.Date
+		}
+		if line.Date.After(stats.newest) {
+			stats.newest = line.Date
+		}
+	}
+	authors := make([]*authorStats, 0, len(byAuthor))
+	for _, stats := range byAuthor {
+		authors = append(authors, stats)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].lines != authors[j].lines {
+			return authors[i].lines > authors[j].lines
+		}
+		return authors[i].name < authors[j].name
+	})
+
+	fmt.Fprintf(b, "\033[1mAuthors\033[0m (%d lines)\n", len(lines))
+	for _, stats := range authors {
+		dates := stats.oldest.Format("2006-01-02")
+		if stats.newest.Format("2006-01-02") != dates {
+			dates += " .. " + stats.newest.Format("2006-01-02")
+		}
+		fmt.Fprintf(b, "  %4d  %3d%%  %s  (%s)\n", stats.lines, stats.lines*100/len(lines), stats.name, dates)
+	}
+
+	fmt.Fprintf(b, "\n\033[1mLines\033[0m\n")
+	for _, line := range lines {
+		commit := line.Commit
+		if len(commit) > 8 {
+			commit = commit[:8]
+		}
+		author := line.Author
+		if len(author) > 16 {
+			author = author[:16]
+		}
+		fmt.Fprintf(b, "%5d %s %-16s %s | %s\n", line.Line, commit, author, line.Date.Format("2006-01-02"), line.Text)
+	}
+}
+
+// showBlameDialog shows git authorship of the matched lines of the selected
+// file in the local source tree given with --source
+func showBlameDialog(g *gocui.Gui, app *AppState) error {
+	if app.ActivePane != "files" {
+		return nil
+	}
+	filePath := focusedFile(app)
+	match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+	if match == nil {
+		return nil
+	}
+	if app.SourceDir == "" {
+		return showErrorDialog(g, app, "Blame", "No source directory configured. Start auditcmd with --source <dir> pointing at the scanned git checkout.")
+	}
+
+	v, err := setDialogView(g, "blame_dialog")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	lines := audit.ExtractMatchedLines(match)
+	v.Title = fmt.Sprintf("Blame: %s", displayPath(app, filePath))
+	if lines != "" && lines != "all" {
+		v.Title += fmt.Sprintf(" (lines %s)", lines)
+	}
+	v.Frame = true
+	v.Wrap = false
+	v.Editable = false
+	v.Clear()
+	v.SetOrigin(0, 0)
+	fmt.Fprintf(v, " Running git blame...")
+
+	if _, err := g.SetCurrentView("blame_dialog"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("blame_dialog")
+	g.SetKeybinding("blame_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeBlameDialog(g, app)
+	})
+	g.SetKeybinding("blame_dialog", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return scrollDialog(v, -1)
+	})
+	g.SetKeybinding("blame_dialog", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return scrollDialog(v, 1)
+	})
+	g.SetKeybinding("blame_dialog", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, height := v.Size()
+		return scrollDialog(v, -height)
+	})
+	g.SetKeybinding("blame_dialog", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, height := v.Size()
+		return scrollDialog(v, height)
+	})
+
+	sourceDir := app.SourceDir
+	go func() {
+		blame, err := gitBlame(sourceDir, filePath, lines)
+		g.Update(func(g *gocui.Gui) error {
+			v, viewErr := g.View("blame_dialog")
+			if viewErr != nil {
+				return nil // closed while blame was running
+			}
+			v.Clear()
+
+			var out strings.Builder
+			if err != nil {
+				fmt.Fprintf(&out, "git blame failed: %v\n", err)
+			} else if len(blame) == 0 {
+				fmt.Fprintf(&out, "No lines to blame.\n")
+			} else {
+				writeBlameReport(&out, blame)
+			}
+			fmt.Fprintf(&out, "\nUp/Down/PgUp/PgDn: Scroll  ESC: Close")
+
+			text := out.String()
+			if app.Accessible {
+				text = stripANSI(text)
+			}
+			fmt.Fprint(v, text)
+			return nil
+		})
+	}()
+
+	return nil
+}
+
+// scrollDialog moves the origin of a scrollable dialog by delta lines
+func scrollDialog(v *gocui.View, delta int) error {
+	ox, oy := v.Origin()
+	_, height := v.Size()
+	maxOrigin := v.LinesHeight() - height
+	if maxOrigin < 0 {
+		maxOrigin = 0
+	}
+	oy = min(max(oy+delta, 0), maxOrigin)
+	return v.SetOrigin(ox, oy)
+}
+
+func closeBlameDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("blame_dialog")
+	g.DeleteView("blame_dialog")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"auditcmd/pkg/audit"
+)
+
+// bomOff in bom_file keeps decisions out of any SCANOSS settings file
+const bomOff = "off"
+
+// bomFilePath is the SCANOSS settings file (scanoss.json) whose BOM is kept
+// in line with the decisions: bom_file, relative to the results file, or
+// else the scanoss.json next to the results when there is one. It is ""
+// when there is none.
+func bomFilePath(app *AppState) string {
+	dir := filepath.Dir(app.FilePath)
+	switch {
+	case app.BOMFile == bomOff:
+		return ""
+	case app.BOMFile == "":
+		path := filepath.Join(dir, "scanoss.json")
+		if info, err := os.Stat(path); err != nil || info.IsDir() || filepath.Clean(path) == filepath.Clean(app.FilePath) {
+			return ""
+		}
+		return path
+	case filepath.IsAbs(app.BOMFile):
+		return app.BOMFile
+	}
+	return filepath.Join(dir, app.BOMFile)
+}
+
+// syncBOM records the decisions of scan in the BOM of the SCANOSS settings
+// file, for SCANOSS Workbench and scanoss-py. The file is only written when
+// its BOM changes.
+func syncBOM(app *AppState, scan *ScanResult) error {
+	path := bomFilePath(app)
+	if path == "" {
+		return nil
+	}
+	bom, err := audit.LoadBOM(path)
+	if err != nil {
+		return err
+	}
+	if !bom.Sync(scan) {
+		return nil
+	}
+	if err := bom.Save(path); err != nil {
+		return fmt.Errorf("failed to update %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"path"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// breadcrumbSeparator joins the segments of the Files pane title
+const breadcrumbSeparator = " › "
+
+// breadcrumbNode is the tree node the Files pane lists: the selected
+// directory, or the directory of a selected file leaf
+func breadcrumbNode(app *AppState) *TreeNode {
+	if app.TreeState == nil || app.TreeState.selectedNode == nil {
+		return nil
+	}
+	node := app.TreeState.selectedNode
+	if treeFile(app, node) != "" {
+		node = node.Parent
+	}
+	return node
+}
+
+// breadcrumbSegments names the levels above the listed files, top first:
+// the directories down to the selected one, or the component and upstream
+// file of the PURL and upstream views
+func breadcrumbSegments(app *AppState) []string {
+	node := breadcrumbNode(app)
+	if node == nil {
+		return nil
+	}
+	switch app.TreeViewType {
+	case "purls":
+		return []string{displayPURL(app, node.Name)}
+	case "upstream":
+		segments := []string{displayPURL(app, node.Name)}
+		if len(node.Files) > 0 {
+			if match := audit.FirstValidMatch(app.ScanData.Files[node.Files[0]]); match != nil {
+				segments = append(segments, path.Base(audit.NormalizePath(displayPath(app, match.File))))
+			}
+		}
+		return segments
+	}
+
+	segments := make([]string, 0)
+	for ; node != nil && node != app.FileTree; node = node.Parent {
+		name := node.Name
+		if app.Redact && node.Path != "" {
+			name = redactComponent(name)
+		}
+		segments = append([]string{name}, segments...)
+	}
+	if app.RootDir != "" {
+		segments = append([]string{displayPath(app, app.RootDir)}, segments...)
+	}
+	return segments
+}
+
+// filesBreadcrumb is the Files pane title in list mode, e.g.
+// "Files: src › net › http". Segments are dropped from the top, behind an
+// ellipsis, when the title wouldn't fit in width.
+func filesBreadcrumb(app *AppState, width int) string {
+	segments := breadcrumbSegments(app)
+	if len(segments) == 0 {
+		return "Files"
+	}
+	title := "Files: " + strings.Join(segments, breadcrumbSeparator)
+	for len(segments) > 1 && displayWidth(title) > width {
+		segments = segments[1:]
+		title = "Files: …" + breadcrumbSeparator + strings.Join(segments, breadcrumbSeparator)
+	}
+	return title
+}
+
+// breadcrumbUp selects the directory one level above the listed files, as
+// if the previous breadcrumb segment had been picked
+func breadcrumbUp(g *gocui.Gui, app *AppState) error {
+	if isAuditDialogOpen(g) || app.ViewMode != "list" || groupedView(app) {
+		return nil
+	}
+	node := chainTop(app, breadcrumbNode(app))
+	if node == nil || node.Parent == nil || node.Parent == app.FileTree {
+		announce(app, "Already at the top directory")
+		return nil
+	}
+
+	app.TreeState.selectedNode = displayedDir(app, node.Parent)
+	updateTreeDisplay(app)
+	displayTree(g, app)
+	updateFileList(g, app)
+	updatePaneTitles(g, app)
+	updateStatus(g, app)
+	announceTreeSelection(app)
+	return nil
+}
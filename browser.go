@@ -0,0 +1,87 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// browserCommand opens url in the default browser of the platform
+func browserCommand(url string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	}
+	return exec.Command("xdg-open", url)
+}
+
+// openInBrowser opens the matched file of the focused file, or of the file
+// selected in the tree, in the browser at its first OSS line range
+func openInBrowser(g *gocui.Gui, app *AppState) error {
+	filePath := focusedFile(app)
+	if app.ActivePane == "tree" && app.ViewMode != "content" && app.TreeState.selectedNode != nil {
+		filePath = treeFile(app, app.TreeState.selectedNode)
+	}
+	match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+	if match == nil {
+		return showErrorDialog(g, app, "Open in Browser", "Select a file with a match to open the matched file in the browser.")
+	}
+	if app.Redact {
+		return showErrorDialog(g, app, "Open in Browser", "Deeplinks are hidden while redaction is enabled.")
+	}
+
+	// Resolving the default branch may take a request
+	go func() {
+		link := audit.Deeplinks(match, audit.ExtractLineRanges(match), 1, audit.DefaultBranch)[0]
+		var err error
+		if link != "" {
+			cmd := browserCommand(link)
+			if err = cmd.Start(); err == nil {
+				go cmd.Wait()
+			}
+		}
+		g.Update(func(g *gocui.Gui) error {
+			switch {
+			case link == "":
+				return showErrorDialog(g, app, "Open in Browser", "Deeplinks need a GitHub, GitLab or Bitbucket PURL, and this match has none.")
+			case err != nil:
+				return showErrorDialog(g, app, "Open in Browser", fmt.Sprintf("Failed to open %s: %v", link, err))
+			}
+			announce(app, "Opened %s in the browser", link)
+			return nil
+		})
+	}()
+	return nil
+}
+
+// openComponentPage opens the page of the selected component on the SCANOSS
+// platform, from the component_page_url template
+func openComponentPage(g *gocui.Gui, app *AppState) error {
+	if app.ComponentPageURL == "" {
+		return showErrorDialog(g, app, "Component Page", fmt.Sprintf("Set component_page_url in %s to the component page of your SCANOSS platform, with {purl} where the PURL goes.", getConfigFilePath()))
+	}
+	purl := selectedComponent(app)
+	if purl == "" {
+		return showErrorDialog(g, app, "Component Page", "Select a file with a match, or a component in the PURL or upstream view.")
+	}
+	if app.Redact {
+		return showErrorDialog(g, app, "Component Page", "Component pages are hidden while redaction is enabled.")
+	}
+	page := audit.ComponentPageURL(app.ComponentPageURL, purl)
+	cmd := browserCommand(page)
+	if err := cmd.Start(); err != nil {
+		return showErrorDialog(g, app, "Component Page", fmt.Sprintf("Failed to open %s: %v", page, err))
+	}
+	go cmd.Wait()
+	announce(app, "Opened the page of %s in the browser", purl)
+	return nil
+}
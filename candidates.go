@@ -0,0 +1,139 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// candidateMarker flags open files whose matches disagree on the component
+// or its licenses, until a decision settles which one applies
+func candidateMarker(app *AppState, filePath string) string {
+	matches := app.ScanData.Files[filePath]
+	if audit.MatchCandidates(matches) == nil {
+		return ""
+	}
+	if status := audit.FileStatus(matches); status != audit.StatusPending && status != audit.StatusDeferred {
+		return ""
+	}
+	if app.Accessible {
+		return " [conflicting matches]"
+	}
+	return " \033[33m⚠\033[0m"
+}
+
+// candidateLabel describes a candidate match, e.g.
+// "pkg:github/madler/zlib@1.2.11 (Zlib)"
+func candidateLabel(app *AppState, match *FileMatch) string {
+	label := "(no PURL)"
+	if len(match.Purl) > 0 {
+		label = displayPURL(app, match.Purl[0])
+		if match.Version != "" && !strings.Contains(match.Purl[0], "@") {
+			label += "@" + match.Version
+		}
+	}
+	licenses := make([]string, 0, len(match.Licenses))
+	for _, license := range match.Licenses {
+		licenses = append(licenses, license.Name)
+	}
+	if len(licenses) == 0 {
+		return label + " (no license)"
+	}
+	return label + " (" + strings.Join(licenses, ", ") + ")"
+}
+
+// candidateSummary lists the candidate matches of a file for the status
+// pane, or "" when its matches agree
+func candidateSummary(app *AppState, filePath string) string {
+	matches := app.ScanData.Files[filePath]
+	labels := make([]string, 0)
+	for _, i := range audit.MatchCandidates(matches) {
+		labels = append(labels, candidateLabel(app, &matches[i]))
+	}
+	return strings.Join(labels, "; ")
+}
+
+// chooseCandidate asks which match of filePath an accept applies to when
+// its matches disagree on the component or its licenses, moves the chosen
+// match first and then accepts with accept. The order is put back when the
+// accept dialog is cancelled. Files whose matches agree are accepted right
+// away.
+func chooseCandidate(g *gocui.Gui, app *AppState, filePath string, accept func() error) error {
+	if _, err := g.View("candidate_dialog"); err == nil {
+		return nil
+	}
+	matches := app.ScanData.Files[filePath]
+	candidates := audit.MatchCandidates(matches)
+	if filePath == "" || candidates == nil {
+		return accept()
+	}
+
+	v, err := setDialogView(g, "candidate_dialog")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "Conflicting Matches"
+	v.Frame = true
+	v.Wrap = false
+	v.Clear()
+	fmt.Fprintf(v, "\n The matches of %s disagree. Accept which one?\n\n", displayPath(app, filePath))
+	labels := make([]string, 0, len(candidates))
+	for n, i := range candidates {
+		label := candidateLabel(app, &matches[i])
+		if n == 0 {
+			label += "  [current]"
+		}
+		if n < 9 {
+			fmt.Fprintf(v, "   %d  %s\n", n+1, label)
+		} else {
+			fmt.Fprintf(v, "      %s\n", label)
+		}
+		labels = append(labels, label)
+	}
+	fmt.Fprintf(v, "\n 1-9: Accept that match  ESC: Cancel")
+
+	if _, err := g.SetCurrentView("candidate_dialog"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("candidate_dialog")
+	g.SetKeybinding("candidate_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		announce(app, "Accept cancelled")
+		return closeCandidateDialog(g, app)
+	})
+	for n := 0; n < len(candidates) && n < 9; n++ {
+		index := candidates[n]
+		g.SetKeybinding("candidate_dialog", rune('1'+n), gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			closeCandidateDialog(g, app)
+			original := slices.Clone(matches)
+			audit.PreferMatch(matches, index)
+			app.RestoreMatches = func() { copy(matches, original) }
+			if app.CurrentMatch != nil {
+				app.CurrentMatch = audit.FirstValidMatch(matches)
+			}
+			return accept()
+		})
+	}
+
+	announce(app, "The matches disagree: %s. Press a number to accept one", strings.Join(labels, "; "))
+	return nil
+}
+
+func closeCandidateDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("candidate_dialog")
+	g.DeleteView("candidate_dialog")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
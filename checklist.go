@@ -0,0 +1,149 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// maxChecklistItems is how many review_checklist items can be ticked with 1-9
+const maxChecklistItems = 9
+
+// checklistRect places the checklist right above the accept dialog, as tall
+// as the configured items
+func checklistRect(maxX, maxY int) (int, int, int, int) {
+	items := 0
+	if globalApp != nil {
+		items = len(globalApp.Checklist)
+	}
+	top, left, right := maxY/3, maxX/4, 3*maxX/4
+	if commentExpanded() {
+		top, left, right = maxY/6, maxX/8, 7*maxX/8
+	}
+	return left, max(top-items-2, 0), right, max(top-1, items+1)
+}
+
+// showChecklist lists the review checklist above the accept dialog, where
+// the auditor ticks what was checked before accepting: TAB moves there from
+// the comment field, 1-9 tick or untick an item and TAB or ESC go back
+func showChecklist(g *gocui.Gui, app *AppState) error {
+	app.ChecklistTicks = make([]bool, len(app.Checklist))
+	if len(app.Checklist) == 0 || app.PendingDecision != audit.DecisionIdentified {
+		return nil
+	}
+	if _, err := setDialogView(g, "audit_checklist"); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+
+	back := func(g *gocui.Gui, v *gocui.View) error {
+		if _, err := g.SetCurrentView("audit_input"); err != nil {
+			return err
+		}
+		announce(app, "Comment field")
+		return drawChecklist(g, app)
+	}
+	g.DeleteKeybindings("audit_checklist")
+	g.SetKeybinding("audit_input", gocui.KeyTab, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if _, err := g.SetCurrentView("audit_checklist"); err != nil {
+			return err
+		}
+		announce(app, "Checklist, %d of %d ticked. 1 to %d tick an item, Tab returns to the comment", checkedItems(app), len(app.Checklist), len(app.Checklist))
+		return drawChecklist(g, app)
+	})
+	g.SetKeybinding("audit_checklist", gocui.KeyTab, gocui.ModNone, back)
+	g.SetKeybinding("audit_checklist", gocui.KeyEsc, gocui.ModNone, back)
+	g.SetKeybinding("audit_checklist", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return saveAuditDecision(g, app)
+	})
+	for i := range app.Checklist {
+		i := i
+		g.SetKeybinding("audit_checklist", rune('1'+i), gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			app.ChecklistTicks[i] = !app.ChecklistTicks[i]
+			state := "unticked"
+			if app.ChecklistTicks[i] {
+				state = "ticked"
+			}
+			announce(app, "%s %s", app.Checklist[i], state)
+			return drawChecklist(g, app)
+		})
+	}
+	return drawChecklist(g, app)
+}
+
+func drawChecklist(g *gocui.Gui, app *AppState) error {
+	v, err := g.View("audit_checklist")
+	if err != nil {
+		return nil
+	}
+	v.Title = "Checklist (TAB)"
+	if g.CurrentView() == v {
+		v.Title = "Checklist (1-9: Tick  TAB: Comment)"
+	}
+	v.Frame = true
+	v.Editable = false
+	v.BgColor = gocui.ColorBlack
+	v.FgColor = gocui.ColorYellow
+	v.Clear()
+	for i, item := range app.Checklist {
+		mark := "[ ]"
+		if app.ChecklistTicks[i] {
+			mark = "[x]"
+		}
+		fmt.Fprintf(v, " %s %d: %s\n", mark, i+1, item)
+	}
+	return nil
+}
+
+func checkedItems(app *AppState) int {
+	checked := 0
+	for _, ticked := range app.ChecklistTicks {
+		if ticked {
+			checked++
+		}
+	}
+	return checked
+}
+
+// pendingChecklist is the checklist as ticked in the open accept dialog, to
+// be stored with the decision, or nil when there is none
+func pendingChecklist(app *AppState) []audit.ChecklistItem {
+	if len(app.Checklist) == 0 || app.PendingDecision != audit.DecisionIdentified || len(app.ChecklistTicks) != len(app.Checklist) {
+		return nil
+	}
+	items := make([]audit.ChecklistItem, len(app.Checklist))
+	for i, item := range app.Checklist {
+		items[i] = audit.ChecklistItem{Item: item, Checked: app.ChecklistTicks[i]}
+	}
+	return items
+}
+
+// withChecklist stores checklist with the decision just recorded on match
+func withChecklist(match *FileMatch, decision AuditDecision, checklist []audit.ChecklistItem) AuditDecision {
+	if len(checklist) == 0 {
+		return decision
+	}
+	decision.Checklist = checklist
+	match.AuditCmd[len(match.AuditCmd)-1] = decision
+	return decision
+}
+
+// checklistLabel sums up the checklist stored with the latest decision,
+// e.g. " | Checklist 2/3", or "" when it has none
+func checklistLabel(match *FileMatch) string {
+	latest := match.LatestDecision()
+	if latest == nil || len(latest.Checklist) == 0 {
+		return ""
+	}
+	checked := 0
+	for _, item := range latest.Checklist {
+		if item.Checked {
+			checked++
+		}
+	}
+	return fmt.Sprintf(" | Checklist %d/%d", checked, len(latest.Checklist))
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// checkpointEntry is a checkpoint file found next to the results
+type checkpointEntry struct {
+	Path       string
+	Checkpoint *audit.Checkpoint
+}
+
+// unsafeNameChars are replaced when a checkpoint name is used in a file name
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// checkpointDir is where checkpoints of a results file are kept
+func checkpointDir(resultsPath string) string {
+	return resultsPath + ".checkpoints"
+}
+
+// createCheckpoint saves the current decisions under name
+func createCheckpoint(app *AppState, name string) error {
+	dir := checkpointDir(app.FilePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %v", err)
+	}
+
+	checkpoint := audit.TakeCheckpoint(&app.ScanData, name)
+	slug := strings.Trim(unsafeNameChars.ReplaceAllString(name, "-"), "-")
+	if slug == "" {
+		slug = "checkpoint"
+	}
+	filename := fmt.Sprintf("%s-%s.json", checkpoint.Created.Format("20060102-150405"), slug)
+	return audit.SaveCheckpoint(filepath.Join(dir, filename), checkpoint)
+}
+
+// listCheckpoints returns the saved checkpoints, newest first. Unreadable
+// files are skipped.
+func listCheckpoints(app *AppState) []checkpointEntry {
+	entries := make([]checkpointEntry, 0)
+	files, err := filepath.Glob(filepath.Join(checkpointDir(app.FilePath), "*.json"))
+	if err != nil {
+		return entries
+	}
+	for _, path := range files {
+		if checkpoint, err := audit.LoadCheckpoint(path); err == nil {
+			entries = append(entries, checkpointEntry{Path: path, Checkpoint: checkpoint})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Checkpoint.Created.After(entries[j].Checkpoint.Created)
+	})
+	return entries
+}
+
+// rollbackToCheckpoint restores a checkpoint after saving the current state as
+// a new one, so the rollback itself can be undone
+func rollbackToCheckpoint(app *AppState, entry checkpointEntry) error {
+	if err := createCheckpoint(app, "before rollback to "+entry.Checkpoint.Name); err != nil {
+		return err
+	}
+	entry.Checkpoint.Restore(&app.ScanData)
+	app.CurrentMatch = nil
+	return saveToFile(app)
+}
+
+// showCheckpointDialog lists the checkpoints of the results file
+func showCheckpointDialog(g *gocui.Gui, app *AppState) error {
+	v, err := setDialogView(g, "checkpoint_dialog")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "CHECKPOINTS  n: New  ENTER: Roll back  Del: Delete  ESC: Close"
+	v.Frame = true
+	v.Editable = false
+	v.TitleColor = gocui.ColorYellow
+	v.BgColor = gocui.ColorBlack
+	v.FgColor = gocui.ColorYellow
+
+	entries := listCheckpoints(app)
+	list := NewScrollableList(nil)
+	list.Plain = app.Accessible
+	list.ShowScrollbar = false
+
+	render := func(v *gocui.View) {
+		items := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			cp := entry.Checkpoint
+			items = append(items, fmt.Sprintf(" %s  %-30s  %d audited", formatTimestamp(app, cp.Created), cp.Name, cp.Audited))
+		}
+		list.SetItems(items)
+		list.Render(v, true)
+		if len(entries) == 0 {
+			fmt.Fprintf(v, " No checkpoints yet. Press n to save the current decisions.")
+		}
+	}
+	render(v)
+
+	if _, err := g.SetCurrentView("checkpoint_dialog"); err != nil {
+		return err
+	}
+
+	selected := func() (checkpointEntry, bool) {
+		if len(entries) == 0 {
+			return checkpointEntry{}, false
+		}
+		return entries[list.GetSelectedIndex()], true
+	}
+
+	g.DeleteKeybindings("checkpoint_dialog")
+	g.SetKeybinding("checkpoint_dialog", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		list.Navigate("up")
+		list.Render(v, true)
+		return nil
+	})
+	g.SetKeybinding("checkpoint_dialog", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		list.Navigate("down")
+		list.Render(v, true)
+		return nil
+	})
+	g.SetKeybinding("checkpoint_dialog", 'n', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return showCheckpointNameInput(g, app)
+	})
+	g.SetKeybinding("checkpoint_dialog", gocui.KeyDelete, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		entry, ok := selected()
+		if !ok {
+			return nil
+		}
+		if err := os.Remove(entry.Path); err != nil {
+			return showErrorDialog(g, app, "Checkpoint Error", fmt.Sprintf("Failed to delete checkpoint: %v", err))
+		}
+		announce(app, "Deleted checkpoint %s", entry.Checkpoint.Name)
+		entries = listCheckpoints(app)
+		render(v)
+		return nil
+	})
+	g.SetKeybinding("checkpoint_dialog", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		entry, ok := selected()
+		if !ok {
+			return nil
+		}
+		closeCheckpointDialog(g, app)
+		if err := rollbackToCheckpoint(app, entry); err != nil {
+			return showErrorDialog(g, app, "Checkpoint Error", fmt.Sprintf("Rollback failed: %v", err))
+		}
+		announce(app, "Rolled back to checkpoint %s", entry.Checkpoint.Name)
+
+		refreshDecisions(g, app)
+		return nil
+	})
+	g.SetKeybinding("checkpoint_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeCheckpointDialog(g, app)
+	})
+
+	return nil
+}
+
+// showCheckpointNameInput asks for the name of a new checkpoint
+func showCheckpointNameInput(g *gocui.Gui, app *AppState) error {
+	v, err := setDialogView(g, "checkpoint_input")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "Checkpoint name (ENTER: Save, ESC: Cancel)"
+	v.Frame = true
+	v.Editable = true
+	v.Clear()
+	v.SetCursor(0, 0)
+
+	if _, err := g.SetCurrentView("checkpoint_input"); err != nil {
+		return err
+	}
+
+	closeInput := func(g *gocui.Gui) {
+		g.DeleteKeybindings("checkpoint_input")
+		g.DeleteView("checkpoint_input")
+	}
+
+	g.DeleteKeybindings("checkpoint_input")
+	g.SetKeybinding("checkpoint_input", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		name := strings.TrimSpace(v.Buffer())
+		if name == "" {
+			name = "checkpoint " + formatTimestamp(app, time.Now())
+		}
+		closeInput(g)
+		if err := createCheckpoint(app, name); err != nil {
+			closeCheckpointDialog(g, app)
+			return showErrorDialog(g, app, "Checkpoint Error", fmt.Sprintf("Failed to save checkpoint: %v", err))
+		}
+		announce(app, "Saved checkpoint %s", name)
+		// Reopen the list so the new checkpoint shows up
+		return showCheckpointDialog(g, app)
+	})
+	g.SetKeybinding("checkpoint_input", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		closeInput(g)
+		_, err := g.SetCurrentView("checkpoint_dialog")
+		return err
+	})
+
+	return nil
+}
+
+func closeCheckpointDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("checkpoint_dialog")
+	g.DeleteView("checkpoint_dialog")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
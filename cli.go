@@ -0,0 +1,273 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"auditcmd/pkg/audit"
+)
+
+// Options holds everything that can be set from the command line
+type Options struct {
+	ResultsPath   string
+	OpenFile      string // file of the scan to open at, after the results file
+	ResetAPIKey   bool
+	APIKeyStatus  bool
+	EncryptAPIKey bool
+	Accessible    bool
+	Redact        bool
+	Review        bool
+	SourceDir     string
+	Baseline      string
+	WFPPath       string
+	Exclude       []string // --exclude globs, see audit.CompileExcludes
+	Dir           string   // --dir: audit only this subdirectory of the scan
+	Filter        string   // --filter: view filter for this run, instead of the saved one
+	View          string   // --view: tree view to start in
+
+	// Subcommand, e.g. "diff", with its positional arguments
+	Command     string
+	CommandArgs []string
+
+	// Report subcommands: output format and file (stdout when empty)
+	Format string
+	Output string
+
+	// Apply makes "import" record the decisions instead of previewing them
+	Apply bool
+}
+
+// reportFormats lists the formats each report subcommand can write
+var reportFormats = map[string][]string{
+	"obligations": {"md", "csv"},
+	"copyrights":  {"txt", "md"},
+	"crypto":      {"md", "csv"},
+	"push":        {"sw360", "fossology"}, // selected with --to
+	"sarif":       {"sarif"},
+	"badge":       {"svg", "json"},
+}
+
+// errNoResultsFile is returned with the options when no results file is
+// given, so one of the recently opened ones can be offered instead
+var errNoResultsFile = errors.New("missing results file")
+
+// viewFilters and treeViews are the values of --filter and --view
+var (
+	viewFilters = []string{audit.FilterAll, audit.FilterMatched, audit.FilterPending, audit.FilterDeferred}
+	treeViews   = []string{"directories", "purls", "upstream"}
+)
+
+// parseArgs reads the command line. Flags may appear before or after the
+// results file so both "auditcmd --accessible scan.json" and
+// "auditcmd scan.json --accessible" work. A second file names the scanned
+// file to open at.
+func parseArgs(args []string) (*Options, error) {
+	opts := &Options{}
+
+	if len(args) > 0 && args[0] == "diff" {
+		if len(args) != 3 {
+			return nil, fmt.Errorf("diff needs exactly two results files: diff <old.json> <new.json>")
+		}
+		opts.Command = args[0]
+		opts.CommandArgs = args[1:]
+		return opts, nil
+	}
+
+	if len(args) > 0 && args[0] == "import" {
+		opts.Command = args[0]
+		for _, arg := range args[1:] {
+			switch {
+			case arg == "--apply":
+				opts.Apply = true
+			case strings.HasPrefix(arg, "-"):
+				return nil, fmt.Errorf("unknown option for import: %s", arg)
+			default:
+				opts.CommandArgs = append(opts.CommandArgs, arg)
+			}
+		}
+		if len(opts.CommandArgs) != 2 {
+			return nil, fmt.Errorf("import needs a results file and a CSV file: import <results.json> <decisions.csv> [--apply]")
+		}
+		return opts, nil
+	}
+
+	if len(args) > 0 && args[0] == "completion" {
+		if len(args) != 2 || !slices.Contains(completionShells, args[1]) {
+			return nil, fmt.Errorf("completion needs a shell: completion %s", strings.Join(completionShells, "|"))
+		}
+		opts.Command = args[0]
+		opts.CommandArgs = args[1:]
+		return opts, nil
+	}
+
+	if len(args) > 0 && reportFormats[args[0]] != nil {
+		return parseReportArgs(args[0], args[1:])
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--reset-api-key":
+			opts.ResetAPIKey = true
+		case "--api-key-status":
+			opts.APIKeyStatus = true
+		case "--encrypt-api-key":
+			opts.EncryptAPIKey = true
+		case "--accessible":
+			opts.Accessible = true
+		case "--redact":
+			opts.Redact = true
+		case "--review":
+			opts.Review = true
+		case "--baseline":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a results file", arg)
+			}
+			i++
+			opts.Baseline = args[i]
+		case "--wfp":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a .wfp file", arg)
+			}
+			i++
+			opts.WFPPath = args[i]
+		case "--exclude":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a glob pattern", arg)
+			}
+			i++
+			opts.Exclude = append(opts.Exclude, args[i])
+		case "--dir":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a directory of the scan", arg)
+			}
+			i++
+			opts.Dir = audit.CleanDirectory(args[i])
+		case "--filter":
+			if i+1 >= len(args) || !slices.Contains(viewFilters, args[i+1]) {
+				return nil, fmt.Errorf("%s requires one of: %s", arg, strings.Join(viewFilters, ", "))
+			}
+			i++
+			opts.Filter = args[i]
+		case "--view":
+			if i+1 >= len(args) || !slices.Contains(treeViews, args[i+1]) {
+				return nil, fmt.Errorf("%s requires one of: %s", arg, strings.Join(treeViews, ", "))
+			}
+			i++
+			opts.View = args[i]
+		case "--source":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a directory", arg)
+			}
+			i++
+			opts.SourceDir = args[i]
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return nil, fmt.Errorf("unknown option: %s", arg)
+			}
+			switch {
+			case opts.ResultsPath == "":
+				opts.ResultsPath = arg
+			case opts.OpenFile == "":
+				opts.OpenFile = arg
+			default:
+				return nil, fmt.Errorf("only one file can be opened (got %s and %s)", opts.OpenFile, arg)
+			}
+		}
+	}
+
+	if opts.ResultsPath == "" && !opts.ResetAPIKey && !opts.APIKeyStatus && !opts.EncryptAPIKey {
+		return opts, errNoResultsFile
+	}
+
+	return opts, nil
+}
+
+// parseReportArgs reads "<report> <results.json> [--format f] [--output file]",
+// or "push <results.json> [--to target]", each with any number of --exclude
+// and an optional --dir
+func parseReportArgs(command string, args []string) (*Options, error) {
+	formats := reportFormats[command]
+	opts := &Options{Command: command, Format: formats[0]}
+	formatFlag, formatName := "--format", "format"
+	if command == "push" {
+		formatFlag, formatName = "--to", "target"
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == formatFlag:
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires one of: %s", arg, strings.Join(formats, ", "))
+			}
+			i++
+			opts.Format = args[i]
+		case arg == "--output" && command != "push":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a file name", arg)
+			}
+			i++
+			opts.Output = args[i]
+		case arg == "--exclude":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a glob pattern", arg)
+			}
+			i++
+			opts.Exclude = append(opts.Exclude, args[i])
+		case arg == "--dir":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a directory of the scan", arg)
+			}
+			i++
+			opts.Dir = audit.CleanDirectory(args[i])
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return nil, fmt.Errorf("unknown option for %s: %s", command, arg)
+			}
+			opts.CommandArgs = append(opts.CommandArgs, arg)
+		}
+	}
+
+	if len(opts.CommandArgs) != 1 {
+		return nil, fmt.Errorf("%s needs exactly one results file", command)
+	}
+	if !slices.Contains(formats, opts.Format) {
+		return nil, fmt.Errorf("unknown %s %s %q (use %s)", command, formatName, opts.Format, strings.Join(formats, " or "))
+	}
+	return opts, nil
+}
+
+func printUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [options] <scanoss-result.json> [path/to/file]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s  (resume a recently opened results file, or browse for one)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s --reset-api-key   (reset stored API key)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s --api-key-status  (check API key status)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s --encrypt-api-key  (encrypt the stored API key)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s diff <old.json> <new.json>  (list findings added or removed since an earlier scan)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s obligations <results.json> [--format md|csv] [--output <file>] [--exclude <glob>] [--dir <dir>]  (license obligations of accepted components)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s copyrights <results.json> [--format txt|md] [--output <file>] [--exclude <glob>] [--dir <dir>]  (copyright notices of accepted components)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s crypto <results.json> [--format md|csv] [--output <file>] [--exclude <glob>] [--dir <dir>]  (cryptographic algorithms for export-control reviews)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s sarif <results.json> [--output <file>] [--exclude <glob>] [--dir <dir>]  (pending matches for code scanning dashboards)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s badge <results.json> [--format svg|json] [--output <file>] [--exclude <glob>] [--dir <dir>]  (audit progress badge for READMEs and CI)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s import <results.json> <decisions.csv> [--apply]  (preview or apply decisions edited in an exported CSV)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s push <results.json> [--to sw360|fossology] [--exclude <glob>] [--dir <dir>]  (send accepted components to a compliance server)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s completion bash|zsh|fish  (print a shell completion script)\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "\nOptions:\n")
+	fmt.Fprintf(os.Stderr, "  --accessible      plain output for screen readers (no colour or box drawing)\n")
+	fmt.Fprintf(os.Stderr, "  --redact          hash file paths and PURLs in the UI and exports\n")
+	fmt.Fprintf(os.Stderr, "  --review          second review: decided files are the queue, decisions are recorded as second opinions\n")
+	fmt.Fprintf(os.Stderr, "  --source <dir>    local checkout that was scanned, for blame, hash checks and re-scans\n")
+	fmt.Fprintf(os.Stderr, "  --baseline <file> earlier results to compare against; [N] shows only new findings\n")
+	fmt.Fprintf(os.Stderr, "  --wfp <file>      fingerprints of the scan, to show local snippet coverage\n")
+	fmt.Fprintf(os.Stderr, "  --exclude <glob>  leave matching paths out of the audit, e.g. 'node_modules/**' (repeatable, also for reports)\n")
+	fmt.Fprintf(os.Stderr, "  --dir <dir>       audit only this subdirectory of the scan (also for reports)\n")
+	fmt.Fprintf(os.Stderr, "  --filter <filter> start with this view filter instead of the saved one: all, matched or pending\n")
+	fmt.Fprintf(os.Stderr, "  --view <view>     start in this tree view: directories, purls or upstream\n")
+}
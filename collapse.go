@@ -0,0 +1,179 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Values of collapse_completed: what happens to expanded directories left
+// without open files by a bulk decision
+const (
+	collapseAsk    = "ask"    // Default: offer to collapse them
+	collapseAlways = "always" // Collapse them without asking
+	collapseNever  = "never"  // Leave the tree as it is
+)
+
+var collapseModes = []string{collapseAsk, collapseAlways, collapseNever}
+
+// completedDirs returns the expanded directories, and the expanded
+// components of the PURL view, whose matched files are all identified or
+// ignored, top first
+func completedDirs(app *AppState) []string {
+	dirs := make([]string, 0)
+	for dir, expanded := range app.TreeState.expandedDirs {
+		if !expanded || dir == "" {
+			continue
+		}
+		files := expandedFiles(app, dir)
+		if len(files) == 0 {
+			continue
+		}
+		completed := true
+		for _, filePath := range files {
+			if status := audit.FileStatus(app.ScanData.Files[filePath]); status != audit.StatusIdentified && status != audit.StatusIgnored {
+				completed = false
+				break
+			}
+		}
+		if completed {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// expandedFiles returns the matched files of an expanded tree node: a
+// directory, or a component of the PURL view
+func expandedFiles(app *AppState, key string) []string {
+	purl, isComponent := strings.CutPrefix(key, "purl:")
+	if !isComponent {
+		return audit.FilesInDirectory(&app.ScanData, key, audit.FilterMatched)
+	}
+	if app.TreeViewType != "purls" {
+		return nil
+	}
+	for _, entry := range app.PURLRanking {
+		if entry.PURL == purl {
+			return filterScope(app, entry.Files)
+		}
+	}
+	return nil
+}
+
+// expandedLabel names an expanded tree node in the collapse dialog
+func expandedLabel(app *AppState, key string) string {
+	if purl, isComponent := strings.CutPrefix(key, "purl:"); isComponent {
+		return displayPURL(app, purl)
+	}
+	return displayPath(app, key)
+}
+
+// offerCollapseCompleted follows a bulk decision: directories and components
+// it finished are collapsed, at once or after asking, so the tree shows
+// remaining work. before is what completedDirs returned ahead of the
+// decision; those were finished already and are left as the user had them.
+func offerCollapseCompleted(g *gocui.Gui, app *AppState, before []string) error {
+	if app.CollapseCompleted == collapseNever {
+		return nil
+	}
+	dirs := make([]string, 0)
+	for _, dir := range completedDirs(app) {
+		if !slices.Contains(before, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+	if app.CollapseCompleted == collapseAlways {
+		collapseDirs(g, app, dirs)
+		return nil
+	}
+
+	v, err := setDialogView(g, "collapse_confirm")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "Completed Directories"
+	v.Frame = true
+	v.Editable = false
+	v.Clear()
+	names := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		names = append(names, expandedLabel(app, dir))
+	}
+	if len(dirs) == 1 {
+		fmt.Fprintf(v, " %s has no open files left.\n Collapse it to focus on the remaining work?\n\n", names[0])
+	} else {
+		fmt.Fprintf(v, " %d expanded directories have no open files left: %s\n Collapse them to focus on the remaining work?\n\n", len(dirs), strings.Join(names, ", "))
+	}
+	fmt.Fprintf(v, " Y or ENTER: Collapse  N or ESC: Keep the tree")
+
+	if _, err := g.SetCurrentView("collapse_confirm"); err != nil {
+		return err
+	}
+	announce(app, "%d directories have no open files left. Collapse them? Y collapses, N keeps the tree", len(dirs))
+
+	collapse := func(g *gocui.Gui, v *gocui.View) error {
+		closeCollapseDialog(g, app)
+		collapseDirs(g, app, dirs)
+		return nil
+	}
+	keep := func(g *gocui.Gui, v *gocui.View) error {
+		announce(app, "Tree kept as it is")
+		return closeCollapseDialog(g, app)
+	}
+	g.DeleteKeybindings("collapse_confirm")
+	for _, key := range []interface{}{'y', 'Y', gocui.KeyEnter} {
+		g.SetKeybinding("collapse_confirm", key, gocui.ModNone, collapse)
+	}
+	for _, key := range []interface{}{'n', 'N', gocui.KeyEsc} {
+		g.SetKeybinding("collapse_confirm", key, gocui.ModNone, keep)
+	}
+	return nil
+}
+
+// collapseDirs collapses dirs. A selection inside them moves up to the
+// highest collapsed directory above it.
+func collapseDirs(g *gocui.Gui, app *AppState, dirs []string) {
+	for _, dir := range dirs {
+		app.TreeState.expandedDirs[dir] = false
+	}
+	selected := app.TreeState.selectedNode
+	if selected != nil && groupedView(app) {
+		// A version moves up to its collapsed component
+		if parent := selected.Parent; parent != nil && !app.TreeState.expandedDirs[parent.Path] {
+			app.TreeState.selectedNode = parent
+		}
+	} else if selected != nil {
+		for node := selected.Parent; node != nil && node != app.FileTree; node = node.Parent {
+			if !app.TreeState.expandedDirs[displayedDir(app, node).Path] {
+				app.TreeState.selectedNode = node
+			}
+		}
+	}
+	refreshScope(g, app)
+	announce(app, "Collapsed %d completed directories", len(dirs))
+}
+
+func closeCollapseDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("collapse_confirm")
+	g.DeleteView("collapse_confirm")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
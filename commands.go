@@ -0,0 +1,179 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// CustomCommand is a shell command bound to a key in the configuration file:
+//
+//	command.o    = code-search {path}       (runs with the TUI suspended)
+//	command_bg.b = open {deeplink}          (runs in the background)
+type CustomCommand struct {
+	Key        rune
+	Template   string
+	Background bool
+}
+
+// reservedKeys are bound by the application and can't be used for commands
+const reservedKeys = "aAiIeEtTpPdDkKbBnNcChHrRlLxXsSoOvV<>mMfFuUwWqQ/ []}+-zZgGjJyY%:"
+
+// parseCommandKey validates the key part of a command.<key> config entry
+func parseCommandKey(name string) (rune, error) {
+	key, size := utf8.DecodeRuneInString(name)
+	if key == utf8.RuneError || size != len(name) {
+		return 0, fmt.Errorf("command key %q must be a single character", name)
+	}
+	if strings.ContainsRune(reservedKeys, key) {
+		return 0, fmt.Errorf("command key %q is already used by auditcmd", name)
+	}
+	return key, nil
+}
+
+// sortedCommands returns the configured commands ordered by key
+func sortedCommands(commands map[rune]CustomCommand) []CustomCommand {
+	list := make([]CustomCommand, 0, len(commands))
+	for _, cmd := range commands {
+		list = append(list, cmd)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list
+}
+
+// shellQuote quotes a value so it is passed to the shell as a single argument
+func shellQuote(value string) string {
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// findingFields returns the values available to command and ticket templates
+func findingFields(app *AppState, filePath string, match *FileMatch, withDeeplink bool) map[string]string {
+	fields := map[string]string{
+		"path":       filePath,
+		"purl":       "",
+		"deeplink":   "",
+		"file":       "",
+		"url":        "",
+		"license":    "",
+		"lines":      "",
+		"oss_lines":  "",
+		"decision":   audit.FileStatus(app.ScanData.Files[filePath]),
+		"assessment": "",
+		"results":    app.FilePath,
+	}
+	if match == nil {
+		return fields
+	}
+
+	if len(match.Purl) > 0 {
+		fields["purl"] = match.Purl[0]
+	}
+	licenses := make([]string, 0, len(match.Licenses))
+	for _, license := range match.Licenses {
+		licenses = append(licenses, license.Name)
+	}
+	fields["license"] = strings.Join(licenses, ", ")
+	fields["file"] = match.File
+	fields["url"] = match.URL
+	fields["lines"] = audit.ExtractMatchedLines(match)
+	fields["oss_lines"] = audit.ExtractLineRanges(match)
+	if latest := match.LatestDecision(); latest != nil {
+		fields["assessment"] = latest.Assessment
+	}
+	if withDeeplink {
+		fields["deeplink"] = audit.Deeplinks(match, audit.ExtractLineRanges(match), 1, audit.DefaultBranch)[0]
+	}
+	return fields
+}
+
+// expandTemplate replaces {name} placeholders with fields, passing each value
+// through quote first
+func expandTemplate(template string, fields map[string]string, quote func(string) string) string {
+	pairs := make([]string, 0, len(fields)*2)
+	for name, value := range fields {
+		pairs = append(pairs, "{"+name+"}", quote(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+// expandCommand fills {path}, {purl}, {deeplink}, {file}, {url}, {results}
+// and the other finding fields in a command template with shell-quoted values
+func expandCommand(template string, app *AppState, filePath string, match *FileMatch) string {
+	fields := findingFields(app, filePath, match, strings.Contains(template, "{deeplink}"))
+	return expandTemplate(template, fields, shellQuote)
+}
+
+// registerCommandKeybindings binds every configured command to its key
+func registerCommandKeybindings(g *gocui.Gui, app *AppState) error {
+	for _, command := range app.Commands {
+		command := command
+		if err := g.SetKeybinding("", command.Key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			if isAuditDialogOpen(g) {
+				return nil
+			}
+			return runCustomCommand(g, app, command)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runCustomCommand runs a command for the file being viewed or selected
+func runCustomCommand(g *gocui.Gui, app *AppState, command CustomCommand) error {
+	if app.ActivePane != "files" {
+		return nil
+	}
+	filePath := focusedFile(app)
+	if filePath == "" {
+		return nil
+	}
+	line := expandCommand(command.Template, app, filePath, audit.FirstValidMatch(app.ScanData.Files[filePath]))
+
+	if command.Background {
+		go func() {
+			cmd := shellCommand(context.Background(), line)
+			if output, err := cmd.CombinedOutput(); err != nil {
+				message := fmt.Sprintf("Command for key '%c' failed: %v\n%s", command.Key, err, strings.TrimSpace(string(output)))
+				g.Update(func(g *gocui.Gui) error {
+					return showErrorDialog(g, app, "Command Error", message)
+				})
+			}
+		}()
+		return nil
+	}
+
+	// Hand the terminal to the command, then wait for the user before redrawing
+	gocui.Suspend()
+	cmd := shellCommand(context.Background(), line)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+	if runErr != nil {
+		fmt.Printf("\nCommand failed: %v\n", runErr)
+	}
+	fmt.Print("\nPress Enter to return to auditcmd...")
+	bufio.NewReader(os.Stdin).ReadString('\n')
+
+	if err := gocui.Resume(); err != nil {
+		return err
+	}
+	g.Update(func(g *gocui.Gui) error { return nil })
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// commentExpanded reports whether the accept or ignore comment is being
+// written in the large editor. The dialog geometry depends on it.
+func commentExpanded() bool {
+	return globalApp != nil && globalApp.CommentExpanded
+}
+
+// bindCommentEditing sets up cursor movement and Ctrl+E in the comment
+// field of the accept and ignore dialogs. The arrow keys are bound here
+// because the global ones would otherwise take them from the editor.
+func bindCommentEditing(g *gocui.Gui, app *AppState) {
+	moves := map[gocui.Key][2]int{
+		gocui.KeyArrowUp:    {0, -1},
+		gocui.KeyArrowDown:  {0, 1},
+		gocui.KeyArrowLeft:  {-1, 0},
+		gocui.KeyArrowRight: {1, 0},
+	}
+	for key, move := range moves {
+		dx, dy := move[0], move[1]
+		g.SetKeybinding("audit_input", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			v.MoveCursor(dx, dy)
+			return nil
+		})
+	}
+	g.SetKeybinding("audit_input", gocui.KeyCtrlE, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return toggleCommentEditor(g, app)
+	})
+}
+
+// toggleCommentEditor grows the comment field into a multi-line editor, in
+// which ENTER starts a new line and Ctrl+S saves, or shrinks it back
+func toggleCommentEditor(g *gocui.Gui, app *AppState) error {
+	app.CommentExpanded = !app.CommentExpanded
+	if _, err := setDialogView(g, "audit_dialog"); err != nil {
+		return err
+	}
+	if _, err := setDialogView(g, "audit_input"); err != nil {
+		return err
+	}
+	if _, err := g.View("audit_checklist"); err == nil {
+		if _, err := setDialogView(g, "audit_checklist"); err != nil {
+			return err
+		}
+	}
+
+	g.DeleteKeybinding("audit_input", gocui.KeyEnter, gocui.ModNone)
+	g.DeleteKeybinding("audit_input", gocui.KeyCtrlS, gocui.ModNone)
+	if app.CommentExpanded {
+		// The reasons would cover the editor; their number keys still work
+		g.DeleteView("audit_reasons")
+		g.SetKeybinding("audit_input", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			v.EditNewLine()
+			return nil
+		})
+		g.SetKeybinding("audit_input", gocui.KeyCtrlS, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			return saveAuditDecision(g, app)
+		})
+		announce(app, "Multi-line comment. ENTER starts a new line, Ctrl+S saves, Ctrl+E returns to the short field")
+	} else {
+		g.SetKeybinding("audit_input", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			return saveAuditDecision(g, app)
+		})
+		if err := showReasonList(g, app); err != nil {
+			return err
+		}
+		announce(app, "Short comment field")
+	}
+	if _, err := g.SetCurrentView("audit_input"); err != nil {
+		return err
+	}
+	return writeCommentDialog(g, app)
+}
+
+// writeCommentDialog draws the frame text of the accept or ignore dialog:
+// the label on the first line and the keys on the last
+func writeCommentDialog(g *gocui.Gui, app *AppState) error {
+	v, err := g.View("audit_dialog")
+	if err != nil {
+		return err
+	}
+	action := decisionVerb(app, app.PendingDecision)
+
+	v.Clear()
+	fmt.Fprintf(v, " Comment (Optional)\n")
+	checklist := ""
+	if len(app.Checklist) > 0 && app.PendingDecision == audit.DecisionIdentified {
+		checklist = "  TAB: Checklist"
+	}
+	if !app.CommentExpanded {
+		fmt.Fprintf(v, "\n\n")
+		fmt.Fprintf(v, " ENTER: %s%s  Ctrl+E: Larger editor  ESC: Cancel", action, checklist)
+		return nil
+	}
+	_, height := v.Size()
+	fmt.Fprint(v, strings.Repeat("\n", max(height-2, 0)))
+	fmt.Fprintf(v, " Ctrl+S: %s  ENTER: New line%s  Ctrl+E: Short field  ESC: Cancel", action, checklist)
+	return nil
+}
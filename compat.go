@@ -0,0 +1,68 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"auditcmd/pkg/audit"
+)
+
+// conflictMarker flags files matched to components whose license can't be
+// used under the configured project_license
+func conflictMarker(app *AppState, filePath string) string {
+	if app.ProjectLicense == "" {
+		return ""
+	}
+	match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+	if len(audit.LicenseConflicts(app.ProjectLicense, match)) == 0 {
+		return ""
+	}
+	if app.Accessible {
+		return " [incompatible license]"
+	}
+	return " \033[31m!license\033[0m"
+}
+
+// staleMarker flags files matched to components released, or last pushed
+// to, longer ago than stale_release_years or stale_push_years
+func staleMarker(app *AppState, filePath string) string {
+	match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+	if len(audit.StaleReasons(match, app.Stale, time.Now())) == 0 {
+		return ""
+	}
+	if app.Accessible {
+		return " [stale component]"
+	}
+	return " \033[33mstale\033[0m"
+}
+
+// staleThresholds are the stale_release_years and stale_push_years settings
+// of config
+func staleThresholds(config *Config) audit.StaleThresholds {
+	return audit.StaleThresholds{
+		ReleaseYears: staleYears(config.StaleReleaseYears, audit.DefaultStaleReleaseYears),
+		PushYears:    staleYears(config.StalePushYears, audit.DefaultStalePushYears),
+	}
+}
+
+// staleYears is the threshold of a stale_*_years setting: 0 when it is off
+func staleYears(setting, defaultYears int) int {
+	switch {
+	case setting < 0:
+		return 0
+	case setting == 0:
+		return defaultYears
+	}
+	return setting
+}
+
+// staleYearsSetting writes a stale_*_years setting back to the config
+func staleYearsSetting(setting int) string {
+	if setting < 0 {
+		return "off"
+	}
+	return strconv.Itoa(setting)
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// completionShells lists the shells "completion" writes scripts for
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// completionFlag is a command line flag and what follows it: "" for a
+// switch, "file", "dir" or a fixed list of values
+type completionFlag struct {
+	name        string
+	description string
+	arg         string
+	values      []string
+}
+
+// completionCommand is a subcommand as offered by shell completion
+type completionCommand struct {
+	name        string
+	description string
+	flags       []completionFlag
+	values      []string // Fixed positional values, instead of files
+}
+
+// globalFlags are the flags of the interactive auditor
+var globalFlags = []completionFlag{
+	{name: "--accessible", description: "plain output for screen readers"},
+	{name: "--redact", description: "hash file paths and PURLs in the UI and exports"},
+	{name: "--review", description: "second review of decided files"},
+	{name: "--source", description: "local checkout that was scanned", arg: "dir"},
+	{name: "--baseline", description: "earlier results to compare against", arg: "file"},
+	{name: "--wfp", description: "fingerprints of the scan", arg: "file"},
+	{name: "--exclude", description: "leave matching paths out of the audit", arg: "dir"},
+	{name: "--dir", description: "audit only this subdirectory of the scan", arg: "dir"},
+	{name: "--filter", description: "view filter to start with", values: viewFilters},
+	{name: "--view", description: "tree view to start in", values: treeViews},
+	{name: "--reset-api-key", description: "reset stored API key"},
+	{name: "--api-key-status", description: "check API key status"},
+	{name: "--encrypt-api-key", description: "encrypt the stored API key"},
+}
+
+// completionCommands returns the subcommands with their flags. Report
+// formats come from reportFormats, so completion follows parseArgs.
+func completionCommands() []completionCommand {
+	output := completionFlag{name: "--output", description: "file to write instead of stdout", arg: "file"}
+	format := func(command string) completionFlag {
+		return completionFlag{name: "--format", description: "output format", values: reportFormats[command]}
+	}
+	exclude := completionFlag{name: "--exclude", description: "leave matching paths out of the report", arg: "dir"}
+	dir := completionFlag{name: "--dir", description: "report only on this subdirectory of the scan", arg: "dir"}
+	return []completionCommand{
+		{name: "diff", description: "list findings added or removed since an earlier scan"},
+		{name: "obligations", description: "license obligations of accepted components", flags: []completionFlag{format("obligations"), output, exclude, dir}},
+		{name: "copyrights", description: "copyright notices of accepted components", flags: []completionFlag{format("copyrights"), output, exclude, dir}},
+		{name: "crypto", description: "cryptographic algorithms for export-control reviews", flags: []completionFlag{format("crypto"), output, exclude, dir}},
+		{name: "sarif", description: "pending matches for code scanning dashboards", flags: []completionFlag{output, exclude, dir}},
+		{name: "badge", description: "audit progress badge for READMEs and CI", flags: []completionFlag{format("badge"), output, exclude, dir}},
+		{name: "import", description: "preview or apply decisions edited in an exported CSV", flags: []completionFlag{
+			{name: "--apply", description: "record the decisions instead of previewing them"},
+		}},
+		{name: "push", description: "send accepted components to a compliance server", flags: []completionFlag{
+			{name: "--to", description: "compliance server type", values: reportFormats["push"]},
+			exclude,
+		}},
+		{name: "completion", description: "print a shell completion script", values: completionShells},
+	}
+}
+
+// runCompletion writes the completion script for shell
+func runCompletion(w io.Writer, shell string) int {
+	switch shell {
+	case "bash":
+		writeBashCompletion(w)
+	case "zsh":
+		writeZshCompletion(w)
+	case "fish":
+		writeFishCompletion(w)
+	}
+	return exitClean
+}
+
+func flagNames(flags []completionFlag) string {
+	names := make([]string, len(flags))
+	for i, flag := range flags {
+		names[i] = flag.name
+	}
+	return strings.Join(names, " ")
+}
+
+func writeBashCompletion(w io.Writer) {
+	commands := completionCommands()
+	names := make([]string, len(commands))
+	for i, command := range commands {
+		names[i] = command.name
+	}
+
+	fmt.Fprintln(w, "# bash completion for auditcmd")
+	fmt.Fprintln(w, "_auditcmd() {")
+	fmt.Fprintln(w, `    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}" command="${COMP_WORDS[1]}" flags`)
+	fmt.Fprintln(w, `    case "$prev" in`)
+	fmt.Fprintln(w, `        --source|--exclude|--dir) COMPREPLY=($(compgen -d -- "$cur")); return ;;`)
+	fmt.Fprintln(w, `        --baseline|--wfp|--output) COMPREPLY=($(compgen -f -- "$cur")); return ;;`)
+	fmt.Fprintln(w, `    esac`)
+	for _, flag := range globalFlags {
+		if len(flag.values) > 0 {
+			fmt.Fprintf(w, "    if [[ $prev == %s ]]; then COMPREPLY=($(compgen -W %q -- \"$cur\")); return; fi\n", flag.name, strings.Join(flag.values, " "))
+		}
+	}
+	for _, command := range commands {
+		for _, flag := range command.flags {
+			if len(flag.values) > 0 {
+				fmt.Fprintf(w, "    if [[ $command == %s && $prev == %s ]]; then COMPREPLY=($(compgen -W %q -- \"$cur\")); return; fi\n", command.name, flag.name, strings.Join(flag.values, " "))
+			}
+		}
+	}
+	fmt.Fprintln(w, `    if [[ $COMP_CWORD -eq 1 ]]; then`)
+	fmt.Fprintf(w, "        if [[ $cur == -* ]]; then COMPREPLY=($(compgen -W %q -- \"$cur\")); else COMPREPLY=($(compgen -W %q -- \"$cur\") $(compgen -f -- \"$cur\")); fi\n", flagNames(globalFlags), strings.Join(names, " "))
+	fmt.Fprintln(w, `        return`)
+	fmt.Fprintln(w, `    fi`)
+	fmt.Fprintln(w, `    case "$command" in`)
+	for _, command := range commands {
+		if len(command.values) > 0 {
+			fmt.Fprintf(w, "        %s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", command.name, strings.Join(command.values, " "))
+			continue
+		}
+		fmt.Fprintf(w, "        %s) flags=%q ;;\n", command.name, flagNames(command.flags))
+	}
+	fmt.Fprintf(w, "        *) flags=%q ;;\n", flagNames(globalFlags))
+	fmt.Fprintln(w, `    esac`)
+	fmt.Fprintln(w, `    if [[ $cur == -* ]]; then COMPREPLY=($(compgen -W "$flags" -- "$cur")); else COMPREPLY=($(compgen -f -- "$cur")); fi`)
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "complete -o filenames -F _auditcmd auditcmd")
+}
+
+// zshFlagSpec is a flag in _arguments syntax
+func zshFlagSpec(flag completionFlag) string {
+	spec := fmt.Sprintf("%s[%s]", flag.name, flag.description)
+	switch {
+	case len(flag.values) > 0:
+		spec += fmt.Sprintf(":%s:(%s)", strings.TrimPrefix(flag.name, "--"), strings.Join(flag.values, " "))
+	case flag.arg == "dir":
+		spec += ":directory:_directories"
+	case flag.arg == "file":
+		spec += ":file:_files"
+	}
+	return "'" + spec + "'"
+}
+
+func writeZshCompletion(w io.Writer) {
+	commands := completionCommands()
+	globalSpecs := make([]string, len(globalFlags))
+	for i, flag := range globalFlags {
+		globalSpecs[i] = zshFlagSpec(flag)
+	}
+
+	fmt.Fprintln(w, "#compdef auditcmd")
+	fmt.Fprintln(w, "_auditcmd() {")
+	fmt.Fprintln(w, "    local -a subcommands")
+	fmt.Fprintln(w, "    subcommands=(")
+	for _, command := range commands {
+		fmt.Fprintf(w, "        '%s:%s'\n", command.name, command.description)
+	}
+	fmt.Fprintln(w, "    )")
+	fmt.Fprintln(w, "    if (( CURRENT == 2 )) && [[ $words[2] != -* ]]; then")
+	fmt.Fprintln(w, "        _describe 'subcommand' subcommands")
+	fmt.Fprintln(w, "        _files")
+	fmt.Fprintln(w, "        return")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w, "    case $words[2] in")
+	for _, command := range commands {
+		fmt.Fprintf(w, "        %s)\n", command.name)
+		fmt.Fprintln(w, "            shift words; (( CURRENT-- ))")
+		specs := make([]string, 0, len(command.flags)+1)
+		for _, flag := range command.flags {
+			specs = append(specs, zshFlagSpec(flag))
+		}
+		if len(command.values) > 0 {
+			specs = append(specs, fmt.Sprintf("'1:%s:(%s)'", command.name, strings.Join(command.values, " ")))
+		} else {
+			specs = append(specs, "'*:results file:_files'")
+		}
+		fmt.Fprintf(w, "            _arguments %s ;;\n", strings.Join(specs, " "))
+	}
+	fmt.Fprintln(w, "        *)")
+	fmt.Fprintf(w, "            _arguments %s '*:results file:_files' ;;\n", strings.Join(globalSpecs, " "))
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, `_auditcmd "$@"`)
+}
+
+// fishFlagLine is a flag as a fish complete command
+func fishFlagLine(condition string, flag completionFlag) string {
+	line := fmt.Sprintf("complete -c auditcmd -n %q -l %s -d %q", condition, strings.TrimPrefix(flag.name, "--"), flag.description)
+	switch {
+	case len(flag.values) > 0:
+		line += fmt.Sprintf(" -x -a %q", strings.Join(flag.values, " "))
+	case flag.arg == "dir":
+		line += " -x -a '(__fish_complete_directories)'"
+	case flag.arg == "file":
+		line += " -r -F"
+	}
+	return line
+}
+
+func writeFishCompletion(w io.Writer) {
+	fmt.Fprintln(w, "# fish completion for auditcmd")
+	for _, command := range completionCommands() {
+		fmt.Fprintf(w, "complete -c auditcmd -n __fish_use_subcommand -a %s -d %q\n", command.name, command.description)
+	}
+	for _, flag := range globalFlags {
+		fmt.Fprintln(w, fishFlagLine("__fish_use_subcommand", flag))
+	}
+	for _, command := range completionCommands() {
+		condition := "__fish_seen_subcommand_from " + command.name
+		for _, flag := range command.flags {
+			fmt.Fprintln(w, fishFlagLine(condition, flag))
+		}
+		if len(command.values) > 0 {
+			fmt.Fprintf(w, "complete -c auditcmd -n %q -f -a %q\n", condition, strings.Join(command.values, " "))
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// configCheckInterval is how often the config file is checked for changes
+const configCheckInterval = 2 * time.Second
+
+// showStartupWarnings shows the problems found while starting, such as
+// config warnings, in a dialog
+func showStartupWarnings(g *gocui.Gui, app *AppState, warnings []string) error {
+	if len(warnings) == 0 {
+		return nil
+	}
+	announce(app, "Started with %d warnings", len(warnings))
+	return showErrorDialog(g, app, "Startup Warnings", "Started with problems:\n\n"+strings.Join(warnings, "\n"))
+}
+
+// savedConfig is the config file content auditcmd last wrote itself, so
+// saving a preference doesn't count as an edit to reload
+var savedConfig string
+
+// applyConfig copies the settings read at startup, and again on reload, to
+// the app state. Preferences toggled from the UI are applied by
+// reloadConfig.
+func applyConfig(app *AppState, config *Config) {
+	app.OnDecisionHook = config.OnDecision
+	app.Commands = config.Commands
+	app.Tracker = config.Tracker
+	app.Webhook = config.Webhook
+	app.GitCommitEvery = config.GitCommitEvery
+	app.RescanCommand = config.RescanCommand
+	app.ProjectLicense = config.ProjectLicense
+	hostKeys, problems := decryptHostKeys(config)
+	app.HostKeys = hostKeys
+	config.Warnings = append(config.Warnings, problems...)
+	app.QuotaURL = config.QuotaURL
+	app.ProvenanceURL = config.ProvenanceURL
+	app.ComponentPageURL = config.ComponentPageURL
+	app.NoContentCache = config.NoContentCache
+	app.Prefetch = config.Prefetch
+	app.BatchContentURL = config.BatchContentURL
+	app.BatchUnsupported = false
+	app.MaxFetches = config.MaxFetches
+	app.PURLOrder = config.PURLOrder
+	app.Reviewer = reviewerIdentity(config.Reviewer)
+	app.ExportOnQuit = config.ExportOnQuit
+	app.RecordDuration = config.RecordDuration
+	app.ExportHistory = config.ExportHistory
+	app.ExportStale = config.ExportStale
+	app.WriteStatus = config.WriteStatus
+	app.BOMFile = config.BOMFile
+	app.QuickActions = config.QuickActions
+	app.CollapseCompleted = config.CollapseCompleted
+	app.FlattenDirs = config.FlattenDirs
+	app.IgnoreList = config.IgnoreList
+	app.Checklist = config.Checklist
+	app.AcceptReasons = config.AcceptReasons
+	app.IgnoreReasons = config.IgnoreReasons
+	app.Labels = config.Labels
+	app.Icons = config.Icons
+	app.TimeZone = nil
+	if config.TimeZone != "" {
+		// Checked when the config was parsed
+		app.TimeZone, _ = time.LoadLocation(config.TimeZone)
+	}
+	app.TimestampFormat = timestampLayout(config.TimestampFormat)
+	app.Stale = staleThresholds(config)
+	app.SimilarityThreshold = defaultSimilarityThreshold
+	if config.SimilarityThreshold != 0 {
+		app.SimilarityThreshold = config.SimilarityThreshold
+	}
+	app.QualityThreshold = defaultQualityThreshold
+	if config.QualityThreshold != 0 {
+		app.QualityThreshold = config.QualityThreshold
+	}
+	if app.Tracker.TitleTemplate == "" {
+		app.Tracker.TitleTemplate = defaultTicketTitle
+	}
+	if app.Tracker.BodyTemplate == "" {
+		app.Tracker.BodyTemplate = defaultTicketBody
+	}
+}
+
+// watchConfig reloads the config file whenever it changes on disk
+func watchConfig(g *gocui.Gui, app *AppState) {
+	configPath := getConfigFilePath()
+	modTime := func() time.Time {
+		if info, err := os.Stat(configPath); err == nil {
+			return info.ModTime()
+		}
+		return time.Time{}
+	}
+
+	go func() {
+		last := modTime()
+		for {
+			time.Sleep(configCheckInterval)
+			if current := modTime(); !current.Equal(last) {
+				last = current
+				g.Update(func(g *gocui.Gui) error {
+					return reloadConfig(g, app)
+				})
+			}
+		}
+	}()
+}
+
+// reloadConfig applies an edited config file without a restart: hooks,
+// endpoints, custom commands, network settings, the view filter, tree and
+// PURL order, file layout and pane layout
+func reloadConfig(g *gocui.Gui, app *AppState) error {
+	data, err := os.ReadFile(getConfigFilePath())
+	if err != nil || string(data) == savedConfig {
+		return nil
+	}
+	config, err := loadConfig()
+	if err != nil {
+		return showErrorDialog(g, app, "Config Reload", err.Error())
+	}
+
+	for key := range app.Commands {
+		g.DeleteKeybinding("", key, gocui.ModNone)
+	}
+	applyConfig(app, config)
+	if err := registerCommandKeybindings(g, app); err != nil {
+		return err
+	}
+	initMilestones(app)
+	if app.GitCommitEvery > 0 {
+		if _, err := gitRepoRoot(app.FilePath); err != nil {
+			config.Warnings = append(config.Warnings, fmt.Sprintf("git_commit_every is set but %v; decisions won't be committed", err))
+			app.GitCommitEvery = 0
+		}
+	}
+	if app.MaxFetches > 0 {
+		network.setLimit(app.MaxFetches)
+	} else {
+		network.setLimit(defaultMaxFetches)
+	}
+
+	if config.ViewFilter != "all" || !groupedView(app) {
+		app.ViewFilter = config.ViewFilter
+	}
+	app.TreeOrder = loadTreeOrder()
+	app.TreeFiles = config.TreeFiles
+	app.FileLayout = loadFileLayout()
+	app.LayoutPreset = loadLayoutPreset()
+	app.PaneWidth = config.PaneWidth
+	app.HideIdentified = config.HideIdentified
+	buildPURLRanking(app)
+	refreshScope(g, app)
+	if err := applyIgnoreList(g, app); err != nil {
+		return err
+	}
+
+	if len(config.Warnings) > 0 {
+		return showErrorDialog(g, app, "Config Reload", "Config reloaded with problems:\n\n"+strings.Join(config.Warnings, "\n"))
+	}
+	announce(app, "Config reloaded")
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// cachedContent is a matched file's content with the ETag it was served
+// with, so it can be revalidated with If-None-Match
+type cachedContent struct {
+	URL     string `json:"url"`
+	ETag    string `json:"etag"`
+	Content string `json:"content"`
+}
+
+// contentCacheDir is where fetched contents are kept between sessions
+func contentCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "auditcmd", "content"), nil
+}
+
+// contentCachePath is the cache file of a content URL
+func contentCachePath(url string) (string, error) {
+	dir, err := contentCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json"), nil
+}
+
+// readCachedContent returns the cached content of url from disk
+func readCachedContent(url string) (cachedContent, bool) {
+	var entry cachedContent
+	path, err := contentCachePath(url)
+	if err != nil {
+		return entry, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, &entry) != nil || entry.URL != url {
+		return cachedContent{}, false
+	}
+	return entry, true
+}
+
+// writeCachedContent stores content on disk. Only contents with an ETag are
+// stored, since others can't be revalidated.
+func writeCachedContent(entry cachedContent) error {
+	if entry.ETag == "" {
+		return nil
+	}
+	path, err := contentCachePath(entry.URL)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %v", err)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// lookupContent returns the content cached in memory or, unless disabled,
+// on disk
+func lookupContent(app *AppState, url string) (cachedContent, bool) {
+	if entry, ok := app.ContentCache[url]; ok {
+		return entry, true
+	}
+	if app.NoContentCache {
+		return cachedContent{}, false
+	}
+	entry, ok := readCachedContent(url)
+	if ok {
+		storeContent(app, entry, false)
+	}
+	return entry, ok
+}
+
+// storeContent keeps content in memory and, when persist is set and the
+// disk cache isn't disabled, on disk. The disk cache is best effort.
+func storeContent(app *AppState, entry cachedContent, persist bool) {
+	if app.ContentCache == nil {
+		app.ContentCache = make(map[string]cachedContent)
+	}
+	app.ContentCache[entry.URL] = entry
+	if persist && !app.NoContentCache {
+		writeCachedContent(entry)
+	}
+}
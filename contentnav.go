@@ -0,0 +1,131 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// stepContentFile opens the file delta places away in the file list
+// without leaving the content view
+func stepContentFile(g *gocui.Gui, app *AppState, delta int) error {
+	if app.ViewMode != "content" || isAuditDialogOpen(g) {
+		return nil
+	}
+	index := app.SelectedFileIndex + delta
+	if index < 0 || index >= len(app.CurrentFileList) {
+		if delta > 0 {
+			announce(app, "Last file in the list")
+		} else {
+			announce(app, "First file in the list")
+		}
+		return nil
+	}
+	return showListedFile(g, app, index)
+}
+
+// nextPendingContent opens the next pending file of the list after the one
+// being viewed, wrapping around at the end
+func nextPendingContent(g *gocui.Gui, app *AppState) error {
+	if app.ViewMode != "content" || isAuditDialogOpen(g) {
+		return nil
+	}
+	count := len(app.CurrentFileList)
+	for step := 1; step < count; step++ {
+		index := (app.SelectedFileIndex + step) % count
+		if awaitingDecision(app, app.CurrentFileList[index]) {
+			return showListedFile(g, app, index)
+		}
+	}
+	announce(app, "No other pending file in the list")
+	return nil
+}
+
+// awaitingDecision reports whether a file still needs a decision: it is
+// pending, or in review mode not yet reviewed
+func awaitingDecision(app *AppState, filePath string) bool {
+	if app.ReviewMode {
+		return reviewInScope(app, filePath)
+	}
+	return audit.MatchesFilter(app.ScanData.Files[filePath], audit.FilterPending)
+}
+
+// decideAndAdvance records a decision without comment for the selected or
+// viewed file and opens the next file awaiting a decision in the list
+func decideAndAdvance(g *gocui.Gui, app *AppState, decisionType string) error {
+	if app.ActivePane != "files" || isAuditDialogOpen(g) || app.ProcessingQuickAction {
+		return nil
+	}
+	if app.SelectedFileIndex < 0 || app.SelectedFileIndex >= len(app.CurrentFileList) {
+		return nil
+	}
+	filePath := app.CurrentFileList[app.SelectedFileIndex]
+	if app.ViewMode == "content" {
+		filePath = app.CurrentFile
+	}
+	match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+	if match == nil {
+		return nil
+	}
+
+	decision := recordDecision(app, match, decisionType, "")
+	if err := saveToFile(app); err != nil {
+		return err
+	}
+	afterDecisionSaved(g, app, filePath, match, decision)
+
+	// Pick the next file before the list is refreshed, as the decided
+	// file may drop out of it
+	next := ""
+	count := len(app.CurrentFileList)
+	for step := 1; step < count; step++ {
+		candidate := app.CurrentFileList[(app.SelectedFileIndex+step)%count]
+		if awaitingDecision(app, candidate) {
+			next = candidate
+			break
+		}
+	}
+
+	saveContentScroll(g, app)
+	app.ViewMode = "list"
+	app.CurrentMatch = nil
+	updateFileList(g, app)
+	updateStatus(g, app)
+	updateHelpBar(g, app)
+	decided := fmt.Sprintf("Marked %s as %s", displayPath(app, filePath), decisionLabel(app, decision.Decision))
+	for i, listed := range app.CurrentFileList {
+		if next != "" && listed == next {
+			app.ViewMode = "content"
+			if err := showListedFile(g, app, i); err != nil {
+				return err
+			}
+			announce(app, "%s. Viewing %s, %s", decided, displayPath(app, next), fileAuditState(app, next))
+			updateHelpBar(g, app)
+			return nil
+		}
+	}
+	announce(app, "%s. No other file in the list awaits a decision", decided)
+	return nil
+}
+
+// showListedFile selects a file of the list and shows its content, keeping
+// the scroll position of the file being left
+func showListedFile(g *gocui.Gui, app *AppState, index int) error {
+	saveContentScroll(g, app)
+	app.FileList.SelectedIndex = index
+	app.FileList.adjustScroll()
+	app.SelectedFileIndex = index
+	app.CurrentFile = app.CurrentFileList[index]
+	announce(app, "Viewing %s, %s", displayPath(app, app.CurrentFile), fileAuditState(app, app.CurrentFile))
+	if err := openFileContent(g, app, app.CurrentFile); err != nil {
+		return err
+	}
+	updatePaneTitles(g, app)
+	updateStatus(g, app)
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// escapeControls makes escape sequences and other control characters in
+// file content visible instead of letting them reach the terminal: ESC
+// becomes "^[", other C0 controls "^A".."^_", DEL "^?" and C1 controls
+// "<U+009B>". Tabs are kept.
+func escapeControls(line string) string {
+	line = cellSafe(line)
+	if !strings.ContainsFunc(line, isControl) {
+		return line
+	}
+	var escaped strings.Builder
+	for _, r := range line {
+		switch {
+		case !isControl(r):
+			escaped.WriteRune(r)
+		case r < 0x20:
+			escaped.WriteString("^" + string(rune(r+'@')))
+		case r == 0x7f:
+			escaped.WriteString("^?")
+		default:
+			fmt.Fprintf(&escaped, "<U+%04X>", r)
+		}
+	}
+	return escaped.String()
+}
+
+func isControl(r rune) bool {
+	return (r < 0x20 && r != '\t') || (r >= 0x7f && r <= 0x9f)
+}
+
+// Limits of the binary content view
+const (
+	hexDumpLimit    = 64 * 1024 // Bytes shown in the hex dump
+	minStringLength = 6         // Shortest run of printable characters listed
+	stringsLimit    = 50        // Strings listed before the dump
+)
+
+// fileSignatures identify common binary formats by their first bytes
+var fileSignatures = []struct {
+	magic string
+	kind  string
+}{
+	{"\x89PNG\r\n\x1a\n", "PNG image"},
+	{"\xff\xd8\xff", "JPEG image"},
+	{"GIF8", "GIF image"},
+	{"%PDF-", "PDF document"},
+	{"PK\x03\x04", "ZIP archive (also JAR, APK, DOCX)"},
+	{"\x1f\x8b", "gzip archive"},
+	{"\x7fELF", "ELF executable"},
+	{"MZ", "Windows executable"},
+	{"\xca\xfe\xba\xbe", "Java class file"},
+	{"\x00\x01\x00\x00", "TrueType font"},
+	{"OTTO", "OpenType font"},
+	{"wOFF", "WOFF font"},
+	{"wOF2", "WOFF2 font"},
+	{"\x00asm", "WebAssembly module"},
+}
+
+// looksBinary reports whether content isn't text, using git's heuristic
+// of a NUL byte near the start
+func looksBinary(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0
+}
+
+// fileKind names the format of binary content from its signature
+func fileKind(content []byte) string {
+	for _, signature := range fileSignatures {
+		if bytes.HasPrefix(content, []byte(signature.magic)) {
+			return signature.kind
+		}
+	}
+	return "unknown binary format"
+}
+
+// printableStrings returns the runs of printable ASCII in content, like
+// strings(1), up to limit
+func printableStrings(content []byte, limit int) []string {
+	found := make([]string, 0)
+	start := -1
+	for i := 0; i <= len(content) && len(found) < limit; i++ {
+		if i < len(content) && content[i] >= 0x20 && content[i] < 0x7f {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 && i-start >= minStringLength {
+			found = append(found, string(content[start:i]))
+		}
+		start = -1
+	}
+	return found
+}
+
+// writeHexDump shows content as offsets, hex bytes and printable
+// characters. Binary content is introduced by its format and the strings
+// it contains, so headers, versions and copyright notices can be checked.
+func writeHexDump(v io.Writer, content []byte) {
+	if looksBinary(content) {
+		fmt.Fprintf(v, "Binary content: %s, %d bytes\n\n", fileKind(content), len(content))
+		if found := printableStrings(content, stringsLimit); len(found) > 0 {
+			fmt.Fprintf(v, "Strings:\n")
+			for _, text := range found {
+				fmt.Fprintf(v, "  %s\n", text)
+			}
+			fmt.Fprintln(v)
+		}
+	}
+	fmt.Fprint(v, hex.Dump(content[:min(len(content), hexDumpLimit)]))
+	if len(content) > hexDumpLimit {
+		fmt.Fprintf(v, "\n... first %d of %d bytes shown\n", hexDumpLimit, len(content))
+	}
+}
+
+// contentModeLabel is appended to the content pane title in hex mode
+func contentModeLabel(app *AppState) string {
+	if app.ContentHex {
+		return " (hex)"
+	}
+	return ""
+}
+
+// toggleContentHex switches the content view between text and a hex dump
+func toggleContentHex(g *gocui.Gui, app *AppState) error {
+	if app.ViewMode != "content" || app.CurrentFile == "" {
+		return nil
+	}
+	app.ContentHex = !app.ContentHex
+	if err := displayFileContent(g, app, app.CurrentFile); err != nil {
+		return err
+	}
+	updatePaneTitles(g, app)
+	if app.ContentHex {
+		announce(app, "Showing hex dump")
+	} else {
+		announce(app, "Showing text")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// saveContentScroll remembers how far the open file was scrolled, so
+// opening it again later in the session continues where the auditor left
+func saveContentScroll(g *gocui.Gui, app *AppState) {
+	if app.ViewMode != "content" || app.CurrentFile == "" {
+		return
+	}
+	v, err := g.View("files")
+	if err != nil {
+		return
+	}
+	if app.ContentScroll == nil {
+		app.ContentScroll = make(map[string]int)
+	}
+	_, oy := v.Origin()
+	app.ContentScroll[app.CurrentFile] = oy
+}
+
+// openFileContent shows a file's content at the offset it was last scrolled
+// to, or at the top the first time
+func openFileContent(g *gocui.Gui, app *AppState, filePath string) error {
+	if err := displayFileContent(g, app, filePath); err != nil {
+		return err
+	}
+	oy := app.ContentScroll[filePath]
+	if oy == 0 {
+		return nil
+	}
+	v, err := g.View("files")
+	if err != nil {
+		return err
+	}
+	// The content may have changed since, e.g. an error page instead
+	if last := v.LinesHeight() - 1; oy > last {
+		oy = max(last, 0)
+	}
+	return v.SetOrigin(0, oy)
+}
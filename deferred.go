@@ -0,0 +1,71 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// showDeferDialog parks the selected or viewed file as deferred, with a
+// comment saying what it waits for. Deferred files leave the pending filter
+// but aren't audited yet: they keep the audit from reaching 100%.
+func showDeferDialog(g *gocui.Gui, app *AppState) error {
+	if isAuditDialogOpen(g) {
+		return nil
+	}
+	if app.CurrentMatch == nil {
+		app.CurrentMatch = audit.FirstValidMatch(app.ScanData.Files[focusedFile(app)])
+	}
+	if app.CurrentMatch == nil {
+		return showErrorDialog(g, app, "No File Selected", "Please select a file with matches to audit.")
+	}
+
+	app.PendingDecision = audit.DecisionDeferred
+
+	if v, err := setDialogView(g, "audit_dialog"); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Title = decisionTitle(app, audit.DecisionDeferred)
+		v.Frame = true
+		v.Editable = false
+		v.TitleColor = gocui.ColorMagenta
+		v.BgColor = gocui.ColorBlack
+		v.FgColor = gocui.ColorMagenta
+	}
+
+	if v, err := setDialogView(g, "audit_input"); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Frame = false
+		v.Editable = true
+		v.Wrap = true
+		v.BgColor = gocui.ColorBlack
+		v.FgColor = gocui.ColorMagenta
+
+		if _, err := g.SetCurrentView("audit_input"); err != nil {
+			return err
+		}
+	}
+
+	if err := writeCommentDialog(g, app); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("audit_dialog")
+	g.DeleteKeybindings("audit_input")
+
+	g.SetKeybinding("audit_input", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return saveAuditDecision(g, app)
+	})
+	g.SetKeybinding("audit_input", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeAuditDialog(g, app)
+	})
+
+	bindCommentEditing(g, app)
+	return nil
+}
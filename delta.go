@@ -0,0 +1,114 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// runDiff implements "auditcmd diff old.json new.json" and returns the exit
+// code of the new results, see outcomeExitCode
+func runDiff(out io.Writer, oldPath, newPath string) int {
+	oldScan, err := audit.Load(oldPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return loadExitCode(err)
+	}
+	newScan, err := audit.Load(newPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return loadExitCode(err)
+	}
+
+	if audit.IsDependencyDocument(oldScan) {
+		oldScan, _ = audit.ExpandDependencies(oldScan)
+	}
+	if audit.IsDependencyDocument(newScan) {
+		newScan, _ = audit.ExpandDependencies(newScan)
+	}
+
+	deltas := audit.Diff(oldScan, newScan)
+	counts := audit.CountDeltas(deltas)
+
+	fmt.Fprintf(out, "Comparing %s -> %s\n", oldPath, newPath)
+	fmt.Fprintf(out, "New:       %d\n", counts[audit.DeltaNew])
+	fmt.Fprintf(out, "Removed:   %d\n", counts[audit.DeltaRemoved])
+	fmt.Fprintf(out, "Unchanged: %d\n", counts[audit.DeltaUnchanged])
+
+	sections := []struct {
+		kind, title, marker string
+	}{
+		{audit.DeltaNew, "New findings", "+"},
+		{audit.DeltaRemoved, "Removed findings", "-"},
+	}
+	for _, section := range sections {
+		if counts[section.kind] == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "\n%s:\n", section.title)
+		for _, delta := range deltas {
+			if delta.Kind == section.kind {
+				fmt.Fprintf(out, "  %s %s  %s\n", section.marker, delta.Path, delta.PURL)
+			}
+		}
+	}
+	return outcomeExitCode(newScan)
+}
+
+// loadBaseline compares the loaded results with an earlier scan and records
+// how each finding changed
+func loadBaseline(app *AppState, baselinePath string) error {
+	baseline, err := audit.Load(baselinePath)
+	if err != nil {
+		return err
+	}
+
+	if audit.IsDependencyDocument(baseline) {
+		baseline, _ = audit.ExpandDependencies(baseline)
+	}
+
+	deltas := audit.Diff(baseline, &app.ScanData)
+	app.Delta = make(map[string]string)
+	for _, delta := range deltas {
+		if delta.Kind != audit.DeltaRemoved {
+			app.Delta[delta.Path] = delta.Kind
+		}
+	}
+	app.DeltaCounts = audit.CountDeltas(deltas)
+	app.BaselinePath = baselinePath
+	return nil
+}
+
+// deltaMarker tags findings that are new since the baseline in the file list
+func deltaMarker(app *AppState, filePath string) string {
+	if app.Delta[filePath] != audit.DeltaNew {
+		return ""
+	}
+	if app.Accessible {
+		return " [new]"
+	}
+	return " \033[33m+new\033[0m"
+}
+
+// toggleDeltaOnly switches between all findings and only those new since the baseline
+func toggleDeltaOnly(g *gocui.Gui, app *AppState) error {
+	if app.Delta == nil {
+		return showErrorDialog(g, app, "Delta Mode", "No baseline loaded. Start auditcmd with --baseline <old-results.json> to compare against an earlier scan.")
+	}
+	app.DeltaOnly = !app.DeltaOnly
+
+	refreshScope(g, app)
+	if app.DeltaOnly {
+		announce(app, "Showing only findings new since baseline, %d items", len(app.TreeList.Items))
+	} else {
+		announce(app, "Showing all findings, %d items", len(app.TreeList.Items))
+	}
+	return nil
+}
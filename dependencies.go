@@ -0,0 +1,38 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// displayDependency shows the details of a dependency in the content pane,
+// which has no file contents to fetch
+func displayDependency(v io.Writer, app *AppState, filePath string, match *FileMatch) {
+	fmt.Fprintf(v, "Dependency\n")
+	fmt.Fprintf(v, "==========\n\n")
+	fmt.Fprintf(v, "Manifest:  %s\n", displayPath(app, match.File))
+	fmt.Fprintf(v, "Component: %s\n", match.Component)
+	if len(match.Purl) > 0 {
+		fmt.Fprintf(v, "PURL:      %s\n", displayPURL(app, match.Purl[0]))
+	}
+	fmt.Fprintf(v, "Version:   %s\n", match.Version)
+
+	licenses := make([]string, 0, len(match.Licenses))
+	for _, license := range match.Licenses {
+		licenses = append(licenses, license.Name)
+	}
+	fmt.Fprintf(v, "Licenses:  %s\n", strings.Join(licenses, ", "))
+
+	if app.Dependencies != nil {
+		if dep := app.Dependencies.Dependency(filePath); dep != nil && dep.Scope != "" {
+			fmt.Fprintf(v, "Scope:     %s\n", dep.Scope)
+		}
+	}
+	if match.URL != "" {
+		fmt.Fprintf(v, "URL:       %s\n", displayURL(app, match.URL))
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// statsTopEntries is how many PURLs and licenses the statistics popup lists
+const statsTopEntries = 10
+
+// selectedNodeFiles returns every file under the selected tree node,
+// regardless of the view filter
+func selectedNodeFiles(app *AppState) (string, []string) {
+	node := app.TreeState.selectedNode
+	if node == nil {
+		return "", nil
+	}
+	if groupedView(app) {
+		return displayPURL(app, node.Name), node.Files
+	}
+	if file := treeFile(app, node); file != "" {
+		return displayPath(app, file), node.Files
+	}
+	label := node.Path
+	if label == "" {
+		label = "(root)"
+	}
+	return displayPath(app, label), audit.FilesInDirectory(&app.ScanData, node.Path, audit.FilterAll)
+}
+
+// writeTallies prints a ranked PURL or license table
+func writeTallies(v io.Writer, title string, tallies []audit.Tally, display func(string) string) {
+	fmt.Fprintf(v, "\n \033[1m%s\033[0m  (pending / files)\n", title)
+	if len(tallies) == 0 {
+		fmt.Fprintf(v, "   none\n")
+		return
+	}
+	for _, tally := range tallies {
+		fmt.Fprintf(v, "   %5d / %-5d %s\n", tally.Pending, tally.Files, display(tally.Name))
+	}
+}
+
+// showStatsDialog shows a breakdown of the selected directory or PURL to help
+// decide where to bulk-triage first
+func showStatsDialog(g *gocui.Gui, app *AppState) error {
+	label, files := selectedNodeFiles(app)
+	if label == "" {
+		return nil
+	}
+	summary := audit.SummarizeFiles(&app.ScanData, files)
+
+	v, err := setDialogView(g, "stats_dialog")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "Statistics: " + label
+	v.Frame = true
+	v.Wrap = false
+	v.Editable = false
+	v.Clear()
+	v.SetOrigin(0, 0)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, " \033[1mFiles:\033[0m %d\n", summary.TotalFiles)
+	fmt.Fprintf(&out, " \033[1mMatched:\033[0m %d   \033[1mNo match:\033[0m %d\n", summary.MatchingFiles, summary.NoMatchFiles)
+	fmt.Fprintf(&out, " \033[1mFile:\033[0m %d   \033[1mSnippet:\033[0m %d", summary.FileMatches, summary.SnippetMatches)
+	if summary.DependencyMatches > 0 {
+		fmt.Fprintf(&out, "   \033[1mDependency:\033[0m %d", summary.DependencyMatches)
+	}
+	fmt.Fprintf(&out, "\n \033[1m%s:\033[0m %d   \033[1m%s:\033[0m %d   \033[1m%s:\033[0m %d   \033[1m%s:\033[0m %d\n", stateTitle(app, audit.StatusPending), summary.Pending, stateTitle(app, audit.StatusIdentified), summary.Identified, stateTitle(app, audit.StatusIgnored), summary.Ignored, stateTitle(app, audit.StatusDeferred), summary.Deferred)
+
+	writeTallies(&out, "Top PURLs", audit.TopPURLs(&app.ScanData, files, statsTopEntries), func(purl string) string {
+		return displayPURL(app, purl)
+	})
+	writeTallies(&out, "Top licenses", audit.TopLicenses(&app.ScanData, files, statsTopEntries), func(license string) string {
+		return license
+	})
+	writeVelocity(&out, app, audit.Summarize(&app.ScanData).Pending)
+	fmt.Fprintf(&out, "\n Up/Down/PgUp/PgDn: Scroll  ESC: Close")
+
+	text := out.String()
+	if app.Accessible {
+		text = stripANSI(text)
+	}
+	fmt.Fprint(v, text)
+
+	if _, err := g.SetCurrentView("stats_dialog"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("stats_dialog")
+	g.SetKeybinding("stats_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeStatsDialog(g, app)
+	})
+	g.SetKeybinding("stats_dialog", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return scrollDialog(v, -1)
+	})
+	g.SetKeybinding("stats_dialog", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return scrollDialog(v, 1)
+	})
+	g.SetKeybinding("stats_dialog", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, height := v.Size()
+		return scrollDialog(v, -height)
+	})
+	g.SetKeybinding("stats_dialog", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, height := v.Size()
+		return scrollDialog(v, height)
+	})
+
+	announce(app, "%s: %d files, %d %s, %d %s, %d %s, %d %s", label, summary.TotalFiles, summary.Pending, stateLabel(app, audit.StatusPending), summary.Identified, stateLabel(app, audit.StatusIdentified), summary.Ignored, stateLabel(app, audit.StatusIgnored), summary.Deferred, stateLabel(app, audit.StatusDeferred))
+	return nil
+}
+
+func closeStatsDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("stats_dialog")
+	g.DeleteView("stats_dialog")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"time"
+
+	"auditcmd/pkg/audit"
+)
+
+// trackFocusedFile notes when the file in focus changes, so the time spent
+// on it can be recorded with its decision. Runs on every layout pass.
+func trackFocusedFile(app *AppState) {
+	if !app.RecordDuration {
+		return
+	}
+	if filePath := focusedFile(app); filePath != app.FocusedFile {
+		app.FocusedFile = filePath
+		app.FocusedSince = time.Now()
+	}
+}
+
+// focusDuration returns the whole seconds the file of match has been in
+// focus, or 0 when durations aren't recorded or the match isn't the
+// focused file's, as with the other files of a group decision
+func focusDuration(app *AppState, match *FileMatch) int {
+	if !app.RecordDuration || app.FocusedFile == "" || audit.FirstValidMatch(app.ScanData.Files[app.FocusedFile]) != match {
+		return 0
+	}
+	return int(time.Since(app.FocusedSince).Seconds())
+}
+
+// recordDecision adds a decision to match, as a second opinion by the
+// reviewer in review mode, with who made it and the time the file was open
+// when enabled
+func recordDecision(app *AppState, match *FileMatch, decision, assessment string) AuditDecision {
+	duration := focusDuration(app, match)
+	var entry AuditDecision
+	if app.ReviewMode {
+		entry = match.AddReview(decision, assessment, app.Reviewer)
+	} else {
+		entry = match.AddDecision(decision, assessment)
+	}
+	entry.Auditor = app.Reviewer
+	if duration > 0 {
+		entry.Duration = duration
+		// A further decision on the same file counts from this one
+		app.FocusedSince = time.Now()
+	}
+	match.AuditCmd[len(match.AuditCmd)-1] = entry
+	return entry
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+
+	"auditcmd/pkg/audit"
+)
+
+// Exit codes of the headless subcommands, so pipelines can branch on the
+// outcome without parsing the output
+const (
+	exitClean           = 0 // Every match is audited
+	exitError           = 1 // Usage errors and failures, e.g. an unwritable output file
+	exitPending         = 2 // Pending findings remain
+	exitPolicyViolation = 3 // An accepted component's license conflicts with project_license
+	exitParseError      = 4 // A results or CSV file couldn't be parsed
+)
+
+// loadExitCode is the exit code for a results file that failed to load
+func loadExitCode(err error) int {
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	var schemaError *audit.SchemaError
+	if errors.As(err, &syntaxError) || errors.As(err, &typeError) || errors.As(err, &schemaError) {
+		return exitParseError
+	}
+	return exitError
+}
+
+// outcomeExitCode reports the state of the audit: a policy violation takes
+// precedence over pending findings
+func outcomeExitCode(scan *audit.ScanResult) int {
+	if config, err := loadConfig(); err == nil && config.ProjectLicense != "" {
+		obligations := audit.CollectObligations(scan)
+		audit.MarkConflicts(obligations, config.ProjectLicense)
+		for _, o := range obligations {
+			if o.Conflict != "" {
+				return exitPolicyViolation
+			}
+		}
+	}
+	if audited, total, _ := audit.Progress(scan); audited < total {
+		return exitPending
+	}
+	return exitClean
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Export splits, switched with S in the export dialog
+const (
+	splitNone      = ""          // One file with everything
+	splitDirectory = "directory" // One file per top-level directory
+	splitPURL      = "purl"      // One file per matched component
+)
+
+// nextExportSplit cycles through the splits: none, per directory, per PURL
+func nextExportSplit(split string) string {
+	switch split {
+	case splitNone:
+		return splitDirectory
+	case splitDirectory:
+		return splitPURL
+	}
+	return splitNone
+}
+
+// exportSplitLabel describes the selected export split
+func exportSplitLabel(app *AppState) string {
+	switch app.ExportSplit {
+	case splitDirectory:
+		return "per top-level directory"
+	case splitPURL:
+		return "per PURL"
+	}
+	return "none, one file"
+}
+
+// exportFolder is the folder a split export writes its files to, named after
+// the file it would otherwise write, e.g. results-by-directory
+func exportFolder(app *AppState, filename string) string {
+	return strings.TrimSuffix(filename, ".csv") + "-by-" + app.ExportSplit
+}
+
+// exportSlices slices the results for a split export, keyed by the name of
+// the file each slice is written to
+func exportSlices(app *AppState) map[string]*ScanResult {
+	var slices map[string]*ScanResult
+	if app.ExportSplit == splitPURL {
+		slices = app.ScanData.SplitByPURL()
+	} else {
+		slices = app.ScanData.SplitByDirectory(app.RootDir)
+	}
+	keys := make([]string, 0, len(slices))
+	for key := range slices {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	named := make(map[string]*ScanResult, len(slices))
+	for _, key := range keys {
+		name := key
+		switch {
+		case key == "":
+			name = "top-level-files"
+		case app.Redact && app.ExportSplit == splitPURL:
+			name = redactPURL(key)
+		case app.Redact:
+			name = redactComponent(key)
+		}
+		name = audit.SliceFilename(name)
+		// Keys that only differ in the characters replaced get a number
+		unique := name
+		for i := 2; named[unique+".csv"] != nil; i++ {
+			unique = fmt.Sprintf("%s-%d", name, i)
+		}
+		named[unique+".csv"] = slices[key]
+	}
+	return named
+}
+
+// performSplitExport writes each slice of the results with export to its own
+// file in folder, replacing the files of an earlier export one by one
+func performSplitExport(g *gocui.Gui, app *AppState, task *backgroundTask, folder string, opts audit.CSVOptions, export func(io.Writer, *ScanResult, audit.CSVOptions) error) error {
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return fmt.Errorf("failed to create folder: %v", err)
+	}
+	slices := exportSlices(app)
+	names := make([]string, 0, len(slices))
+	for name := range slices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Progress is reported per file rather than per row
+	opts.Progress = nil
+	for i, name := range names {
+		if err := task.ctx.Err(); err != nil {
+			return err
+		}
+		reportTask(g, app, task, fmt.Sprintf("Writing %s (%d of %d)...", name, i+1, len(names)), i, len(names))
+		if err := writeExportFile(filepath.Join(folder, name), slices[name], opts, export); err != nil {
+			return err
+		}
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		notifyWebhook(g, app, "export", fmt.Sprintf("Exported audit of %s to %d files in %s", filepath.Base(app.FilePath), len(names), filepath.Base(folder)))
+		announce(app, "Exported %d files to %s", len(names), folder)
+		return nil
+	})
+	return nil
+}
+
+// writeExportFile writes one slice through a temporary file, so a failed
+// export leaves the previous file in place
+func writeExportFile(filename string, scan *ScanResult, opts audit.CSVOptions, export func(io.Writer, *ScanResult, audit.CSVOptions) error) error {
+	file, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if err := export(file, scan, opts); err != nil {
+		return err
+	}
+	return moveExport(file, filename)
+}
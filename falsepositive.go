@@ -0,0 +1,155 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// falsePositiveMarker flags pending matches the heuristics consider likely
+// false positives
+func falsePositiveMarker(app *AppState, filePath string) string {
+	likely, _ := audit.LikelyFalsePositive(filePath, app.ScanData.Files[filePath], app.SimilarityThreshold, app.QualityThreshold)
+	if !likely {
+		return ""
+	}
+	if app.Accessible {
+		return " [likely false positive]"
+	}
+	return " \033[35m~fp\033[0m"
+}
+
+// likelyFalsePositives returns the flagged files under the selected tree
+// node that are shown with the current toggles, with their signals
+func likelyFalsePositives(app *AppState) ([]string, map[string][]string) {
+	_, files := selectedNodeFiles(app)
+	flagged := make([]string, 0)
+	signals := make(map[string][]string)
+	for _, filePath := range filterScope(app, files) {
+		if likely, reasons := audit.LikelyFalsePositive(filePath, app.ScanData.Files[filePath], app.SimilarityThreshold, app.QualityThreshold); likely {
+			flagged = append(flagged, filePath)
+			signals[filePath] = reasons
+		}
+	}
+	return flagged, signals
+}
+
+// showFalsePositiveDialog lists the likely false positives under the
+// selected directory or PURL for review and ignores them all on ENTER
+func showFalsePositiveDialog(g *gocui.Gui, app *AppState) error {
+	label, _ := selectedNodeFiles(app)
+	if label == "" {
+		return nil
+	}
+	flagged, signals := likelyFalsePositives(app)
+	if len(flagged) == 0 {
+		return showErrorDialog(g, app, "Likely False Positives", fmt.Sprintf("No pending matches in %s look like false positives.", label))
+	}
+
+	v, err := setDialogView(g, "fp_dialog")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = fmt.Sprintf("Likely False Positives: %s (%d)", label, len(flagged))
+	v.Frame = true
+	v.Wrap = false
+	v.Editable = false
+	v.Clear()
+	v.SetOrigin(0, 0)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, " Review the matches below. ENTER ignores all %d, ESC cancels.\n", len(flagged))
+	fmt.Fprintf(&out, " A checkpoint is saved first so the decisions can be rolled back with [C].\n\n")
+	for _, filePath := range flagged {
+		match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+		purl := ""
+		if len(match.Purl) > 0 {
+			purl = displayPURL(app, match.Purl[0])
+		}
+		fmt.Fprintf(&out, " \033[1m%s\033[0m  %s\n", displayPath(app, filePath), purl)
+		fmt.Fprintf(&out, "   %s\n", strings.Join(signals[filePath], ", "))
+	}
+	fmt.Fprintf(&out, "\n ENTER: Ignore all  Up/Down/PgUp/PgDn: Scroll  ESC: Cancel")
+
+	text := out.String()
+	if app.Accessible {
+		text = stripANSI(text)
+	}
+	fmt.Fprint(v, text)
+
+	if _, err := g.SetCurrentView("fp_dialog"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("fp_dialog")
+	g.SetKeybinding("fp_dialog", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		closeFalsePositiveDialog(g, app)
+		return ignoreFalsePositives(g, app, flagged, signals)
+	})
+	g.SetKeybinding("fp_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeFalsePositiveDialog(g, app)
+	})
+	g.SetKeybinding("fp_dialog", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return scrollDialog(v, -1)
+	})
+	g.SetKeybinding("fp_dialog", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return scrollDialog(v, 1)
+	})
+	g.SetKeybinding("fp_dialog", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, height := v.Size()
+		return scrollDialog(v, -height)
+	})
+	g.SetKeybinding("fp_dialog", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, height := v.Size()
+		return scrollDialog(v, height)
+	})
+
+	announce(app, "%d likely false positives in %s. Enter ignores all, Escape cancels", len(flagged), label)
+	return nil
+}
+
+// ignoreFalsePositives records an ignore decision for every reviewed file,
+// after saving a checkpoint to roll back to
+func ignoreFalsePositives(g *gocui.Gui, app *AppState, flagged []string, signals map[string][]string) error {
+	if err := createCheckpoint(app, "before ignoring likely false positives"); err != nil {
+		return showErrorDialog(g, app, "Checkpoint Error", fmt.Sprintf("Nothing was ignored: %v", err))
+	}
+
+	completed := completedDirs(app)
+	decisions := make([]savedDecision, 0, len(flagged))
+	for _, filePath := range flagged {
+		match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+		if match == nil || audit.MatchStatus(match) != audit.StatusPending {
+			continue
+		}
+		decision := recordDecision(app, match, audit.DecisionIgnored, "Likely false positive: "+strings.Join(signals[filePath], ", "))
+		decisions = append(decisions, savedDecision{filePath, match, decision})
+	}
+
+	if err := saveToFile(app); err != nil {
+		return showErrorDialog(g, app, "Save Error", fmt.Sprintf("Error saving audit decisions: %v", err))
+	}
+	detail := fmt.Sprintf("Ignoring %d likely false positives", len(decisions))
+	return afterDecisionsSaved(g, app, "FALSE POSITIVES", detail, decisions, func(g *gocui.Gui) error {
+		announce(app, "Ignored %d likely false positives", len(decisions))
+		return offerCollapseCompleted(g, app, completed)
+	})
+}
+
+func closeFalsePositiveDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("fp_dialog")
+	g.DeleteView("fp_dialog")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
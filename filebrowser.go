@@ -0,0 +1,166 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// fileBrowser finds a results file to audit when none is given and none of
+// the recent ones is picked. Only directories and JSON files are listed.
+type fileBrowser struct {
+	dir     string
+	entries []string // Directories end in "/"
+	list    *ScrollableList
+	problem string // Why the last directory couldn't be opened
+	chosen  string
+}
+
+func newFileBrowser(dir string, accessible bool) *fileBrowser {
+	b := &fileBrowser{list: NewScrollableList(nil)}
+	b.list.Plain = accessible
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	if err := b.open(dir); err != nil {
+		b.dir = dir
+		b.problem = err.Error()
+	}
+	return b
+}
+
+// open lists dir: the parent first, then its directories and its JSON files.
+// Hidden entries are left out.
+func (b *fileBrowser) open(dir string) error {
+	listing, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var dirs, files []string
+	for _, entry := range listing {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		// Stat follows links to directories
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.IsDir() {
+			dirs = append(dirs, name+"/")
+		} else if strings.EqualFold(filepath.Ext(name), ".json") {
+			files = append(files, name)
+		}
+	}
+
+	var entries []string
+	if filepath.Dir(dir) != dir {
+		entries = append(entries, "../")
+	}
+	b.dir = dir
+	b.entries = append(append(entries, dirs...), files...)
+	b.problem = ""
+
+	items := make([]string, len(b.entries))
+	for i, entry := range b.entries {
+		if strings.HasSuffix(entry, "/") {
+			items[i] = "\033[34m" + cellSafe(entry) + "\033[0m"
+		} else {
+			items[i] = cellSafe(entry)
+		}
+	}
+	b.list.SetItems(items)
+	b.list.SelectedIndex = 0
+	b.list.ScrollOffset = 0
+	return nil
+}
+
+// up opens the parent directory with the directory left selected
+func (b *fileBrowser) up() {
+	left := filepath.Base(b.dir) + "/"
+	if err := b.open(filepath.Dir(b.dir)); err != nil {
+		b.problem = err.Error()
+		return
+	}
+	for i, entry := range b.entries {
+		if entry == left {
+			b.list.SelectedIndex = i
+			b.list.adjustScroll()
+		}
+	}
+}
+
+// enter opens the selected directory, or picks the selected file. It
+// reports whether a file was picked.
+func (b *fileBrowser) enter() bool {
+	index := b.list.GetSelectedIndex()
+	if index < 0 || index >= len(b.entries) {
+		return false
+	}
+	entry := b.entries[index]
+	switch {
+	case entry == "../":
+		b.up()
+	case strings.HasSuffix(entry, "/"):
+		if err := b.open(filepath.Join(b.dir, entry)); err != nil {
+			b.problem = err.Error()
+		}
+	default:
+		b.chosen = filepath.Join(b.dir, entry)
+		return true
+	}
+	return false
+}
+
+func (b *fileBrowser) layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	v, err := g.SetView("file_browser", maxX/8, maxY/8, 7*maxX/8, 7*maxY/8, 0)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = fmt.Sprintf("Open results file: %s (ENTER: Open, BACKSPACE: Up, ESC: Quit)", b.dir)
+	if b.problem != "" {
+		v.Title = "Cannot open directory: " + b.problem
+	}
+	if len(b.entries) == 0 {
+		v.Clear()
+		fmt.Fprintln(v, "No directories or JSON files here")
+	} else {
+		b.list.Render(v, true)
+	}
+	if _, err := g.SetCurrentView("file_browser"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b *fileBrowser) keybindings(g *gocui.Gui) error {
+	navigation := map[gocui.Key]func(){
+		gocui.KeyArrowUp:    func() { b.list.Navigate("up") },
+		gocui.KeyArrowDown:  func() { b.list.Navigate("down") },
+		gocui.KeyHome:       func() { b.list.Navigate("first") },
+		gocui.KeyEnd:        func() { b.list.Navigate("last") },
+		gocui.KeyPgup:       func() { b.list.NavigatePage("up") },
+		gocui.KeyPgdn:       func() { b.list.NavigatePage("down") },
+		gocui.KeyBackspace:  b.up,
+		gocui.KeyBackspace2: b.up,
+	}
+	for key, navigate := range navigation {
+		navigate := navigate
+		if err := g.SetKeybinding("file_browser", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			navigate()
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return g.SetKeybinding("file_browser", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if b.enter() {
+			return gocui.ErrQuit
+		}
+		return nil
+	})
+}
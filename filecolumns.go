@@ -0,0 +1,183 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/mattn/go-runewidth"
+)
+
+// fileColumn is one field of the column layout and the widest it can grow
+type fileColumn struct {
+	title    string
+	maxWidth int
+	keepTail bool // Truncate from the left, so paths keep their file name
+}
+
+var fileColumns = []fileColumn{
+	{title: "Status", maxWidth: 14},
+	{title: "Path", maxWidth: 60, keepTail: true},
+	{title: "Path %", maxWidth: 6},
+	{title: "Quality", maxWidth: 7},
+	{title: "PURL", maxWidth: 48},
+	{title: "License", maxWidth: 32},
+	{title: "Lines", maxWidth: 20},
+}
+
+// columnScrollStep is how far [<] and [>] scroll the column layout
+const columnScrollStep = 10
+
+// fileColumnFields returns the column values for a file
+func fileColumnFields(app *AppState, filePath string) []string {
+	matches := app.ScanData.Files[filePath]
+	fields := []string{strings.TrimSpace(statusMarker(app, audit.FileStatus(matches))), displayPath(app, filePath), "", "", "", "", ""}
+	if similarity := audit.MatchSimilarity(filePath, matches); similarity >= 0 {
+		fields[2] = strconv.Itoa(similarity) + "%"
+	}
+
+	match := audit.FirstValidMatch(matches)
+	if match == nil {
+		return fields
+	}
+	fields[3], _ = audit.BestQuality(match)
+	if len(match.Purl) > 0 {
+		fields[4] = displayPURL(app, match.Purl[0])
+		if len(match.Purl) > 1 {
+			fields[4] += " +" + strconv.Itoa(len(match.Purl)-1)
+		}
+	}
+	licenses := make([]string, 0, len(match.Licenses))
+	for _, license := range match.Licenses {
+		licenses = append(licenses, license.Name)
+	}
+	fields[5] = strings.Join(uniqueSorted(licenses), ", ")
+	if match.ID == "snippet" {
+		fields[6] = audit.ExtractMatchedLines(match)
+	} else {
+		fields[6] = match.ID
+	}
+	return fields
+}
+
+// truncateColumn shortens a value to width cells, marking the cut with "…"
+func truncateColumn(value string, width int, keepTail bool) string {
+	if runewidth.StringWidth(value) <= width {
+		return value
+	}
+	if width < 2 {
+		return runewidth.Truncate(value, width, "")
+	}
+	if keepTail {
+		return "…" + tailWidth(value, width-1)
+	}
+	return runewidth.Truncate(value, width, "…")
+}
+
+// formatFileColumns renders files as aligned status | path | path similarity
+// | quality | PURL | license | lines rows. Columns are as wide as their longest value up to a limit, and
+// the rows are shifted left by the horizontal scroll offset. Markers such as
+// "+new" are appended after the scrolled text so they stay visible.
+func formatFileColumns(app *AppState, files []string) []string {
+	rows := make([][]string, len(files))
+	widths := make([]int, len(fileColumns))
+	for i, column := range fileColumns {
+		widths[i] = runewidth.StringWidth(column.title)
+	}
+	for i, filePath := range files {
+		rows[i] = fileColumnFields(app, filePath)
+		for j, field := range rows[i] {
+			if length := runewidth.StringWidth(field); length > widths[j] {
+				widths[j] = length
+			}
+		}
+	}
+	for i, column := range fileColumns {
+		if widths[i] > column.maxWidth {
+			widths[i] = column.maxWidth
+		}
+	}
+
+	// Stop scrolling once the end of the widest row would leave the pane
+	rowWidth := 3 * (len(widths) - 1)
+	for _, width := range widths {
+		rowWidth += width
+	}
+	if app.ColumnOffset > rowWidth-columnScrollStep {
+		app.ColumnOffset = maxInt(rowWidth-columnScrollStep, 0)
+	}
+
+	separator := " │ "
+	if app.Accessible {
+		separator = " | "
+	}
+	lines := make([]string, len(files))
+	for i, filePath := range files {
+		var line strings.Builder
+		for j, field := range rows[i] {
+			if j > 0 {
+				line.WriteString(separator)
+			}
+			field = truncateColumn(field, widths[j], fileColumns[j].keepTail)
+			line.WriteString(field)
+			line.WriteString(strings.Repeat(" ", widths[j]-runewidth.StringWidth(field)))
+		}
+		text := dropCells(strings.TrimRight(line.String(), " "), app.ColumnOffset)
+		lines[i] = text + falsePositiveMarker(app, filePath) + ignoreListMarker(app, filePath) + deltaMarker(app, filePath) + driftMarker(app, filePath) + conflictMarker(app, filePath) + staleMarker(app, filePath) + qualityMarker(app, filePath) + candidateMarker(app, filePath)
+	}
+	return lines
+}
+
+// fileColumnsTitle names the columns in the file pane title
+func fileColumnsTitle(app *AppState) string {
+	titles := make([]string, len(fileColumns))
+	for i, column := range fileColumns {
+		titles[i] = column.title
+	}
+	title := "Files: " + strings.Join(titles, " | ")
+	if app.ColumnOffset > 0 {
+		title += " (scrolled " + strconv.Itoa(app.ColumnOffset) + ")"
+	}
+	return title
+}
+
+// toggleFileLayout switches the file list between plain paths and columns
+func toggleFileLayout(g *gocui.Gui, app *AppState) error {
+	if app.FileLayout == "columns" {
+		app.FileLayout = "paths"
+	} else {
+		app.FileLayout = "columns"
+	}
+	app.ColumnOffset = 0
+	saveFileLayout(app.FileLayout)
+
+	if app.ViewMode == "list" {
+		updateFileList(g, app)
+		updatePaneTitles(g, app)
+	}
+	if app.FileLayout == "columns" {
+		announce(app, "File list shows columns")
+	} else {
+		announce(app, "File list shows paths")
+	}
+	return nil
+}
+
+// scrollFileColumns moves the column layout left or right
+func scrollFileColumns(g *gocui.Gui, app *AppState, delta int) error {
+	if app.FileLayout != "columns" || app.ViewMode != "list" {
+		return nil
+	}
+	app.ColumnOffset += delta
+	if app.ColumnOffset < 0 {
+		app.ColumnOffset = 0
+	}
+	updateFileList(g, app)
+	updatePaneTitles(g, app)
+	return nil
+}
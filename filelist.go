@@ -4,14 +4,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"auditcmd/pkg/audit"
+
 	"github.com/awesome-gocui/gocui"
 )
 
@@ -33,18 +35,29 @@ func updateFileList(g *gocui.Gui, app *AppState) error {
 	node := app.TreeState.selectedNode
 	var files []string
 
-	if app.TreeViewType == "purls" {
-		// In PURL mode, show files from the selected PURL's file list
+	if groupedView(app) {
+		// In PURL and upstream modes, show files from the selected group's file list
 		if len(node.Files) > 0 {
 			files = node.Files
 		}
 	} else {
-		// In directory mode, show files in the selected directory
+		// In directory mode, show files in the selected directory, or in
+		// the directory of the selected file leaf
+		if treeFile(app, node) != "" {
+			node = node.Parent
+		}
 		if !node.IsDir {
 			return nil
 		}
 		files = getFilesInDirectory(app, node.Path)
 	}
+	if app.SimilarityMode != "" {
+		files = sortBySimilarity(app, files)
+	}
+	// The quality order comes first, the similarity order among equal scores
+	if app.QualityMode != "" {
+		files = sortByQuality(app, files)
+	}
 	
 	// Filter and format files with status indicators
 	displayFiles := make([]string, 0)
@@ -54,61 +67,35 @@ func updateFileList(g *gocui.Gui, app *AppState) error {
 		matches := app.ScanData.Files[filePath]
 
 		// Apply view filter
-		shouldShow := false
-		statusIcon := "- "
-
-		if app.ViewFilter == "all" {
-			shouldShow = true
+		if !audit.MatchesFilter(matches, app.ViewFilter) || !inScope(app, filePath) {
+			continue
 		}
-
-		if len(matches) > 0 {
-			// Find the first valid match (file or snippet)
-			var match *FileMatch
-			for j, m := range matches {
-				if m.ID == "file" || m.ID == "snippet" {
-					match = &matches[j]
-					break
-				}
-			}
-
-			if match != nil {
-				if app.ViewFilter == "matched" || app.ViewFilter == "all" {
-					shouldShow = true
-				}
-
-				// Check if file has been processed
-				isProcessed := len(match.AuditCmd) > 0
-				if isProcessed {
-					latest := match.AuditCmd[len(match.AuditCmd)-1]
-					decision := strings.ToLower(strings.TrimSpace(latest.Decision))
-					if decision == "identified" {
-						statusIcon = "✓ "
-					} else {
-						statusIcon = "✗ "
-					}
-				} else {
-					statusIcon = "? "
-					if app.ViewFilter == "pending" {
-						shouldShow = true
-					}
-				}
-			}
+		if app.FileLayout == "columns" {
+			filteredFiles = append(filteredFiles, filePath)
+			continue
 		}
+		status := audit.FileStatus(matches)
 
-		if shouldShow {
-			// Apply path highlighting if there are matches
-			highlightedPath := filePath
-			if len(matches) > 0 {
-				highlightedPath = highlightMatchingPath(filePath, matches)
-			}
-			displayFiles = append(displayFiles, statusIcon+highlightedPath)
-			filteredFiles = append(filteredFiles, filePath) // Keep track of filtered file paths
+		// Apply path highlighting if there are matches
+		highlightedPath, similarity := highlightMatchingPath(filePath, matches)
+		if app.Redact {
+			// The matched path would reveal the component, so skip highlighting
+			highlightedPath = redactPath(filePath)
 		}
+		displayFiles = append(displayFiles, statusMarker(app, status)+highlightedPath+similarityMarker(app, similarity)+falsePositiveMarker(app, filePath)+ignoreListMarker(app, filePath)+deltaMarker(app, filePath)+driftMarker(app, filePath)+conflictMarker(app, filePath)+staleMarker(app, filePath)+qualityMarker(app, filePath)+candidateMarker(app, filePath))
+		filteredFiles = append(filteredFiles, filePath) // Keep track of filtered file paths
+	}
+
+	if app.FileLayout == "columns" {
+		displayFiles = formatFileColumns(app, filteredFiles)
 	}
 
 	// Update our custom scrollable list
 	app.FileList.SetItems(displayFiles)
 	app.CurrentFileList = filteredFiles // Keep filtered file paths for selection
+	if file := selectedTreeFile(app); file != "" {
+		selectTreeFileInList(app, file)
+	}
 
 	// Sync the selected index after updating the list
 	app.SelectedFileIndex = app.FileList.GetSelectedIndex()
@@ -121,48 +108,124 @@ func updateFileList(g *gocui.Gui, app *AppState) error {
 }
 
 func getFilesInDirectory(app *AppState, dirPath string) []string {
-	files := make([]string, 0)
-	
-	// If dirPath is empty (root), show all files
-	// Otherwise, show files that are in this directory or subdirectories
-	for filePath, matches := range app.ScanData.Files {
-		
-		if app.ViewFilter == "all" {
-			// In "all" mode, include all files regardless of match status
-		} else {
-			// Filter by match type - only show files with id = "file" or "snippet"
-			hasValidMatch := false
-			for _, match := range matches {
-				if match.ID == "file" || match.ID == "snippet" {
-					hasValidMatch = true
-					break
-				}
-			}
-			
-			if !hasValidMatch {
-				continue
-			}
+	// Files in this directory or its subdirectories, sorted by path
+	return filterScope(app, audit.FilesInDirectory(&app.ScanData, dirPath, app.ViewFilter))
+}
+
+// scopeActive reports whether a toggle narrows the files beyond the view filter
+func scopeActive(app *AppState) bool {
+	return app.DeltaOnly || app.DriftOnly || app.HideIdentified || app.SimilarityMode == "low" || app.QualityMode == "low" || app.AssessmentPattern != nil || app.ReviewMode
+}
+
+// inScope reports whether a file is shown given the new-only, drifted-only,
+// hide decided, low path similarity and low quality toggles, the assessment filter and
+// review mode
+func inScope(app *AppState, filePath string) bool {
+	if !reviewInScope(app, filePath) || !assessmentInScope(app, filePath) {
+		return false
+	}
+	if app.SimilarityMode == "low" && !lowSimilarity(app, filePath) {
+		return false
+	}
+	if app.QualityMode == "low" && !lowQuality(app, filePath) {
+		return false
+	}
+	if app.DeltaOnly && app.Delta[filePath] != audit.DeltaNew {
+		return false
+	}
+	if app.DriftOnly && app.Drift[filePath] == "" {
+		return false
+	}
+	if app.HideIdentified {
+		if status := audit.FileStatus(app.ScanData.Files[filePath]); status == audit.StatusIdentified || status == audit.StatusIgnored {
+			return false
 		}
-		
-		// Check if file is in the selected directory or its subdirectories
-		if dirPath == "" {
-			// Root directory - only show files with no "/" (actual root files)
-			if !strings.Contains(filePath, "/") {
-				files = append(files, filePath)
-			}
-		} else {
-			// Check if file is in this directory or subdirectories
-			if strings.HasPrefix(filePath, dirPath+"/") {
-				files = append(files, filePath)
+	}
+	return true
+}
+
+// filterScope drops files hidden by the scope toggles
+func filterScope(app *AppState, files []string) []string {
+	if !scopeActive(app) {
+		return files
+	}
+	scoped := make([]string, 0, len(files))
+	for _, filePath := range files {
+		if inScope(app, filePath) {
+			scoped = append(scoped, filePath)
+		}
+	}
+	return scoped
+}
+
+// refreshScope redraws the tree and file list after a scope toggle, moving
+// the selection if the selected node is no longer shown
+func refreshScope(g *gocui.Gui, app *AppState) {
+	updateTreeDisplay(app)
+	if len(app.TreeState.displayLines) > 0 {
+		visible := false
+		for _, line := range app.TreeState.displayLines {
+			if line.Node == app.TreeState.selectedNode {
+				visible = true
+				break
 			}
 		}
+		if !visible {
+			app.TreeState.selectedNode = app.TreeState.displayLines[0].Node
+			app.TreeList.SelectedIndex = 0
+			app.TreeList.adjustScroll()
+		}
 	}
-	
-	// Sort files by path
-	sort.Strings(files)
-	return files
+
+	displayTree(g, app)
+	updateFileList(g, app)
+	updateStatus(g, app)
+}
+
+// refreshDecisions recomputes the tree and file list counts, the view
+// filters and the progress bar after decisions changed in bulk, and repaints
+// them in one pass rather than once per decision
+func refreshDecisions(g *gocui.Gui, app *AppState) {
+	app.CurrentMatch = nil
+	if app.TreeState == nil {
+		return
+	}
+	if app.TreeFiles && !groupedView(app) {
+		// Also moves the selection off a leaf the view filter now hides
+		refreshTreeFiles(g, app)
+	} else {
+		refreshScope(g, app)
+	}
+	displayProgressBar(g, app)
+	updateHelpBar(g, app)
+	checkMilestones(g, app)
 }
 
+// refreshAll rebuilds the component ranking and repaints everything from
+// the decisions in memory, for when a count looks out of date
+func refreshAll(g *gocui.Gui, app *AppState) error {
+	buildPURLRanking(app)
+	refreshDecisions(g, app)
+	announce(app, "Refreshed counts and filters")
+	return nil
+}
+
+// toggleHideIdentified hides or shows the identified and ignored files in
+// every view filter, and remembers the choice in the config
+func toggleHideIdentified(g *gocui.Gui, app *AppState) error {
+	app.HideIdentified = !app.HideIdentified
+	if err := saveHideIdentified(app.HideIdentified); err != nil {
+		// Don't fail the toggle if the config can't be saved
+	}
+
+	refreshScope(g, app)
+	if app.HideIdentified {
+		announce(app, "Hiding %s and %s files, %d items", stateLabel(app, audit.StatusIdentified), stateLabel(app, audit.StatusIgnored), len(app.TreeList.Items))
+	} else {
+		announce(app, "Showing %s and %s files, %d items", stateLabel(app, audit.StatusIdentified), stateLabel(app, audit.StatusIgnored), len(app.TreeList.Items))
+	}
+	return nil
+}
 
 func displayFileContent(g *gocui.Gui, app *AppState, filePath string) error {
 	v, err := g.View("files")
@@ -182,14 +245,7 @@ func displayFileContent(g *gocui.Gui, app *AppState, filePath string) error {
 	}
 
 	// Find the first valid match (file or snippet)
-	var match *FileMatch
-	for i, m := range matches {
-		if m.ID == "file" || m.ID == "snippet" {
-			match = &matches[i]
-			break
-		}
-	}
-	
+	match := audit.FirstValidMatch(matches)
 	if match == nil {
 		fmt.Fprintf(v, "No valid matches found for this file")
 		return nil
@@ -197,112 +253,167 @@ func displayFileContent(g *gocui.Gui, app *AppState, filePath string) error {
 
 	app.CurrentMatch = match
 
+	if match.ID == audit.MatchDependency {
+		displayDependency(v, app, filePath, match)
+		return nil
+	}
+
+	if app.ContentSide == "local" {
+		return displayLocalContent(v, app, filePath, match)
+	}
+
 	// Check if file_url is empty or only whitespace
 	if strings.TrimSpace(match.FileURL) == "" {
 		fmt.Fprintf(v, "No file_url available for this file. This requires scanning with an API key.")
 		return nil
 	}
 
-	if app.APIKey == "" {
-			fmt.Fprintf(v, "File Content Not Available\n")
-			fmt.Fprintf(v, "========================\n\n")
-			fmt.Fprintf(v, "API key required to fetch file contents from:\n")
-			fmt.Fprintf(v, "%s\n\n", match.FileURL)
-			fmt.Fprintf(v, "To view file contents:\n")
-			fmt.Fprintf(v, "1. Exit the application\n")
-			fmt.Fprintf(v, "2. Run: ./auditcmd --reset-api-key\n")
-			fmt.Fprintf(v, "3. Restart and provide your API key\n\n")
-			fmt.Fprintf(v, "You can still navigate, review, and audit files\n")
-			fmt.Fprintf(v, "based on the metadata shown in the status panel.")
-		} else {
-			content, err := fetchFileContent(match.FileURL, app.APIKey)
-			if err != nil {
-				// Check if it's a timeout error
-				if strings.Contains(err.Error(), "TIMEOUT") {
-					fmt.Fprintf(v, "TIMEOUT WHEN RETRIEVING FILE")
-					return nil
-				}
-
-				fmt.Fprintf(v, "Error fetching file content: %v\n\n", err)
-				fmt.Fprintf(v, "This may indicate:\n")
-				fmt.Fprintf(v, "• Invalid API key\n")
-				fmt.Fprintf(v, "• Network connectivity issues\n")
-				fmt.Fprintf(v, "• API service unavailable\n\n")
-				fmt.Fprintf(v, "Try running: ./auditcmd --reset-api-key")
+	if apiKeyFor(app, match.FileURL) == "" {
+		fmt.Fprintf(v, "File Content Not Available\n")
+		fmt.Fprintf(v, "========================\n\n")
+		fmt.Fprintf(v, "API key required to fetch file contents from:\n")
+		fmt.Fprintf(v, "%s\n\n", match.FileURL)
+		fmt.Fprintf(v, "To view file contents:\n")
+		fmt.Fprintf(v, "1. Exit the application\n")
+		fmt.Fprintf(v, "2. Run: ./auditcmd --reset-api-key\n")
+		fmt.Fprintf(v, "3. Restart and provide your API key\n\n")
+		fmt.Fprintf(v, "You can still navigate, review, and audit files\n")
+		fmt.Fprintf(v, "based on the metadata shown in the status panel.")
+	} else {
+		content, err := loadFileContent(g, app, match.FileURL)
+		if err == errOffline {
+			fmt.Fprintf(v, "OFFLINE: the SCANOSS API can't be reached\n\n")
+			fmt.Fprintf(v, "This file wasn't opened before the connection was lost, so its\n")
+			fmt.Fprintf(v, "content isn't available. Files opened earlier in this session can\n")
+			fmt.Fprintf(v, "still be viewed, and audit decisions work as usual.\n\n")
+			fmt.Fprintf(v, "Connectivity is checked every %d seconds; this view refreshes\n", int(offlineRetryInterval.Seconds()))
+			fmt.Fprintf(v, "when the API is reachable again.")
+			return nil
+		}
+		if err != nil {
+			// Check if it's a timeout error
+			if strings.Contains(err.Error(), "TIMEOUT") {
+				fmt.Fprintf(v, "TIMEOUT WHEN RETRIEVING FILE")
 				return nil
 			}
 
-			lines := strings.Split(content, "\n")
-			highlightLines := parseOSSLines(match.OSSLines)
-
-			// Display all content at once and let gocui handle scrolling
-			for i, line := range lines {
-				lineNum := i + 1
+			fmt.Fprintf(v, "Error fetching file content: %v\n\n", err)
+			fmt.Fprintf(v, "This may indicate:\n")
+			fmt.Fprintf(v, "• Invalid API key\n")
+			fmt.Fprintf(v, "• Network connectivity issues\n")
+			fmt.Fprintf(v, "• API service unavailable\n\n")
+			fmt.Fprintf(v, "Try running: ./auditcmd --reset-api-key")
+			return nil
+		}
+		startPrefetch(g, app, filePath)
+		if app.ContentHex || looksBinary([]byte(content)) {
+			writeHexDump(v, []byte(content))
+			return nil
+		}
 
-				// Highlight logic based on match type
-				shouldHighlight := false
-				if match.ID == "file" {
-					// For "file" type, highlight the entire file
+		// Files checked out on Windows use CRLF line endings
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+		lines := strings.Split(content, "\n")
+		highlightLines := parseOSSLines(match.OSSLines)
+		writeRangePairs(v, app, filePath, match)
+
+		// Display all content at once and let gocui handle scrolling
+		for i, line := range lines {
+			lineNum := i + 1
+			line = escapeControls(line)
+
+			// Highlight logic based on match type
+			shouldHighlight := false
+			if match.ID == "file" {
+				// For "file" type, highlight the entire file
+				shouldHighlight = true
+			} else if match.ID == "snippet" && highlightLines != nil {
+				// For "snippet" type, check if we should highlight
+				if len(highlightLines) > 0 && highlightLines[0] == -1 {
+					// Special marker -1 means highlight all lines
 					shouldHighlight = true
-				} else if match.ID == "snippet" && highlightLines != nil {
-					// For "snippet" type, check if we should highlight
-					if len(highlightLines) > 0 && highlightLines[0] == -1 {
-						// Special marker -1 means highlight all lines
-						shouldHighlight = true
-					} else {
-						// Only highlight specific lines
-						shouldHighlight = contains(highlightLines, lineNum)
-					}
-				}
-
-				if shouldHighlight {
-					fmt.Fprintf(v, "\033[43m\033[30m%4d: %s\033[0m\n", lineNum, line)
 				} else {
-					fmt.Fprintf(v, "%4d: %s\n", lineNum, line)
+					// Only highlight specific lines
+					shouldHighlight = contains(highlightLines, lineNum)
 				}
 			}
+
+			if shouldHighlight && app.Accessible {
+				// Matched lines are flagged with "*" instead of colour
+				fmt.Fprintf(v, "%4d* %s\n", lineNum, line)
+			} else if shouldHighlight {
+				fmt.Fprintf(v, "\033[43m\033[30m%4d: %s\033[0m\n", lineNum, line)
+			} else {
+				fmt.Fprintf(v, "%4d: %s\n", lineNum, line)
+			}
 		}
+	}
 
 	return nil
 }
 
-func fetchFileContent(url string, apiKey string) (string, error) {
+// contentResponse is the outcome of a file content request
+type contentResponse struct {
+	Content     string
+	ETag        string
+	NotModified bool      // The cached copy sent with If-None-Match is current
+	Quota       *apiQuota // Remaining API quota, when the server reports it
+}
+
+// fetchFileContent downloads a matched file. With the ETag of a cached copy
+// the request is conditional, and an unchanged file costs no download. The
+// quota is returned when the server reports it, also for failed requests.
+// Cancelling ctx aborts the request with ctx's error.
+func fetchFileContent(ctx context.Context, url string, apiKey string, etag string) (contentResponse, error) {
 	// Create HTTP client with 15 second timeout
 	client := &http.Client{
 		Timeout: 15 * time.Second,
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %v", err)
+		return contentResponse{}, fmt.Errorf("failed to create request: %v", err)
 	}
 
 	// Add required headers as per curl example
 	req.Header.Set("X-API-Key", apiKey)
 	req.Header.Set("Content-Type", "application/json")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
 
-	resp, err := client.Do(req)
+	resp, err := network.do(client, req)
 	if err != nil {
+		if ctx.Err() != nil {
+			return contentResponse{}, ctx.Err()
+		}
 		// Check if it's a timeout error
 		if strings.Contains(err.Error(), "deadline exceeded") || strings.Contains(err.Error(), "timeout") {
-			return "", fmt.Errorf("TIMEOUT")
+			return contentResponse{}, fmt.Errorf("TIMEOUT: %w", errAPIUnreachable)
 		}
-		return "", fmt.Errorf("HTTP request failed: %v", err)
+		return contentResponse{}, fmt.Errorf("HTTP request failed: %v (%w)", err, errAPIUnreachable)
 	}
 	defer resp.Body.Close()
+	response := contentResponse{ETag: resp.Header.Get("ETag"), Quota: quotaFromHeaders(resp.Header)}
+
+	if etag != "" && resp.StatusCode == http.StatusNotModified {
+		response.NotModified = true
+		return response, nil
+	}
 
 	// Read response body
 	content, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %v", err)
+		return response, fmt.Errorf("failed to read response body: %v", err)
 	}
 
 	// Check for API errors
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(content))
+		return response, fmt.Errorf("API error %d: %s", resp.StatusCode, string(content))
 	}
 
-	return string(content), nil
+	response.Content = string(content)
+	return response, nil
 }
 
 func parseOSSLines(ossLines interface{}) []int {
@@ -355,36 +466,22 @@ func parseOSSLines(ossLines interface{}) []int {
 	return nil
 }
 
-// highlightMatchingPath highlights the parts of filePath that match with the matched file path
-func highlightMatchingPath(filePath string, matches []FileMatch) string {
-	if len(matches) == 0 {
-		return filePath
-	}
-
-	// Find the first valid match (file or snippet)
-	var matchedPath string
-	for _, match := range matches {
-		if match.ID == "file" || match.ID == "snippet" {
-			matchedPath = match.File
-			break
-		}
-	}
-
-	if matchedPath == "" {
-		return filePath
+// highlightMatchingPath highlights the parts of filePath that match with the
+// matched file path and returns the path similarity (-1 without a match)
+func highlightMatchingPath(filePath string, matches []FileMatch) (string, int) {
+	similarity := audit.MatchSimilarity(filePath, matches)
+	if similarity <= 0 {
+		return filePath, similarity
 	}
 
 	// Find the longest common suffix between filePath and matchedPath
-	commonSuffix := findCommonSuffix(filePath, matchedPath)
-	if commonSuffix == "" {
-		return filePath
-	}
+	commonSuffix := audit.CommonPathSuffix(filePath, audit.FirstValidMatch(matches).File)
 
 	// Find where the common suffix starts in filePath
 	suffixStart := len(filePath) - len(commonSuffix)
 	if suffixStart <= 0 {
 		// The entire path matches, highlight everything
-		return "\033[43m\033[30m" + filePath + "\033[0m"
+		return "\033[43m\033[30m" + filePath + "\033[0m", similarity
 	}
 
 	// Split the path into non-matching and matching parts
@@ -392,31 +489,7 @@ func highlightMatchingPath(filePath string, matches []FileMatch) string {
 	suffix := filePath[suffixStart:]
 
 	// Return with highlighting on the matching suffix
-	return prefix + "\033[43m\033[30m" + suffix + "\033[0m"
-}
-
-// findCommonSuffix finds the longest common suffix between two paths
-func findCommonSuffix(path1, path2 string) string {
-	// Split paths into components
-	parts1 := strings.Split(path1, "/")
-	parts2 := strings.Split(path2, "/")
-
-	// Find common suffix components
-	i := len(parts1) - 1
-	j := len(parts2) - 1
-	var commonParts []string
-
-	for i >= 0 && j >= 0 && parts1[i] == parts2[j] {
-		commonParts = append([]string{parts1[i]}, commonParts...)
-		i--
-		j--
-	}
-
-	if len(commonParts) == 0 {
-		return ""
-	}
-
-	return strings.Join(commonParts, "/")
+	return prefix + "\033[43m\033[30m" + suffix + "\033[0m", similarity
 }
 
 func contains(slice []int, item int) bool {
@@ -431,7 +504,11 @@ func contains(slice []int, item int) bool {
 func navigateFileList(g *gocui.Gui, app *AppState, direction string) error {
 	// Use our custom scrollable list for navigation
 	app.FileList.Navigate(direction)
-	
+	return showFileSelection(g, app)
+}
+
+// showFileSelection follows the selection of the file list after it moved
+func showFileSelection(g *gocui.Gui, app *AppState) error {
 	// Update selected file index to match
 	app.SelectedFileIndex = app.FileList.GetSelectedIndex()
 	
@@ -440,6 +517,7 @@ func navigateFileList(g *gocui.Gui, app *AppState, direction string) error {
 		isActive := (app.ActivePane == "files")
 		app.FileList.Render(v, isActive)
 	}
+	announceFileSelection(app)
 	
 	return nil
 }
@@ -447,17 +525,7 @@ func navigateFileList(g *gocui.Gui, app *AppState, direction string) error {
 func navigateFileListPage(g *gocui.Gui, app *AppState, direction string) error {
 	// Use our custom scrollable list for page navigation
 	app.FileList.NavigatePage(direction)
-	
-	// Update selected file index to match
-	app.SelectedFileIndex = app.FileList.GetSelectedIndex()
-	
-	// Re-render the list
-	if v, err := g.View("files"); err == nil {
-		isActive := (app.ActivePane == "files")
-		app.FileList.Render(v, isActive)
-	}
-	
-	return nil
+	return showFileSelection(g, app)
 }
 
 func selectFile(g *gocui.Gui, app *AppState) error {
@@ -503,6 +571,10 @@ func scrollFileContent(g *gocui.Gui, app *AppState, direction string, pageMode b
 	case "down":
 		newY := oy + scrollAmount
 		v.SetOrigin(ox, newY)
+	case "first":
+		v.SetOrigin(ox, 0)
+	case "last":
+		v.SetOrigin(ox, max(v.LinesHeight()-viewHeight, 0))
 	}
 
 	return nil
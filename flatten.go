@@ -0,0 +1,52 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import "strings"
+
+// flattenedDir reports whether node is shown on one line together with its
+// only subdirectory, as with flatten_dirs: it holds no files of its own
+// that pass the view filter, and a single subdirectory holds them all.
+func flattenedDir(app *AppState, node *TreeNode) bool {
+	if app == nil || !app.FlattenDirs || !node.IsDir || node == app.FileTree || len(node.Children) != 1 {
+		return false
+	}
+	child := node.Children[0]
+	return child.IsDir && countFilesInDirectory(node.Path) == countFilesInDirectory(child.Path)
+}
+
+// displayedDir is the directory whose line shows node: the bottom of the
+// flattened chain node is part of, or node itself
+func displayedDir(app *AppState, node *TreeNode) *TreeNode {
+	for node != nil && flattenedDir(app, node) {
+		node = node.Children[0]
+	}
+	return node
+}
+
+// chainTop is the top directory of the flattened chain node ends, or node
+// itself
+func chainTop(app *AppState, node *TreeNode) *TreeNode {
+	for node != nil && node.Parent != nil && flattenedDir(app, node.Parent) {
+		node = node.Parent
+	}
+	return node
+}
+
+// dirLabel names the tree line of the chain starting at node, e.g.
+// "main/java/com/acme", and returns the directory that line stands for
+func dirLabel(app *AppState, node *TreeNode) (string, *TreeNode) {
+	names := make([]string, 0, 1)
+	for {
+		name := node.Name
+		if app != nil && app.Redact && node.Path != "" {
+			name = redactComponent(name)
+		}
+		names = append(names, name)
+		if !flattenedDir(app, node) {
+			return strings.Join(names, "/"), node
+		}
+		node = node.Children[0]
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"auditcmd/pkg/audit"
+)
+
+// gitRepoRoot returns the top-level directory of the repository containing
+// path, or an error if it isn't inside a git work tree
+func gitRepoRoot(path string) (string, error) {
+	dir := filepath.Dir(path)
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", fmt.Errorf("%s is not inside a git repository", path)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// runGit runs a git command in dir, returning stderr in the error
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return fmt.Errorf("git %s: %v", args[0], err)
+	}
+	return nil
+}
+
+// recordDecisionForCommit queues a saved decision and commits the results file
+// once git_commit_every decisions have accumulated
+func recordDecisionForCommit(app *AppState, filePath string, match *FileMatch, decision AuditDecision) error {
+	if app.GitCommitEvery <= 0 {
+		return nil
+	}
+
+	line := fmt.Sprintf("%s %s", decision.Decision, displayPath(app, filePath))
+	if match != nil && len(match.Purl) > 0 {
+		line += fmt.Sprintf(" (%s)", displayPURL(app, match.Purl[0]))
+	}
+	if decision.Assessment != "" {
+		line += ": " + oneLine(decision.Assessment)
+	}
+	app.UncommittedDecisions = append(app.UncommittedDecisions, line)
+
+	if len(app.UncommittedDecisions) < app.GitCommitEvery {
+		return nil
+	}
+	return commitPendingDecisions(app)
+}
+
+// commitPendingDecisions commits the results file with a message listing the
+// decisions made since the last commit. Only the results file is committed,
+// whatever else is staged in the repository.
+func commitPendingDecisions(app *AppState) error {
+	if app.GitCommitEvery <= 0 || len(app.UncommittedDecisions) == 0 {
+		return nil
+	}
+
+	root, err := gitRepoRoot(app.FilePath)
+	if err != nil {
+		return err
+	}
+	absPath, err := filepath.Abs(app.FilePath)
+	if err != nil {
+		return err
+	}
+
+	if err := runGit(root, "add", "--", absPath); err != nil {
+		return err
+	}
+	if err := runGit(root, "commit", "--quiet", "-m", gitCommitMessage(app), "--", absPath); err != nil {
+		return err
+	}
+	app.UncommittedDecisions = nil
+	return nil
+}
+
+// gitCommitMessage builds the structured message for an audit commit
+func gitCommitMessage(app *AppState) string {
+	decisions := app.UncommittedDecisions
+	noun := "decisions"
+	if len(decisions) == 1 {
+		noun = "decision"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "audit: %d %s in %s\n\n", len(decisions), noun, filepath.Base(app.FilePath))
+	for _, line := range decisions {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	audited, total, percentage := audit.Progress(&app.ScanData)
+	fmt.Fprintf(&b, "\nProgress: %d/%d files (%d%%)\n", audited, total, percentage)
+	return b.String()
+}
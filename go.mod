@@ -3,13 +3,16 @@ module auditcmd
 go 1.24.4
 
 require (
-	github.com/awesome-gocui/gocui v1.1.0 // indirect
+	github.com/awesome-gocui/gocui v1.1.0
+	github.com/mattn/go-runewidth v0.0.10
+	golang.org/x/term v0.34.0
+	golang.org/x/text v0.3.3
+)
+
+require (
 	github.com/gdamore/encoding v1.0.0 // indirect
 	github.com/gdamore/tcell/v2 v2.4.0 // indirect
 	github.com/lucasb-eyer/go-colorful v1.0.3 // indirect
-	github.com/mattn/go-runewidth v0.0.10 // indirect
 	github.com/rivo/uniseg v0.1.0 // indirect
 	golang.org/x/sys v0.35.0 // indirect
-	golang.org/x/term v0.34.0 // indirect
-	golang.org/x/text v0.3.3 // indirect
 )
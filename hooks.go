@@ -0,0 +1,106 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// hookTimeout bounds how long a hook command may run
+const hookTimeout = 30 * time.Second
+
+// DecisionEvent is the JSON document sent on stdin to the on_decision hook
+type DecisionEvent struct {
+	ResultsFile string                `json:"results_file"`
+	File        string                `json:"file"`
+	PURL        string                `json:"purl"`
+	PURLs       []string              `json:"purls"`
+	MatchType   string                `json:"match_type"`
+	Decision    string                `json:"decision"`
+	Assessment  string                `json:"assessment"`
+	Reviewer    string                `json:"reviewer,omitempty"`
+	Auditor     string                `json:"auditor,omitempty"`
+	Duration    int                   `json:"duration_seconds,omitempty"`
+	Source      string                `json:"source,omitempty"`
+	Checklist   []audit.ChecklistItem `json:"checklist,omitempty"`
+	Timestamp   time.Time             `json:"timestamp"`
+}
+
+// shellCommand builds a command that runs line through the platform shell
+func shellCommand(ctx context.Context, line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", line)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", line)
+}
+
+// runHook runs a hook command with payload encoded as JSON on stdin
+func runHook(command string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode hook payload: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, command)
+	cmd.Stdin = bytes.NewReader(data)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%v: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// fireDecisionHook runs the configured on_decision command in the background
+// so a slow integration never blocks the audit
+func fireDecisionHook(g *gocui.Gui, app *AppState, filePath string, match *FileMatch, decision AuditDecision) {
+	if app.OnDecisionHook == "" || match == nil {
+		return
+	}
+
+	event := DecisionEvent{
+		ResultsFile: app.FilePath,
+		File:        filePath,
+		PURLs:       match.Purl,
+		MatchType:   match.ID,
+		Decision:    decision.Decision,
+		Assessment:  decision.Assessment,
+		Reviewer:    decision.Reviewer,
+		Auditor:     decision.Auditor,
+		Duration:    decision.Duration,
+		Source:      decision.Source,
+		Checklist:   decision.Checklist,
+		Timestamp:   decision.Timestamp,
+	}
+	if len(match.Purl) > 0 {
+		event.PURL = match.Purl[0]
+	}
+
+	command := app.OnDecisionHook
+	go func() {
+		if err := runHook(command, event); err != nil {
+			g.Update(func(g *gocui.Gui) error {
+				return showErrorDialog(g, app, "Hook Error", fmt.Sprintf("on_decision hook failed: %v", err))
+			})
+		}
+	}()
+}
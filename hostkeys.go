@@ -0,0 +1,169 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// urlHost returns the host, with port if any, of a URL in lower case, or ""
+// when it has none
+func urlHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Host)
+}
+
+// hostAPIKey returns the key configured with api_key.<host> for the host of
+// rawURL, tried with and then without its port, or fallback
+func hostAPIKey(hostKeys map[string]string, fallback, rawURL string) string {
+	host := urlHost(rawURL)
+	if host == "" {
+		return fallback
+	}
+	if key, ok := hostKeys[host]; ok {
+		return key
+	}
+	if u, err := url.Parse(rawURL); err == nil {
+		if key, ok := hostKeys[strings.ToLower(u.Hostname())]; ok {
+			return key
+		}
+	}
+	return fallback
+}
+
+// apiKeyFor returns the API key sent with requests to rawURL, so results
+// referencing several SCANOSS instances, e.g. on-premises and SaaS, fetch
+// from each with its own key
+func apiKeyFor(app *AppState, rawURL string) string {
+	return hostAPIKey(app.HostKeys, app.APIKey, rawURL)
+}
+
+// apiKeysFor returns apiKeyFor as configured now, for background requests
+// that run while the config may be reloaded
+func apiKeysFor(app *AppState) func(string) string {
+	hostKeys, fallback := app.HostKeys, app.APIKey
+	return func(rawURL string) string {
+		return hostAPIKey(hostKeys, fallback, rawURL)
+	}
+}
+
+// hasAPIKey reports whether any API key is configured
+func hasAPIKey(app *AppState) bool {
+	for _, key := range app.HostKeys {
+		if key != "" {
+			return true
+		}
+	}
+	return app.APIKey != ""
+}
+
+// addConfigHostKey records an api_key.<host> or api_key_encrypted.<host>
+// entry, named by prefix, in keys
+func addConfigHostKey(config *Config, keys map[string]string, prefix, name, value string) {
+	host := strings.ToLower(strings.TrimSpace(name))
+	if host == "" || strings.Contains(host, "/") {
+		config.Warnings = append(config.Warnings, fmt.Sprintf("invalid %s.%s (use %s.<host>, e.g. %s.osskb.example.com)", prefix, name, prefix, prefix))
+		return
+	}
+	keys[host] = value
+}
+
+// configHostKeyLines writes the <prefix>.<host> entries, sorted by host
+func configHostKeyLines(prefix string, hostKeys map[string]string) string {
+	hosts := make([]string, 0, len(hostKeys))
+	for host := range hostKeys {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	content := ""
+	for _, host := range hosts {
+		content += fmt.Sprintf("%s.%s=%s\n", prefix, host, hostKeys[host])
+	}
+	return content
+}
+
+// decryptHostKeys returns the keys per host, the encrypted ones decrypted.
+// Keys that can't be decrypted are left out and reported as problems.
+func decryptHostKeys(config *Config) (map[string]string, []string) {
+	if len(config.HostKeysEncrypted) == 0 {
+		return config.HostKeys, nil
+	}
+	keys := make(map[string]string, len(config.HostKeys)+len(config.HostKeysEncrypted))
+	for host, key := range config.HostKeys {
+		keys[host] = key
+	}
+	var problems []string
+	for host, encrypted := range config.HostKeysEncrypted {
+		key, err := decryptAPIKey(encrypted)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("could not decrypt api_key_encrypted.%s: %v", host, err))
+			continue
+		}
+		keys[host] = key
+	}
+	sort.Strings(problems)
+	return keys, problems
+}
+
+// encryptHostKeys replaces the api_key.<host> keys stored in plain text by
+// api_key_encrypted.<host> ones, reporting how many were encrypted
+func encryptHostKeys(config *Config, mode string) (int, error) {
+	count := 0
+	for host, key := range config.HostKeys {
+		encrypted, err := encryptAPIKey(key, mode)
+		if err != nil {
+			return count, err
+		}
+		config.HostKeysEncrypted[host] = encrypted
+		delete(config.HostKeys, host)
+		count++
+	}
+	return count, nil
+}
+
+// sealHostKeys encrypts host keys added in plain text while
+// api_key_encryption is set, as a new main API key would be
+func sealHostKeys() error {
+	config, err := loadConfig()
+	if err != nil || config.APIKeyEncryption == "" || len(config.HostKeys) == 0 {
+		return err
+	}
+	count, err := encryptHostKeys(config, config.APIKeyEncryption)
+	if err != nil {
+		return err
+	}
+	if err := saveConfig(config); err != nil {
+		return err
+	}
+	fmt.Printf("Encrypted the API keys of %d hosts in %s\n", count, getConfigFilePath())
+	return nil
+}
+
+// printHostKeyStatus lists the hosts with their own API key for
+// --api-key-status, without the keys
+func printHostKeyStatus(config *Config) {
+	hosts := make([]string, 0, len(config.HostKeys)+len(config.HostKeysEncrypted))
+	for host := range config.HostKeys {
+		hosts = append(hosts, host)
+	}
+	for host := range config.HostKeysEncrypted {
+		if _, ok := config.HostKeys[host]; !ok {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		if encrypted, ok := config.HostKeysEncrypted[host]; ok {
+			fmt.Printf("API key for %s: configured, encrypted with %s\n", host, encryptionDescription(encryptedMode(encrypted)))
+		} else {
+			fmt.Printf("API key for %s: configured\n", host)
+		}
+	}
+}
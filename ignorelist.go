@@ -0,0 +1,176 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// ignoreListMarker flags files ignored because their component is on the
+// always-ignore list
+func ignoreListMarker(app *AppState, filePath string) string {
+	match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+	if match == nil || match.LatestDecision() == nil || match.LatestDecision().Source != audit.SourceIgnoreList {
+		return ""
+	}
+	if app.Accessible {
+		return " [always ignored]"
+	}
+	return " \033[90m∅\033[0m"
+}
+
+// ignoreListLabel notes a decision taken from the always-ignore list, e.g.
+// " | From the always-ignore list", or "" for decisions made by hand
+func ignoreListLabel(match *FileMatch) string {
+	if latest := match.LatestDecision(); latest == nil || latest.Source != audit.SourceIgnoreList {
+		return ""
+	}
+	return " | From the always-ignore list"
+}
+
+// applyIgnoreList ignores the pending files of the components on the
+// always-ignore list, when a scan is opened and whenever the list changes.
+// The decisions are saved and reported like any bulk decision.
+func applyIgnoreList(g *gocui.Gui, app *AppState) error {
+	completed := completedDirs(app)
+	files := app.ScanData.ApplyIgnoreList(app.IgnoreList)
+	if len(files) == 0 {
+		return nil
+	}
+	saved := make([]savedDecision, 0, len(files))
+	for _, filePath := range files {
+		match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+		saved = append(saved, savedDecision{filePath, match, *match.LatestDecision()})
+	}
+	if err := saveToFile(app); err != nil {
+		return showErrorDialog(g, app, "Save Error", fmt.Sprintf("Error saving audit decisions: %v", err))
+	}
+
+	detail := fmt.Sprintf("Ignoring %d files of always-ignored components", len(saved))
+	return afterDecisionsSaved(g, app, "ALWAYS IGNORE", detail, saved, func(g *gocui.Gui) error {
+		announce(app, "Ignored %d files of components on the always-ignore list", len(saved))
+		if err := offerCollapseCompleted(g, app, completed); err != nil {
+			return err
+		}
+		// Don't hide a dialog that is already showing, such as config
+		// warnings or the offer to collapse directories
+		for _, name := range []string{"error_dialog", "collapse_confirm"} {
+			if _, err := g.View(name); err == nil {
+				return nil
+			}
+		}
+		return showErrorDialog(g, app, "Always Ignore", fmt.Sprintf("Ignored %d files of components on your always-ignore list (always_ignore in %s). They are marked as such in the Files pane and the status panel.", len(saved), getConfigFilePath()))
+	})
+}
+
+// selectedComponent is the PURL of the component [Z] acts on: that of the
+// focused file, or of the selected PURL or upstream group
+func selectedComponent(app *AppState) string {
+	if app.ActivePane == "files" || app.ViewMode == "content" {
+		if match := audit.FirstValidMatch(app.ScanData.Files[focusedFile(app)]); match != nil && len(match.Purl) > 0 {
+			return audit.ComponentPURL(match.Purl[0])
+		}
+		return ""
+	}
+	node := app.TreeState.selectedNode
+	if node == nil {
+		return ""
+	}
+	if groupedView(app) {
+		return audit.ComponentPURL(node.Name)
+	}
+	if file := treeFile(app, node); file != "" {
+		if match := audit.FirstValidMatch(app.ScanData.Files[file]); match != nil && len(match.Purl) > 0 {
+			return audit.ComponentPURL(match.Purl[0])
+		}
+	}
+	return ""
+}
+
+// showIgnoreListDialog offers to add the selected component to the
+// always-ignore list in the config, or to take it off again
+func showIgnoreListDialog(g *gocui.Gui, app *AppState) error {
+	purl := selectedComponent(app)
+	if purl == "" {
+		return showErrorDialog(g, app, "Always Ignore", "Select a file with a match, or a component in the PURL or upstream view.")
+	}
+	listed := false
+	for _, entry := range app.IgnoreList {
+		if strings.EqualFold(audit.ComponentPURL(entry), purl) {
+			listed = true
+		}
+	}
+
+	v, err := setDialogView(g, "ignore_list_confirm")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "Always Ignore"
+	v.Frame = true
+	v.Editable = false
+	v.Wrap = true
+	v.Clear()
+	if listed {
+		fmt.Fprintf(v, " %s is on your always-ignore list.\n Take it off? Files already ignored stay ignored.\n\n", displayPURL(app, purl))
+		fmt.Fprintf(v, " Y or ENTER: Take it off  N or ESC: Keep it")
+		announce(app, "%s is on the always-ignore list. Take it off? Y takes it off, N keeps it", displayPURL(app, purl))
+	} else {
+		fmt.Fprintf(v, " Ignore %s in this scan and in every scan opened later?\n Its pending files are ignored; decided files are left alone.\n\n", displayPURL(app, purl))
+		fmt.Fprintf(v, " Y or ENTER: Always ignore  N or ESC: Cancel")
+		announce(app, "Always ignore %s in every scan? Y confirms, N cancels", displayPURL(app, purl))
+	}
+	if _, err := g.SetCurrentView("ignore_list_confirm"); err != nil {
+		return err
+	}
+
+	confirm := func(g *gocui.Gui, v *gocui.View) error {
+		closeIgnoreListDialog(g, app)
+		list := make([]string, 0, len(app.IgnoreList)+1)
+		for _, entry := range app.IgnoreList {
+			if !strings.EqualFold(audit.ComponentPURL(entry), purl) {
+				list = append(list, entry)
+			}
+		}
+		if !listed {
+			list = append(list, purl)
+		}
+		if err := saveIgnoreList(list); err != nil {
+			return showErrorDialog(g, app, "Config Error", fmt.Sprintf("Failed to save the always-ignore list: %v", err))
+		}
+		app.IgnoreList = list
+		if listed {
+			announce(app, "Took %s off the always-ignore list", displayPURL(app, purl))
+			return nil
+		}
+		return applyIgnoreList(g, app)
+	}
+	cancel := func(g *gocui.Gui, v *gocui.View) error {
+		return closeIgnoreListDialog(g, app)
+	}
+	g.DeleteKeybindings("ignore_list_confirm")
+	for _, key := range []interface{}{'y', 'Y', gocui.KeyEnter} {
+		g.SetKeybinding("ignore_list_confirm", key, gocui.ModNone, confirm)
+	}
+	for _, key := range []interface{}{'n', 'N', gocui.KeyEsc} {
+		g.SetKeybinding("ignore_list_confirm", key, gocui.ModNone, cancel)
+	}
+	return nil
+}
+
+func closeIgnoreListDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("ignore_list_confirm")
+	g.DeleteView("ignore_list_confirm")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"auditcmd/pkg/audit"
+)
+
+// runImport implements "auditcmd import results.json decisions.csv": it
+// previews the decisions an edited CSV export would record and, with
+// --apply, records them. Returns the exit code of the results file, see
+// outcomeExitCode.
+func runImport(out io.Writer, opts *Options) int {
+	app := &AppState{FilePath: opts.CommandArgs[0]}
+	// Saving follows the config like in the interface, e.g. write_status.
+	// No API key is needed, so none is asked for.
+	secretPrompts = false
+	config, _ := loadConfig()
+	applyConfig(app, config)
+	if err := loadScanData(app); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return loadExitCode(err)
+	}
+
+	file, err := os.Open(opts.CommandArgs[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	// Exports use the configured state labels, so imports accept them
+	changes, issues, err := audit.PlanCSVImportLabels(file, &app.ScanData, config.Labels)
+	file.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", opts.CommandArgs[1], err)
+		return exitParseError
+	}
+
+	if len(changes) > 0 {
+		fmt.Fprintf(out, "Changes:\n")
+		for _, change := range changes {
+			fmt.Fprintf(out, "  line %d: %s\n", change.Line, change.Path)
+			if change.NewStatus != change.OldStatus {
+				fmt.Fprintf(out, "    status:  %s -> %s\n", change.OldStatus, change.NewStatus)
+			}
+			if change.NewComment != change.OldComment {
+				fmt.Fprintf(out, "    comment: %q -> %q\n", change.OldComment, change.NewComment)
+			}
+		}
+	}
+	if len(issues) > 0 {
+		fmt.Fprintf(out, "Problems:\n")
+		for _, issue := range issues {
+			fmt.Fprintf(out, "  line %d: %s: %s\n", issue.Line, issue.Path, issue.Message)
+		}
+	}
+	fmt.Fprintf(out, "%d changes, %d problems\n", len(changes), len(issues))
+
+	if !opts.Apply {
+		if len(changes) > 0 {
+			fmt.Fprintf(out, "Nothing was changed. Run again with --apply to record these decisions.\n")
+		}
+		return outcomeExitCode(&app.ScanData)
+	}
+	if len(issues) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: fix or remove the rows with problems before applying\n")
+		return exitError
+	}
+	if len(changes) == 0 {
+		return outcomeExitCode(&app.ScanData)
+	}
+
+	audit.ApplyCSVImport(&app.ScanData, changes, app.Reviewer)
+	if err := saveToFile(app); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	fmt.Fprintf(out, "Recorded %d decisions in %s\n", len(changes), app.FilePath)
+	return outcomeExitCode(&app.ScanData)
+}
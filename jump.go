@@ -0,0 +1,93 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// showJumpInput asks how far down the active list, or the file content, to
+// jump, as a percentage
+func showJumpInput(g *gocui.Gui, app *AppState) error {
+	v, err := setDialogView(g, "jump_input")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "Jump to % (ENTER: Jump, ESC: Cancel)"
+	v.Frame = true
+	v.Editable = true
+	v.Clear()
+
+	if _, err := g.SetCurrentView("jump_input"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("jump_input")
+	g.SetKeybinding("jump_input", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		text := strings.TrimSpace(v.Buffer())
+		closeJumpInput(g, app)
+		percent, ok := parseJumpPercent(text)
+		if !ok {
+			return showErrorDialog(g, app, "Jump", "Enter a percentage from 0 to 100, e.g. 50 or 50%.")
+		}
+		return jumpToPercent(g, app, percent)
+	})
+	g.SetKeybinding("jump_input", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeJumpInput(g, app)
+	})
+	return nil
+}
+
+// parseJumpPercent reads a percentage such as "50", "50%" or ":50%"
+func parseJumpPercent(text string) (int, bool) {
+	text = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(text, ":"), "%"))
+	percent, err := strconv.Atoi(text)
+	if err != nil || percent < 0 || percent > 100 {
+		return 0, false
+	}
+	return percent, true
+}
+
+// jumpToPercent moves percent of the way down the file content when a file
+// is open, otherwise down the active list
+func jumpToPercent(g *gocui.Gui, app *AppState, percent int) error {
+	switch {
+	case app.ViewMode == "content":
+		v, err := g.View("files")
+		if err != nil {
+			return err
+		}
+		_, height := v.Size()
+		lines := v.LinesHeight()
+		// The last page is as far as the content scrolls with End
+		oy := min(percent*max(lines-1, 0)/100, max(lines-height, 0))
+		ox, _ := v.Origin()
+		return v.SetOrigin(ox, oy)
+	case app.ActivePane == "tree":
+		if len(app.TreeState.displayLines) == 0 {
+			return nil
+		}
+		app.TreeList.JumpToPercent(percent)
+		return showTreeSelection(g, app)
+	case app.ViewMode == "list":
+		app.FileList.JumpToPercent(percent)
+		return showFileSelection(g, app)
+	}
+	return nil
+}
+
+func closeJumpInput(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("jump_input")
+	g.DeleteView("jump_input")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
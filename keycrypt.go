@@ -0,0 +1,279 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Values of api_key_encryption: what the key protecting the API key in the
+// config file is derived from
+const (
+	encryptPassphrase = "passphrase" // Asked for, or read from AUDITCMD_PASSPHRASE
+	encryptMachine    = "machine"    // This machine's ID and the user name
+)
+
+var encryptionModes = []string{encryptPassphrase, encryptMachine}
+
+// encryptionDescription names the secret of an api_key_encryption mode
+func encryptionDescription(mode string) string {
+	if mode == encryptMachine {
+		return "this machine's key"
+	}
+	return "a passphrase"
+}
+
+// passphraseEnv holds the passphrase in non-interactive runs
+const passphraseEnv = "AUDITCMD_PASSPHRASE"
+
+// knownSecrets holds the secret of each mode once it encrypted or decrypted
+// a key, so the passphrase is asked once for the main key and the host keys
+var knownSecrets = make(map[string]string)
+
+// secretPrompts is turned off once the interface owns the terminal: keys
+// decrypted on a config reload can only use a known secret
+var secretPrompts = true
+
+// keyIterations is the PBKDF2-SHA256 work factor, as recommended by OWASP
+const keyIterations = 600000
+
+// encryptAPIKey encrypts apiKey with AES-256-GCM under a key derived from
+// the secret of mode. The result, stored as api_key_encrypted, is
+// "v1:<mode>:<salt>:<nonce>:<ciphertext>" in base64.
+func encryptAPIKey(apiKey, mode string) (string, error) {
+	secret, err := encryptionSecret(mode, true)
+	if err != nil {
+		return "", err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	gcm, err := apiKeyCipher(secret, salt)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	knownSecrets[mode] = secret
+	sealed := gcm.Seal(nil, nonce, []byte(apiKey), []byte(mode))
+	encode := base64.RawStdEncoding.EncodeToString
+	return strings.Join([]string{"v1", mode, encode(salt), encode(nonce), encode(sealed)}, ":"), nil
+}
+
+// decryptAPIKey reverses encryptAPIKey, asking for the passphrase when
+// needed
+func decryptAPIKey(value string) (string, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 5 || parts[0] != "v1" {
+		return "", fmt.Errorf("api_key_encrypted isn't in a known format")
+	}
+	mode := parts[1]
+	decoded := make([][]byte, 0, 3)
+	for _, part := range parts[2:] {
+		data, err := base64.RawStdEncoding.DecodeString(part)
+		if err != nil {
+			return "", fmt.Errorf("api_key_encrypted is corrupt: %v", err)
+		}
+		decoded = append(decoded, data)
+	}
+	salt, nonce, sealed := decoded[0], decoded[1], decoded[2]
+
+	secret, err := encryptionSecret(mode, false)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := apiKeyCipher(secret, salt)
+	if err != nil {
+		return "", err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return "", fmt.Errorf("api_key_encrypted is corrupt: bad nonce")
+	}
+	apiKey, err := gcm.Open(nil, nonce, sealed, []byte(mode))
+	if err != nil {
+		if mode == encryptMachine {
+			return "", fmt.Errorf("the API key was encrypted on another machine or for another user")
+		}
+		return "", fmt.Errorf("wrong passphrase")
+	}
+	knownSecrets[mode] = secret
+	return string(apiKey), nil
+}
+
+// encryptedMode returns the api_key_encryption an api_key_encrypted value
+// was written with, without decrypting it
+func encryptedMode(value string) string {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+func apiKeyCipher(secret string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, secret, salt, keyIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptionSecret returns what the key of mode is derived from. New
+// passphrases are asked for twice.
+func encryptionSecret(mode string, confirm bool) (string, error) {
+	if secret, ok := knownSecrets[mode]; ok {
+		return secret, nil
+	}
+	switch mode {
+	case encryptPassphrase:
+		if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+			return passphrase, nil
+		}
+		if !secretPrompts {
+			return "", fmt.Errorf("the passphrase is needed; set %s or restart auditcmd", passphraseEnv)
+		}
+		passphrase, err := readPassphrase("Passphrase for the SCANOSS API key: ")
+		if err != nil || !confirm {
+			return passphrase, err
+		}
+		again, err := readPassphrase("Repeat the passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", fmt.Errorf("the passphrases don't match")
+		}
+		return passphrase, nil
+	case encryptMachine:
+		id, err := machineID()
+		if err != nil {
+			return "", err
+		}
+		name := ""
+		if current, err := user.Current(); err == nil {
+			name = current.Username
+		}
+		return "auditcmd:" + id + ":" + name, nil
+	}
+	return "", fmt.Errorf("unknown api_key_encryption %q (use %s)", mode, strings.Join(encryptionModes, " or "))
+}
+
+// readPassphrase asks for a passphrase without echoing it, like
+// promptForAPIKey, falling back to visible input without a terminal
+func readPassphrase(prompt string) (string, error) {
+	fmt.Print(prompt)
+	input, err := term.ReadPassword(int(os.Stdin.Fd()))
+	if err == nil {
+		fmt.Println()
+	} else {
+		line, readErr := bufio.NewReader(os.Stdin).ReadString('\n')
+		if readErr != nil && line == "" {
+			return "", fmt.Errorf("failed to read passphrase: %v", readErr)
+		}
+		input = []byte(line)
+	}
+	passphrase := strings.TrimRight(string(input), "\r\n")
+	if passphrase == "" {
+		return "", fmt.Errorf("empty passphrase")
+	}
+	return passphrase, nil
+}
+
+var (
+	macPlatformUUID  = regexp.MustCompile(`"IOPlatformUUID"\s*=\s*"([^"]+)"`)
+	windowsMachineID = regexp.MustCompile(`MachineGuid\s+REG_SZ\s+(\S+)`)
+)
+
+// machineID returns the ID the operating system gives this installation:
+// the systemd machine ID, the macOS platform UUID or the Windows
+// MachineGuid
+func machineID() (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		out, err := exec.Command("ioreg", "-rd1", "-c", "IOPlatformExpertDevice").Output()
+		if m := macPlatformUUID.FindSubmatch(out); err == nil && m != nil {
+			return string(m[1]), nil
+		}
+	case "windows":
+		out, err := exec.Command("reg", "query", `HKLM\SOFTWARE\Microsoft\Cryptography`, "/v", "MachineGuid").Output()
+		if m := windowsMachineID.FindSubmatch(out); err == nil && m != nil {
+			return string(m[1]), nil
+		}
+	default:
+		for _, path := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id"} {
+			if data, err := os.ReadFile(path); err == nil && strings.TrimSpace(string(data)) != "" {
+				return strings.TrimSpace(string(data)), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no machine ID is available on this system; use api_key_encryption = %s", encryptPassphrase)
+}
+
+// runEncryptAPIKey implements --encrypt-api-key: the API key stored in
+// plain text is replaced by api_key_encrypted
+func runEncryptAPIKey() int {
+	config, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if config.APIKey == "" && len(config.HostKeys) == 0 {
+		if config.APIKeyEncrypted != "" || len(config.HostKeysEncrypted) > 0 {
+			fmt.Println("The API keys are already encrypted.")
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: no API key is stored in %s\n", getConfigFilePath())
+		return 1
+	}
+	if config.APIKeyEncryption == "" {
+		config.APIKeyEncryption = encryptPassphrase
+	}
+	if config.APIKey != "" {
+		encrypted, err := encryptAPIKey(config.APIKey, config.APIKeyEncryption)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		config.APIKey, config.APIKeyEncrypted = "", encrypted
+	}
+	// Keys per host are encrypted the same way, with the same secret
+	hosts, err := encryptHostKeys(config, config.APIKeyEncryption)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if err := saveConfig(config); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if config.APIKeyEncrypted != "" && config.APIKey == "" {
+		fmt.Printf("API key encrypted with %s in %s\n", encryptionDescription(config.APIKeyEncryption), getConfigFilePath())
+	}
+	if hosts > 0 {
+		fmt.Printf("API keys of %d hosts encrypted with %s in %s\n", hosts, encryptionDescription(config.APIKeyEncryption), getConfigFilePath())
+	}
+	return 0
+}
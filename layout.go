@@ -0,0 +1,272 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Smallest terminal the main layout can be drawn in without overlapping views
+const (
+	minTerminalWidth  = 60
+	minTerminalHeight = 12
+	minPaneColumns    = 15
+)
+
+// dialogRect computes the coordinates of a dialog for the given terminal size
+type dialogRect func(maxX, maxY int) (int, int, int, int)
+
+// dialogLayouts holds the geometry of every modal view so they can be
+// recreated at the right position whenever the terminal is resized
+var dialogLayouts = map[string]dialogRect{
+	"audit_dialog": func(maxX, maxY int) (int, int, int, int) {
+		if commentExpanded() {
+			return maxX / 8, maxY / 6, 7 * maxX / 8, 5 * maxY / 6
+		}
+		return maxX / 4, maxY / 3, 3 * maxX / 4, maxY/3 + 5
+	},
+	"audit_input": func(maxX, maxY int) (int, int, int, int) {
+		if commentExpanded() {
+			return maxX/8 + 1, maxY/6 + 1, 7*maxX/8 - 1, 5*maxY/6 - 1
+		}
+		return maxX/4 + 1, maxY/3 + 1, 3*maxX/4 - 1, maxY/3 + 3
+	},
+	"audit_error": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 4, maxY / 3, 3 * maxX / 4, maxY/3 + 4
+	},
+	"audit_checklist": checklistRect,
+	"audit_reasons": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 4, maxY/3 + 6, 3 * maxX / 4, maxY/3 + 16
+	},
+	"assessment_input": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 4, maxY/3 + 5, 3 * maxX / 4, 2 * maxY / 3
+	},
+	"save_error": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 4, maxY/2 - 2, 3 * maxX / 4, maxY/2 + 2
+	},
+	"export_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 4, maxY / 3, 3 * maxX / 4, maxY/3 + 7
+	},
+	"export_error": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 4, maxY / 3, 3 * maxX / 4, maxY/3 + 4
+	},
+	"ticket_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 4, maxY / 3, 3 * maxX / 4, maxY/3 + 5
+	},
+	"blame_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 8, maxY / 6, 7 * maxX / 8, 5 * maxY / 6
+	},
+	"checkpoint_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 6, maxY / 4, 5 * maxX / 6, 3 * maxY / 4
+	},
+	"checkpoint_input": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 4, maxY/2 - 1, 3 * maxX / 4, maxY/2 + 1
+	},
+	"rescan_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 6, maxY / 3, 5 * maxX / 6, maxY/3 + 5
+	},
+	"stats_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 6, maxY / 6, 5 * maxX / 6, 5 * maxY / 6
+	},
+	"license_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 8, maxY / 8, 7 * maxX / 8, 7 * maxY / 8
+	},
+	"candidate_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 8, maxY / 4, 7 * maxX / 8, 3 * maxY / 4
+	},
+	"fp_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 8, maxY / 6, 7 * maxX / 8, 5 * maxY / 6
+	},
+	"assessment_filter": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 4, maxY/2 - 1, 3 * maxX / 4, maxY/2 + 1
+	},
+	"jump_input": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 3, maxY/2 - 1, 2 * maxX / 3, maxY/2 + 1
+	},
+	"quick_confirm": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 4, maxY / 3, 3 * maxX / 4, maxY/3 + 4
+	},
+	"collapse_confirm": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 6, maxY / 3, 5 * maxX / 6, maxY/3 + 5
+	},
+	"ignore_list_confirm": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 6, maxY / 3, 5 * maxX / 6, maxY/3 + 5
+	},
+	"progress_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 6, maxY / 3, 5 * maxX / 6, maxY/3 + 5
+	},
+	"tasks_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 8, maxY / 6, 7 * maxX / 8, 5 * maxY / 6
+	},
+	"error_dialog": func(maxX, maxY int) (int, int, int, int) {
+		return maxX / 4, maxY / 3, 3 * maxX / 4, maxY/3 + 5
+	},
+}
+
+// setDialogView creates or repositions a dialog using its registered geometry
+func setDialogView(g *gocui.Gui, name string) (*gocui.View, error) {
+	maxX, maxY := g.Size()
+	rect, ok := dialogLayouts[name]
+	if !ok {
+		return nil, fmt.Errorf("no layout registered for dialog %q", name)
+	}
+	x0, y0, x1, y1 := rect(maxX, maxY)
+	// Keep the dialog valid on tiny terminals; it stays hidden behind the
+	// too-small notice until the window grows again
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+	return g.SetView(name, x0, y0, x1, y1, 0)
+}
+
+// showErrorDialog shows a message that stays until the user presses ESC
+func showErrorDialog(g *gocui.Gui, app *AppState, title, message string) error {
+	v, err := setDialogView(g, "error_dialog")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = title
+	v.Frame = true
+	v.Wrap = true
+	v.Clear()
+	fmt.Fprintf(v, "%s\nPress ESC to close.", message)
+
+	g.DeleteKeybindings("error_dialog")
+	g.SetKeybinding("error_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		g.DeleteKeybindings("error_dialog")
+		g.DeleteView("error_dialog")
+		if app.ActivePane == "tree" {
+			g.SetCurrentView("tree")
+		} else {
+			g.SetCurrentView("files")
+		}
+		return nil
+	})
+
+	if _, err := g.SetCurrentView("error_dialog"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// relayoutDialogs moves any open dialogs to match the current terminal size
+func relayoutDialogs(g *gocui.Gui) error {
+	for name := range dialogLayouts {
+		if _, err := g.View(name); err != nil {
+			continue
+		}
+		if _, err := setDialogView(g, name); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	return nil
+}
+
+// isTerminalTooSmall reports whether the main layout can't be drawn
+func isTerminalTooSmall(maxX, maxY int) bool {
+	return maxX < minTerminalWidth || maxY < minTerminalHeight
+}
+
+// clampSplit keeps the pane divider far enough from both edges that neither
+// pane ends up with a zero or negative width
+func clampSplit(maxX int, paneWidth float64) int {
+	splitX := int(float64(maxX) * paneWidth)
+	if splitX < minPaneColumns {
+		splitX = minPaneColumns
+	}
+	if splitX > maxX-minPaneColumns {
+		splitX = maxX - minPaneColumns
+	}
+	return splitX
+}
+
+// showTooSmallScreen covers the whole terminal with a notice until it is enlarged
+func showTooSmallScreen(g *gocui.Gui, maxX, maxY int) error {
+	if maxX < 2 || maxY < 2 {
+		return nil
+	}
+
+	v, err := g.SetView("too_small", 0, 0, maxX-1, maxY-1, 0)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "AuditCmd"
+	v.Wrap = true
+	v.Clear()
+	fmt.Fprintf(v, "Terminal too small\n")
+	fmt.Fprintf(v, "Current: %dx%d\n", maxX, maxY)
+	fmt.Fprintf(v, "Minimum: %dx%d\n", minTerminalWidth, minTerminalHeight)
+	fmt.Fprintf(v, "Resize the window to continue.")
+
+	if _, err := g.SetViewOnTop("too_small"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// hideTooSmallScreen removes the notice once the terminal is big enough again
+func hideTooSmallScreen(g *gocui.Gui) error {
+	if err := g.DeleteView("too_small"); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	return nil
+}
+
+// layoutPreset is a named arrangement of the main panes
+type layoutPreset struct {
+	name      string
+	label     string
+	paneWidth float64 // Share of the width given to the tree, 0 keeps the user's width
+	hideTree  bool    // The file content uses the full width
+	hideBars  bool    // The status pane and help bar are hidden
+}
+
+// layoutPresets are cycled with [W]; "custom" is the width set with the arrow keys
+var layoutPresets = []layoutPreset{
+	{name: "custom", label: "custom"},
+	{name: "wide-tree", label: "wide tree", paneWidth: 0.6},
+	{name: "wide-files", label: "wide files", paneWidth: 0.2},
+	{name: "content", label: "content focused", paneWidth: 0.2, hideTree: true},
+	{name: "zen", label: "zen", hideBars: true},
+}
+
+// findLayoutPreset returns the preset with the given name, or "custom"
+func findLayoutPreset(name string) layoutPreset {
+	for _, preset := range layoutPresets {
+		if preset.name == name {
+			return preset
+		}
+	}
+	return layoutPresets[0]
+}
+
+// presetPaneWidth returns the tree pane share used by the active preset
+func presetPaneWidth(app *AppState) float64 {
+	if preset := findLayoutPreset(app.LayoutPreset); preset.paneWidth > 0 {
+		return preset.paneWidth
+	}
+	return app.PaneWidth
+}
+
+// cycleLayoutPreset switches to the next layout preset and saves it
+func cycleLayoutPreset(g *gocui.Gui, app *AppState) error {
+	next := 0
+	for i, preset := range layoutPresets {
+		if preset.name == app.LayoutPreset {
+			next = (i + 1) % len(layoutPresets)
+			break
+		}
+	}
+	preset := layoutPresets[next]
+	app.LayoutPreset = preset.name
+	saveLayoutPreset(app.LayoutPreset)
+
+	announce(app, "Layout %s", preset.label)
+	return nil
+}
@@ -0,0 +1,379 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// licenseTextURL is where the plain text of an SPDX license is fetched from
+const licenseTextURL = "https://raw.githubusercontent.com/spdx/license-list-data/main/text/%s.txt"
+
+// maxLicenseText bounds the size of a fetched license text
+const maxLicenseText = 1 << 20
+
+// embeddedLicenses holds the texts of common short licenses, shown without
+// fetching them, also offline
+//
+//go:embed licenses/*.txt
+var embeddedLicenses embed.FS
+
+var (
+	spdxIDPattern    = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.+-]*$`)
+	htmlDropPattern  = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)>`)
+	htmlBlockPattern = regexp.MustCompile(`(?i)</?(p|div|br|li|ul|ol|pre|tr|h[1-6])\b[^>]*>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankRunPattern  = regexp.MustCompile(`\n(\s*\n){2,}`)
+)
+
+// licenseMatch is the match whose licenses the viewer offers: the
+// highlighted file while browsing, otherwise the viewed or focused file
+func licenseMatch(app *AppState) *FileMatch {
+	if match := highlightedMatch(app); match != nil {
+		return match
+	}
+	if app.ViewMode == "content" && app.CurrentMatch != nil {
+		return app.CurrentMatch
+	}
+	return audit.FirstValidMatch(app.ScanData.Files[focusedFile(app)])
+}
+
+// uniqueLicenses returns the licenses of a match once per name, as a
+// license is often reported by several sources
+func uniqueLicenses(match *FileMatch) []audit.License {
+	seen := make(map[string]bool)
+	licenses := make([]audit.License, 0, len(match.Licenses))
+	for _, license := range match.Licenses {
+		if license.Name == "" || seen[license.Name] {
+			continue
+		}
+		seen[license.Name] = true
+		licenses = append(licenses, license)
+	}
+	return licenses
+}
+
+// licenseTextSources lists where the text of a license can be fetched, the
+// SPDX license list first as its texts are plain
+func licenseTextSources(license audit.License) []string {
+	sources := make([]string, 0, 2)
+	if spdxIDPattern.MatchString(license.Name) {
+		sources = append(sources, fmt.Sprintf(licenseTextURL, license.Name))
+	}
+	if license.URL != "" {
+		sources = append(sources, license.URL)
+	}
+	return sources
+}
+
+// cachedLicenseText returns the text of a license fetched earlier in the
+// session or embedded in auditcmd
+func cachedLicenseText(app *AppState, name string) (string, bool) {
+	if text, ok := app.LicenseTexts[name]; ok {
+		return text, true
+	}
+	if !spdxIDPattern.MatchString(name) {
+		return "", false
+	}
+	data, err := embeddedLicenses.ReadFile("licenses/" + name + ".txt")
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// fetchLicenseText downloads a license text from the first source that
+// answers. HTML pages, such as the SPDX license pages, are reduced to text.
+func fetchLicenseText(ctx context.Context, sources []string) (string, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+	var lastErr error
+	for _, source := range sources {
+		req, err := http.NewRequestWithContext(ctx, "GET", source, nil)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to create request: %v", err)
+			continue
+		}
+		resp, err := network.do(client, req)
+		if err != nil {
+			lastErr = fmt.Errorf("HTTP request failed: %v", err)
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxLicenseText))
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read response body: %v", err)
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			lastErr = fmt.Errorf("%s: HTTP %d", source, resp.StatusCode)
+			continue
+		}
+		text := string(data)
+		if strings.Contains(resp.Header.Get("Content-Type"), "html") {
+			text = htmlToText(text)
+		}
+		if strings.TrimSpace(text) == "" {
+			lastErr = fmt.Errorf("%s: empty license text", source)
+			continue
+		}
+		return text, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no license text source known")
+	}
+	return "", lastErr
+}
+
+// htmlToText strips the markup of a license page, keeping its text
+func htmlToText(page string) string {
+	page = htmlDropPattern.ReplaceAllString(page, "")
+	page = htmlBlockPattern.ReplaceAllString(page, "\n")
+	page = htmlTagPattern.ReplaceAllString(page, "")
+	page = html.UnescapeString(strings.ReplaceAll(page, "\r", ""))
+	return strings.TrimSpace(blankRunPattern.ReplaceAllString(page, "\n\n"))
+}
+
+// wrapText breaks the lines of text at word boundaries to fit width, so the
+// dialog can be scrolled line by line
+func wrapText(text string, width int) []string {
+	if width < 20 {
+		width = 20
+	}
+	lines := make([]string, 0)
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r", ""), "\n") {
+		paragraph = strings.ReplaceAll(strings.TrimRight(paragraph, " \t"), "\t", "    ")
+		indent := paragraph[:len(paragraph)-len(strings.TrimLeft(paragraph, " "))]
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case line == "":
+				line = indent + word
+			case len([]rune(line))+1+len([]rune(word)) > width:
+				lines = append(lines, line)
+				line = indent + word
+			default:
+				line += " " + word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// showLicenseDialog shows the full text of the license of the highlighted
+// or viewed file, or lets the auditor pick one when it has several
+func showLicenseDialog(g *gocui.Gui, app *AppState) error {
+	if isAuditDialogOpen(g) {
+		return nil
+	}
+	match := licenseMatch(app)
+	if match == nil || len(uniqueLicenses(match)) == 0 {
+		return showErrorDialog(g, app, "No License", "The selected file has no license information.")
+	}
+	licenses := uniqueLicenses(match)
+	if len(licenses) == 1 {
+		return showLicenseText(g, app, licenses[0])
+	}
+
+	v, err := setDialogView(g, "license_dialog")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "Licenses"
+	v.Frame = true
+	v.Wrap = false
+	v.Clear()
+	v.SetOrigin(0, 0)
+	fmt.Fprintf(v, "\n Licenses of the match:\n\n")
+	names := make([]string, 0, len(licenses))
+	for i, license := range licenses {
+		if i < 9 {
+			fmt.Fprintf(v, "   %d  %s\n", i+1, license.Name)
+		} else {
+			fmt.Fprintf(v, "      %s\n", license.Name)
+		}
+		names = append(names, license.Name)
+	}
+	fmt.Fprintf(v, "\n 1-9: Show license text  ESC: Close")
+
+	if _, err := g.SetCurrentView("license_dialog"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("license_dialog")
+	g.SetKeybinding("license_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeLicenseDialog(g, app)
+	})
+	for i := 0; i < len(licenses) && i < 9; i++ {
+		license := licenses[i]
+		g.SetKeybinding("license_dialog", rune('1'+i), gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			return showLicenseText(g, app, license)
+		})
+	}
+
+	announce(app, "%d licenses: %s. Press a number to read one", len(licenses), strings.Join(names, ", "))
+	return nil
+}
+
+// showLicenseText shows the text of license, fetching it in the background
+// when it isn't cached or embedded
+func showLicenseText(g *gocui.Gui, app *AppState, license audit.License) error {
+	v, err := setDialogView(g, "license_dialog")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	title := "License: " + license.Name
+	v.Title = title
+	v.Frame = true
+	v.Wrap = false
+
+	if _, err := g.SetCurrentView("license_dialog"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("license_dialog")
+	g.SetKeybinding("license_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeLicenseDialog(g, app)
+	})
+	g.SetKeybinding("license_dialog", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return scrollDialog(v, -1)
+	})
+	g.SetKeybinding("license_dialog", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return scrollDialog(v, 1)
+	})
+	g.SetKeybinding("license_dialog", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, height := v.Size()
+		return scrollDialog(v, -height)
+	})
+	g.SetKeybinding("license_dialog", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, height := v.Size()
+		return scrollDialog(v, height)
+	})
+
+	if text, ok := cachedLicenseText(app, license.Name); ok {
+		writeLicenseText(v, app, license, text)
+		announce(app, "%s license text, %d lines", license.Name, strings.Count(text, "\n")+1)
+		return nil
+	}
+
+	sources := licenseTextSources(license)
+	if app.Offline || len(sources) == 0 {
+		reason := "Working offline: the license text isn't available."
+		if len(sources) == 0 {
+			reason = "No SPDX identifier or URL is known for this license."
+		}
+		writeLicenseMessage(v, app, license, reason)
+		announce(app, "%s license text not available", license.Name)
+		return nil
+	}
+
+	writeLicenseMessage(v, app, license, "Fetching the license text...")
+	announce(app, "Fetching the %s license text", license.Name)
+	task := startBackgroundTask(g, app, license.Name+" license text")
+	go func() {
+		text, err := fetchLicenseText(task.ctx, sources)
+		g.Update(func(g *gocui.Gui) error {
+			finishTask(g, app, task, err)
+			if err == nil {
+				if app.LicenseTexts == nil {
+					app.LicenseTexts = make(map[string]string)
+				}
+				app.LicenseTexts[license.Name] = text
+			}
+			// The dialog may have been closed or moved on to another license
+			v, viewErr := g.View("license_dialog")
+			if viewErr != nil || v.Title != title {
+				return nil
+			}
+			if err != nil {
+				writeLicenseMessage(v, app, license, fmt.Sprintf("Failed to fetch the license text: %v", err))
+				announce(app, "Failed to fetch the %s license text", license.Name)
+				return nil
+			}
+			writeLicenseText(v, app, license, text)
+			announce(app, "%s license text, %d lines", license.Name, strings.Count(text, "\n")+1)
+			return nil
+		})
+	}()
+	return nil
+}
+
+// writeLicenseText fills the dialog with the license text, wrapped to its
+// width, below the license facts reported by the scan
+func writeLicenseText(v *gocui.View, app *AppState, license audit.License, text string) {
+	v.Clear()
+	v.SetOrigin(0, 0)
+	width, _ := v.Size()
+	var out strings.Builder
+	writeLicenseFacts(&out, license)
+	for _, line := range wrapText(text, width-2) {
+		fmt.Fprintf(&out, " %s\n", escapeControls(line))
+	}
+	fmt.Fprintf(&out, "\n Up/Down/PgUp/PgDn: Scroll  ESC: Close")
+
+	output := out.String()
+	if app.Accessible {
+		output = stripANSI(output)
+	}
+	fmt.Fprint(v, output)
+}
+
+// writeLicenseMessage shows a message instead of the text, with the sources
+// to open in a browser
+func writeLicenseMessage(v *gocui.View, app *AppState, license audit.License, message string) {
+	v.Clear()
+	v.SetOrigin(0, 0)
+	var out strings.Builder
+	writeLicenseFacts(&out, license)
+	fmt.Fprintf(&out, " %s\n", message)
+	for _, source := range licenseTextSources(license) {
+		fmt.Fprintf(&out, "   %s\n", source)
+	}
+	fmt.Fprintf(&out, "\n ESC: Close")
+
+	output := out.String()
+	if app.Accessible {
+		output = stripANSI(output)
+	}
+	fmt.Fprint(v, output)
+}
+
+// writeLicenseFacts lists what the scan reports about the license
+func writeLicenseFacts(v io.Writer, license audit.License) {
+	fmt.Fprintf(v, "\n")
+	if license.Copyleft != "" {
+		fmt.Fprintf(v, " \033[1mCopyleft:\033[0m %s\n", license.Copyleft)
+	}
+	if license.PatentHints != "" {
+		fmt.Fprintf(v, " \033[1mPatent hints:\033[0m %s\n", license.PatentHints)
+	}
+	if license.ChecklistURL != "" {
+		fmt.Fprintf(v, " \033[1mObligations checklist:\033[0m %s\n", license.ChecklistURL)
+	}
+	fmt.Fprintf(v, "\n")
+}
+
+func closeLicenseDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("license_dialog")
+	g.DeleteView("license_dialog")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
@@ -4,44 +4,93 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"os"
-	"sort"
+	"path"
 	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
 
 	"github.com/awesome-gocui/gocui"
 )
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <scanoss-result.json>\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "       %s --reset-api-key   (reset stored API key)\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "       %s --api-key-status  (check API key status)\n", os.Args[0])
-		os.Exit(1)
+	opts, err := parseArgs(os.Args[1:])
+	if err == errNoResultsFile {
+		// Resume one of the recent audits, or browse for a results file,
+		// rather than just print the usage
+		if opts.ResultsPath = chooseResultsFile(opts.Accessible || loadAccessible()); opts.ResultsPath != "" {
+			err = nil
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		printUsage()
+		os.Exit(exitError)
+	}
+
+	if opts.Command == "diff" {
+		os.Exit(runDiff(os.Stdout, opts.CommandArgs[0], opts.CommandArgs[1]))
+	}
+	if opts.Command == "obligations" {
+		os.Exit(runObligations(opts))
+	}
+	if opts.Command == "copyrights" {
+		os.Exit(runCopyrights(opts))
+	}
+	if opts.Command == "crypto" {
+		os.Exit(runCrypto(opts))
+	}
+	if opts.Command == "sarif" {
+		os.Exit(runSARIF(opts))
+	}
+	if opts.Command == "badge" {
+		os.Exit(runBadge(opts))
+	}
+	if opts.Command == "import" {
+		os.Exit(runImport(os.Stdout, opts))
+	}
+	if opts.Command == "push" {
+		os.Exit(runPush(opts))
+	}
+	if opts.Command == "completion" {
+		os.Exit(runCompletion(os.Stdout, opts.CommandArgs[0]))
 	}
 
 	// Handle special commands
-	if os.Args[1] == "--reset-api-key" {
+	if opts.ResetAPIKey {
 		configPath := getConfigFilePath()
 		// Load existing config to preserve other settings
 		config, _ := loadConfig()
-		config.APIKey = "" // Clear only the API key
+		// Clear only the API keys, those per host included
+		config.APIKey, config.APIKeyEncrypted = "", ""
+		config.HostKeys, config.HostKeysEncrypted = map[string]string{}, map[string]string{}
 		if err := saveConfig(config); err != nil {
 			fmt.Printf("Error updating config file: %v\n", err)
 			os.Exit(1)
 		}
-		fmt.Printf("API key removed from %s\n", configPath)
+		fmt.Printf("API keys removed from %s\n", configPath)
 		fmt.Println("You will be prompted for a new API key on next run.")
 		os.Exit(0)
 	}
 
-	if os.Args[1] == "--api-key-status" {
+	if opts.EncryptAPIKey {
+		os.Exit(runEncryptAPIKey())
+	}
+
+	if opts.APIKeyStatus {
 		configPath := getConfigFilePath()
-		apiKey, err := loadAPIKey()
-		if err != nil {
+		config, _ := loadConfig()
+		apiKey, err := "", error(nil)
+		if config.APIKey == "" && config.APIKeyEncrypted != "" {
+			fmt.Printf("API Key Status: Configured, encrypted with %s\n", encryptionDescription(encryptedMode(config.APIKeyEncrypted)))
+			fmt.Printf("Config file: %s\n", configPath)
+		} else if apiKey, err = loadAPIKey(); err != nil && (len(config.HostKeys) > 0 || len(config.HostKeysEncrypted) > 0) {
+			fmt.Printf("API Key Status: Configured for some hosts only\n")
+			fmt.Printf("Config file: %s\n", configPath)
+		} else if err != nil {
 			fmt.Printf("API Key Status: Not configured\n")
 			fmt.Printf("Config file: %s (not found)\n", configPath)
 			fmt.Println("Run the application to be prompted for an API key.")
@@ -53,24 +102,99 @@ func main() {
 				apiKey[max(0, len(apiKey)-4):], 
 				len(apiKey))
 		}
+		printHostKeyStatus(config)
 		os.Exit(0)
 	}
 
+	if opts.SourceDir != "" {
+		if info, err := os.Stat(opts.SourceDir); err != nil || !info.IsDir() {
+			fmt.Fprintf(os.Stderr, "Error: source directory %s not found\n", opts.SourceDir)
+			os.Exit(1)
+		}
+	}
+
 	app := &AppState{
 		ActivePane:        "tree",
-		FilePath:          os.Args[1],
+		FilePath:          opts.ResultsPath,
 		CurrentFileList:   make([]string, 0),
 		SelectedFileIndex: 0,
 		PaneWidth:         loadPaneWidth(),        // Load from config
 		ViewFilter:        loadViewFilter(),       // Load from config
+		TreeOrder:         loadTreeOrder(),
+		TreeFiles:         loadTreeFiles(),
+		FileLayout:        loadFileLayout(),
+		LayoutPreset:      loadLayoutPreset(),
+		HideIdentified:    loadHideIdentified(),
 		ViewMode:          "list",
 		TreeViewType:      "directories",
 		FileList:          NewScrollableList([]string{}),
 		TreeList:          NewScrollableList([]string{}),
+		Accessible:        opts.Accessible || loadAccessible(),
+		Redact:            opts.Redact,
+		ReviewMode:        opts.Review,
+		SourceDir:         opts.SourceDir,
+		ContentSide:       "oss",
+	}
+	// Problems that don't stop auditcmd are shown once the UI is up, as
+	// anything printed now is hidden by it
+	var warnings []string
+	// Host keys added in plain text are encrypted before they are used
+	if err := sealHostKeys(); err != nil {
+		warnings = append(warnings, fmt.Sprintf("API keys per host not encrypted: %v", err))
+	}
+	// loadConfig falls back to the defaults when the file can't be read
+	config, _ := loadConfig()
+	applyConfig(app, config)
+	for _, warning := range config.Warnings {
+		warnings = append(warnings, fmt.Sprintf("%s in %s", warning, getConfigFilePath()))
+	}
+	if opts.Filter != "" {
+		app.ViewFilter = opts.Filter
+	}
+	if app.ReviewMode {
+		if app.Reviewer == "" {
+			fmt.Fprintf(os.Stderr, "Error: --review needs a reviewer name; set reviewer in %s\n", getConfigFilePath())
+			os.Exit(1)
+		}
+		// Pending files are never in the review queue
+		if app.ViewFilter == "pending" {
+			app.ViewFilter = "matched"
+		}
 	}
+	app.FileList.Plain = app.Accessible
+	app.TreeList.Plain = app.Accessible
+	app.ExcludeGlobs = append(config.Exclude, opts.Exclude...)
+	app.ExcludePatterns = audit.CompileExcludes(app.ExcludeGlobs)
+	app.RootDir = opts.Dir
 
+	// Wrong files are reported before any of the UI is built
 	if err := loadScanData(app); err != nil {
-		log.Fatalf("Failed to load scan data: %v", err)
+		fmt.Fprintf(os.Stderr, "Error: cannot audit %s: %v\n", app.FilePath, err)
+		os.Exit(loadExitCode(err))
+	}
+	if err := rememberResultsFile(app.FilePath); err != nil {
+		warnings = append(warnings, fmt.Sprintf("recent files %s not updated: %v", userStatePath(), err))
+	}
+	openFile := ""
+	if opts.OpenFile != "" {
+		if openFile, err = resolveScanPath(app, opts.OpenFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
+
+	if opts.WFPPath != "" {
+		wfp, err := audit.LoadWFP(opts.WFPPath)
+		if err != nil {
+			log.Fatalf("Failed to load fingerprints: %v", err)
+		}
+		app.WFP = wfp
+	}
+
+	if opts.Baseline != "" {
+		if err := loadBaseline(app, opts.Baseline); err != nil {
+			log.Fatalf("Failed to load baseline: %v", err)
+		}
 	}
 
 	if err := buildFileTree(app); err != nil {
@@ -81,24 +205,47 @@ func main() {
 	if err := buildPURLRanking(app); err != nil {
 		log.Fatalf("Failed to build PURL ranking: %v", err)
 	}
+	initMilestones(app)
+	if err := loadProjectState(app); err != nil {
+		warnings = append(warnings, fmt.Sprintf("session history %s unreadable, starting a new one: %v", projectStatePath(app.FilePath), err))
+	}
+	if app.GitCommitEvery > 0 {
+		if _, err := gitRepoRoot(app.FilePath); err != nil {
+			warnings = append(warnings, fmt.Sprintf("git_commit_every is set but %v; decisions won't be committed", err))
+			app.GitCommitEvery = 0
+		}
+	}
 
 	// Initialize API key (may be empty if user skipped)
-	apiKey, err := getOrPromptAPIKey()
+	apiKey, warning, err := getOrPromptAPIKey()
 	if err != nil {
 		log.Fatalf("Failed to get API key: %v", err)
 	}
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
 	app.APIKey = apiKey
 	
-	if app.APIKey == "" {
+	if !hasAPIKey(app) {
 		fmt.Println("Running in limited mode without API key.")
 	}
 
+	if opts.View != "" {
+		if warning := startInView(app, opts.View, opts.Filter != ""); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
 	setGlobalApp(app) // Set global reference for pending file counting
 	initTreeState(app)
 	
-
+	// The passphrase can't be asked once the UI owns the terminal
+	secretPrompts = false
 	g, err := gocui.NewGui(gocui.OutputNormal, true)
 	if err != nil {
+		for _, warning := range warnings {
+			fmt.Printf("Warning: %s\n", warning)
+		}
 		fmt.Printf("Error initializing GUI: %v\n", err)
 		fmt.Println("This application requires a proper terminal environment.")
 		fmt.Println("Data loaded successfully:")
@@ -111,6 +258,12 @@ func main() {
 	g.Highlight = false
 	g.Cursor = false
 	g.SelFgColor = gocui.ColorDefault
+
+	// Accessible mode: ASCII frames and a visible cursor on the focused line
+	if app.Accessible {
+		g.ASCII = true
+		g.Cursor = true
+	}
 	
 	// Don't set initial current view to avoid gocui cursor artifacts
 	
@@ -149,22 +302,72 @@ func main() {
 	if err := keybindings(g, app); err != nil {
 		log.Panicln(err)
 	}
+	if err := registerCommandKeybindings(g, app); err != nil {
+		log.Panicln(err)
+	}
 
 	// Force initial file list update after everything is set up
 	updateFileList(g, app)
+	if openFile != "" {
+		openAtFile(g, app, openFile)
+	}
+	if err := showStartupWarnings(g, app, warnings); err != nil {
+		log.Panicln(err)
+	}
+	if err := applyIgnoreList(g, app); err != nil {
+		log.Panicln(err)
+	}
+	startSourceVerification(g, app)
+	watchNetwork(g, app)
+	watchConfig(g, app)
+	startQuotaCheck(g, app)
 
 	if err := g.MainLoop(); err != nil && err != gocui.ErrQuit {
 		log.Panicln(err)
 	}
+
+	exported := true
+	if len(app.ExportOnQuit) > 0 && app.Unexported {
+		// Exports report to the terminal, so leave the UI first
+		g.Close()
+		exported = exportOnQuit(app)
+	}
+
+	// Commit decisions that didn't fill a whole git_commit_every batch
+	if err := commitPendingDecisions(app); err != nil {
+		g.Close()
+		fmt.Fprintf(os.Stderr, "Warning: failed to commit audit decisions: %v\n", err)
+	}
+	if !exported {
+		os.Exit(exitError)
+	}
 }
 
 func loadScanData(app *AppState) error {
-	data, err := ioutil.ReadFile(app.FilePath)
+	scan, err := audit.Load(app.FilePath)
 	if err != nil {
 		return err
 	}
 
-	return json.Unmarshal(data, &app.ScanData.Files)
+	// A scanoss-py dependencies.json is audited one dependency at a time
+	if audit.IsDependencyDocument(scan) {
+		scan, app.Dependencies = audit.ExpandDependencies(scan)
+	} else if path := bomFilePath(app); path != "" {
+		bom, err := audit.LoadBOM(path)
+		if err != nil {
+			return err
+		}
+		scan.AdoptBOM(bom, time.Now().UTC())
+	}
+	app.Duplicates = scan.MergeDuplicatePaths()
+	app.Excluded = scan.Exclude(app.ExcludePatterns)
+	app.OutsideDir = scan.KeepDirectory(app.RootDir)
+	if app.RootDir != "" && len(scan.Files) == 0 {
+		return fmt.Errorf("no scanned files under %s", app.RootDir)
+	}
+
+	app.ScanData = *scan
+	return nil
 }
 
 func buildFileTree(app *AppState) error {
@@ -176,26 +379,11 @@ func buildFileTree(app *AppState) error {
 	}
 
 	// Get file paths from JSON keys and filter by match type
-	paths := make([]string, 0)
-	for filePath, matches := range app.ScanData.Files {
-		// Only include files with valid matches (id = "file" or "snippet")
-		hasValidMatch := false
-		for _, match := range matches {
-			if match.ID == "file" || match.ID == "snippet" {
-				hasValidMatch = true
-				break
-			}
-		}
-		if hasValidMatch {
-			paths = append(paths, filePath)
-		}
-	}
-
-	sort.Strings(paths)
+	paths := audit.MatchedPaths(&app.ScanData)
 
 	// Build directory tree (no files in tree, only directories)
 	for _, path := range paths {
-		parts := strings.Split(path, "/")
+		parts := strings.Split(audit.NormalizePath(path), "/")
 		current := root
 
 		// Only create directory nodes, not file nodes
@@ -229,11 +417,20 @@ func buildFileTree(app *AppState) error {
 		}
 	}
 
+	// With --dir the tree starts at that directory; every file is below it
+	if app.RootDir != "" {
+		dirNode := findTreeNode(root, app.RootDir)
+		root.Children = dirNode.Children
+		for _, child := range root.Children {
+			child.Parent = root
+		}
+	}
+
 	// If no directories were created, add a virtual "All Files" node
 	if len(root.Children) == 0 && len(paths) > 0 {
 		allFilesNode := &TreeNode{
 			Name:     "All Files",
-			Path:     "",
+			Path:     app.RootDir,
 			IsDir:    true,
 			Parent:   root,
 			Children: make([]*TreeNode, 0),
@@ -242,10 +439,12 @@ func buildFileTree(app *AppState) error {
 		root.Children = append(root.Children, allFilesNode)
 	}
 
-	// Check if there are files in the root directory (no "/" in path)
+	// Check if there are files in the root directory (no "/" in path, or
+	// directly in the --dir directory)
 	rootFiles := make([]string, 0)
+	rootPath := path.Join("/", app.RootDir)
 	for filePath := range app.ScanData.Files {
-		if !strings.Contains(filePath, "/") {
+		if path.Dir("/"+audit.NormalizePath(filePath)) == rootPath {
 			rootFiles = append(rootFiles, filePath)
 		}
 	}
@@ -254,7 +453,7 @@ func buildFileTree(app *AppState) error {
 	if len(rootFiles) > 0 {
 		rootDirNode := &TreeNode{
 			Name:     ".",
-			Path:     "",
+			Path:     app.RootDir,
 			IsDir:    true,
 			Parent:   root,
 			Children: make([]*TreeNode, 0),
@@ -269,6 +468,10 @@ func buildFileTree(app *AppState) error {
 	}
 
 	app.FileTree = root
+	if app.TreeState != nil {
+		// The file leaves belonged to the old directory nodes
+		app.TreeState.fileNodes = nil
+	}
 	
 	// Pre-calculate pending counts for all directories
 	calculateDirectoryCounts(root, app)
@@ -286,65 +489,55 @@ func calculateDirectoryCounts(node *TreeNode, app *AppState) {
 	}
 }
 
+// buildPURLRanking groups the files by component, and by upstream file for
+// the upstream view
 func buildPURLRanking(app *AppState) error {
-	purlMap := make(map[string][]string)
-	
-	// Collect first PURL from each file with valid matches
-	for filePath, matches := range app.ScanData.Files {
-		for _, match := range matches {
-			// Only process files with id = "file" or "snippet"
-			if match.ID != "file" && match.ID != "snippet" {
-				continue
-			}
-			
-			// Get first PURL from this match
-			if len(match.Purl) > 0 {
-				firstPURL := match.Purl[0]
-				if _, exists := purlMap[firstPURL]; !exists {
-					purlMap[firstPURL] = make([]string, 0)
-				}
-				purlMap[firstPURL] = append(purlMap[firstPURL], filePath)
-			}
-			break // Only process first valid match per file
-		}
-	}
-	
-	// Convert map to sorted slice
-	app.PURLRanking = make([]PURLRankEntry, 0, len(purlMap))
-	for purl, files := range purlMap {
-		app.PURLRanking = append(app.PURLRanking, PURLRankEntry{
-			PURL:  purl,
-			Files: files,
-			Count: len(files),
-		})
+	app.PURLRanking = audit.BuildPURLRanking(&app.ScanData)
+	if app.PURLOrder == "size" {
+		audit.SortPURLRankingBySize(app.PURLRanking)
+	} else if app.PURLOrder == "pending" {
+		sortPURLRankingByPending(app)
 	}
-	
-	// Sort by count descending, then by PURL name ascending
-	sort.Slice(app.PURLRanking, func(i, j int) bool {
-		if app.PURLRanking[i].Count != app.PURLRanking[j].Count {
-			return app.PURLRanking[i].Count > app.PURLRanking[j].Count
-		}
-		return app.PURLRanking[i].PURL < app.PURLRanking[j].PURL
-	})
-	
+	app.UpstreamGroups = audit.BuildUpstreamGroups(&app.ScanData)
 	return nil
 }
 
 func layoutWithApp(g *gocui.Gui, app *AppState) error {
 	maxX, maxY := g.Size()
-	splitX := int(float64(maxX) * app.PaneWidth)
+	if isTerminalTooSmall(maxX, maxY) {
+		return showTooSmallScreen(g, maxX, maxY)
+	}
+	if err := hideTooSmallScreen(g); err != nil {
+		return err
+	}
+	preset := findLayoutPreset(app.LayoutPreset)
+	splitX := clampSplit(maxX, presetPaneWidth(app))
+	top, bottom := 3, maxY-2
 
-	// Status pane - 2 lines high at top
-	if v, err := g.SetView("status", 0, 0, maxX-1, 3, 0); err != nil {
+	// Zen mode hides the status pane and help bar; the help bar stays in
+	// accessible mode because it carries the announcements
+	showHelp := !preset.hideBars || app.Accessible
+	if preset.hideBars {
+		g.DeleteView("status")
+		top = 0
+	} else if v, err := g.SetView("status", 0, 0, maxX-1, 3, 0); err != nil {
+		// Status pane - 2 lines high at top
 		if err != gocui.ErrUnknownView {
 			return err
 		}
 		v.Title = "Status"
 		v.Wrap = true
 	}
+	if !showHelp {
+		g.DeleteView("help")
+		bottom = maxY - 1
+	}
 
-	// Directory tree pane
-	if v, err := g.SetView("tree", 0, 3, splitX-1, maxY-2, 0); err != nil {
+	// Directory tree pane, hidden while reading a file in the content focused layout
+	if preset.hideTree && app.ViewMode == "content" {
+		g.DeleteView("tree")
+		splitX = 0
+	} else if v, err := g.SetView("tree", 0, top, splitX-1, bottom, 0); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
@@ -353,7 +546,7 @@ func layoutWithApp(g *gocui.Gui, app *AppState) error {
 	}
 
 	// Files pane
-	if v, err := g.SetView("files", splitX, 3, maxX-1, maxY-2, 0); err != nil {
+	if v, err := g.SetView("files", splitX, top, maxX-1, bottom, 0); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
@@ -363,14 +556,17 @@ func layoutWithApp(g *gocui.Gui, app *AppState) error {
 	}
 
 	// Help bar with status on the right
-	if v, err := g.SetView("help", 0, maxY-2, maxX-1, maxY, 0); err != nil {
-		if err != gocui.ErrUnknownView {
-			return err
+	if showHelp {
+		if v, err := g.SetView("help", 0, maxY-2, maxX-1, maxY, 0); err != nil {
+			if err != gocui.ErrUnknownView {
+				return err
+			}
+			v.Frame = false
 		}
-		v.Frame = false
 	}
 
-	return nil
+	// Keep any open dialogs centred after a resize
+	return relayoutDialogs(g)
 }
 
 func keybindings(g *gocui.Gui, app *AppState) error {
@@ -389,6 +585,19 @@ func keybindings(g *gocui.Gui, app *AppState) error {
 	}); err != nil {
 		return err
 	}
+	for _, key := range []gocui.Key{gocui.KeyBackspace, gocui.KeyBackspace2} {
+		key := key
+		if err := g.SetKeybinding("", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			// Global key bindings win over text fields; hand them the key back
+			if v != nil && v.Editable && v.Editor != nil {
+				v.Editor.Edit(v, key, 0, gocui.ModNone)
+				return nil
+			}
+			return breadcrumbUp(g, app)
+		}); err != nil {
+			return err
+		}
+	}
 	if err := g.SetKeybinding("", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
 		return selectItem(g, app)
 	}); err != nil {
@@ -404,38 +613,64 @@ func keybindings(g *gocui.Gui, app *AppState) error {
 		return err
 	}
 	if err := g.SetKeybinding("", 'a', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		// Only allow accept when NOT in directory pane
+		// Only allow accept when NOT in directory pane, except for upstream groups
 		if app.ActivePane == "tree" {
-			return nil
+			if isAuditDialogOpen(g) {
+				return nil
+			}
+			return showTreeDecisionDialog(g, app, audit.DecisionIdentified)
 		}
-		return showAcceptDialog(g, app)
+		return chooseCandidate(g, app, focusedFile(app), func() error {
+			return showAcceptDialog(g, app)
+		})
 	}); err != nil {
 		return err
 	}
 	if err := g.SetKeybinding("", 'A', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		// Only allow quick accept when NOT in directory pane
-		if app.ActivePane == "tree" {
-			return nil
-		}
-		return quickAccept(g, app)
+		// Only allow quick accept when NOT in directory pane, except for upstream groups
+		return guardQuickAction(g, app, audit.DecisionIdentified, false, func() error {
+			if app.ActivePane == "tree" {
+				return quickTreeDecision(g, app, audit.DecisionIdentified)
+			}
+			return chooseCandidate(g, app, focusedFile(app), func() error {
+				return quickAccept(g, app)
+			})
+		})
 	}); err != nil {
 		return err
 	}
 	if err := g.SetKeybinding("", 'i', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		// Only allow ignore when NOT in directory pane
+		// Only allow ignore when NOT in directory pane, except for upstream groups
 		if app.ActivePane == "tree" {
-			return nil
+			if isAuditDialogOpen(g) {
+				return nil
+			}
+			return showTreeDecisionDialog(g, app, audit.DecisionIgnored)
 		}
 		return showIgnoreDialog(g, app)
 	}); err != nil {
 		return err
 	}
 	if err := g.SetKeybinding("", 'I', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		// Only allow quick ignore when NOT in directory pane
+		// Only allow quick ignore when NOT in directory pane, except for upstream groups
+		return guardQuickAction(g, app, audit.DecisionIgnored, false, func() error {
+			if app.ActivePane == "tree" {
+				return quickTreeDecision(g, app, audit.DecisionIgnored)
+			}
+			return quickIgnore(g, app)
+		})
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'z', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		// Defer the file, tree leaf or upstream group, awaiting input
 		if app.ActivePane == "tree" {
-			return nil
+			if isAuditDialogOpen(g) {
+				return nil
+			}
+			return showTreeDecisionDialog(g, app, audit.DecisionDeferred)
 		}
-		return quickIgnore(g, app)
+		return showDeferDialog(g, app)
 	}); err != nil {
 		return err
 	}
@@ -455,6 +690,349 @@ func keybindings(g *gocui.Gui, app *AppState) error {
 	}); err != nil {
 		return err
 	}
+	if err := g.SetKeybinding("", gocui.KeyCtrlT, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showTasksDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gocui.KeyCtrlO, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return openInBrowser(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gocui.KeyCtrlP, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return openComponentPage(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gocui.KeyCtrlL, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return refreshAll(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'b', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showBlameDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'B', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showBlameDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'n', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleDeltaOnly(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'N', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleDeltaOnly(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'c', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showCheckpointDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'C', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showCheckpointDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'h', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleDriftOnly(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'H', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleDriftOnly(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'g', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleHideIdentified(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'G', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleHideIdentified(g, app)
+	}); err != nil {
+		return err
+	}
+	for _, key := range []rune{'y', 'Y'} {
+		if err := g.SetKeybinding("", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			return showLicenseDialog(g, app)
+		}); err != nil {
+			return err
+		}
+	}
+	if err := g.SetKeybinding("", 'r', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showRescanDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'R', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showRescanDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'l', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleContentSide(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'L', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleContentSide(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", '/', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showAssessmentFilterInput(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'x', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleContentHex(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'X', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleContentHex(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 's', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showStatsDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'S', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showStatsDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'o', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleTreeOrder(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'O', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleTreeOrder(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'j', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleTreeFiles(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'J', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleTreeFiles(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'v', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleFileLayout(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'V', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return toggleFileLayout(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", '<', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return scrollFileColumns(g, app, -columnScrollStep)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", '>', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return scrollFileColumns(g, app, columnScrollStep)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'm', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return cycleSimilarityMode(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'M', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return cycleQualityMode(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'f', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showFalsePositiveDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'F', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showFalsePositiveDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'Z', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showIgnoreListDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'u', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) || app.ViewMode == "content" {
+			return nil
+		}
+		return toggleUpstreamView(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'U', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) || app.ViewMode == "content" {
+			return nil
+		}
+		return toggleUpstreamView(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'w', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return cycleLayoutPreset(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'W', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return cycleLayoutPreset(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'k', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showTicketDialog(g, app)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'K', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isAuditDialogOpen(g) {
+			return nil
+		}
+		return showTicketDialog(g, app)
+	}); err != nil {
+		return err
+	}
 	if err := g.SetKeybinding("", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
 		// Don't allow navigation if audit dialog is open
 		if isAuditDialogOpen(g) {
@@ -487,6 +1065,37 @@ func keybindings(g *gocui.Gui, app *AppState) error {
 	}); err != nil {
 		return err
 	}
+	// Home and End jump to the first and last item of the active list, or
+	// the top and bottom of the file content
+	for key, direction := range map[gocui.Key]string{gocui.KeyHome: "first", gocui.KeyEnd: "last"} {
+		direction := direction
+		if err := g.SetKeybinding("", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			if isAuditDialogOpen(g) {
+				return nil
+			}
+			if app.ViewMode == "content" {
+				return scrollFileContent(g, app, direction, false)
+			} else if app.ActivePane == "tree" {
+				return navigateTree(g, app, direction)
+			} else if app.ViewMode == "list" {
+				return navigateFileList(g, app, direction)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	// % and : ask how far down the active list or the content to jump
+	for _, key := range []rune{'%', ':'} {
+		if err := g.SetKeybinding("", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			if isAuditDialogOpen(g) {
+				return nil
+			}
+			return showJumpInput(g, app)
+		}); err != nil {
+			return err
+		}
+	}
 	if err := g.SetKeybinding("", gocui.KeyArrowRight, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
 		return resizePane(g, app, 0.05)
 	}); err != nil {
@@ -518,6 +1127,39 @@ func keybindings(g *gocui.Gui, app *AppState) error {
 	}); err != nil {
 		return err
 	}
+
+	// Previous, next and next pending file while viewing content
+	if err := g.SetKeybinding("", '[', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return stepContentFile(g, app, -1)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", ']', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return stepContentFile(g, app, 1)
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", '}', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return nextPendingContent(g, app)
+	}); err != nil {
+		return err
+	}
+
+	// Accept or ignore, then open the next file awaiting a decision
+	if err := g.SetKeybinding("", '+', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return guardQuickAction(g, app, audit.DecisionIdentified, true, func() error {
+			return decideAndAdvance(g, app, audit.DecisionIdentified)
+		})
+	}); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", '-', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return guardQuickAction(g, app, audit.DecisionIgnored, true, func() error {
+			return decideAndAdvance(g, app, audit.DecisionIgnored)
+		})
+	}); err != nil {
+		return err
+	}
 	
 	// Shift+Up for page up scrolling
 	if err := g.SetKeybinding("", gocui.KeyArrowUp, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
@@ -598,6 +1240,9 @@ func keybindings(g *gocui.Gui, app *AppState) error {
 		if isAuditDialogOpen(g) || app.ViewMode == "content" {
 			return nil
 		}
+		if app.TreeViewType == "upstream" {
+			return toggleUpstreamView(g, app)
+		}
 		return toggleTreeViewType(g, app)
 	}); err != nil {
 		return err
@@ -606,6 +1251,9 @@ func keybindings(g *gocui.Gui, app *AppState) error {
 		if isAuditDialogOpen(g) || app.ViewMode == "content" {
 			return nil
 		}
+		if app.TreeViewType == "upstream" {
+			return toggleUpstreamView(g, app)
+		}
 		return toggleTreeViewType(g, app)
 	}); err != nil {
 		return err
@@ -637,6 +1285,11 @@ func switchPane(g *gocui.Gui, app *AppState) error {
 	
 	// Force immediate update of pane titles
 	updatePaneTitles(g, app)
+	if app.ActivePane == "files" {
+		announceFileSelection(app)
+	} else {
+		announceTreeSelection(app)
+	}
 	return nil
 }
 
@@ -650,7 +1303,8 @@ func selectItem(g *gocui.Gui, app *AppState) error {
 				app.ViewMode = "content"
 				selectedFile := app.CurrentFileList[app.SelectedFileIndex]
 				app.CurrentFile = selectedFile
-				return displayFileContent(g, app, selectedFile)
+				announce(app, "Viewing %s, %s. Escape returns to the file list", displayPath(app, selectedFile), fileAuditState(app, selectedFile))
+				return openFileContent(g, app, selectedFile)
 			}
 		}
 		return nil
@@ -658,6 +1312,12 @@ func selectItem(g *gocui.Gui, app *AppState) error {
 }
 
 func resizePane(g *gocui.Gui, app *AppState, delta float64) error {
+	// Resizing a preset layout continues from the preset's width
+	if preset := findLayoutPreset(app.LayoutPreset); preset.paneWidth > 0 {
+		app.PaneWidth = preset.paneWidth
+		app.LayoutPreset = "custom"
+		saveLayoutPreset(app.LayoutPreset)
+	}
 	app.PaneWidth += delta
 	if app.PaneWidth < 0.2 {
 		app.PaneWidth = 0.2
@@ -676,24 +1336,28 @@ func resizePane(g *gocui.Gui, app *AppState, delta float64) error {
 }
 
 func cycleViewFilter(g *gocui.Gui, app *AppState) error {
-	if app.TreeViewType == "purls" {
-		// In PURL mode, only cycle between matched and pending
+	if groupedView(app) {
+		// In PURL and upstream modes, only cycle between matched, pending and deferred
 		switch app.ViewFilter {
 		case "matched":
 			app.ViewFilter = "pending"
 		case "pending":
+			app.ViewFilter = "deferred"
+		case "deferred":
 			app.ViewFilter = "matched"
 		default:
 			app.ViewFilter = "matched" // Default to matched in PURL mode
 		}
 	} else {
-		// In directory mode, cycle through: all -> matched -> pending -> all
+		// In directory mode, cycle through: all -> matched -> pending -> deferred -> all
 		switch app.ViewFilter {
 		case "all":
 			app.ViewFilter = "matched"
 		case "matched":
 			app.ViewFilter = "pending"
 		case "pending":
+			app.ViewFilter = "deferred"
+		case "deferred":
 			app.ViewFilter = "all"
 		default:
 			app.ViewFilter = "all" // Default case
@@ -728,11 +1392,12 @@ func cycleViewFilter(g *gocui.Gui, app *AppState) error {
 	
 	displayTree(g, app)
 	updateFileList(g, app)
+	announce(app, "View filter %s, %d items", app.ViewFilter, len(app.TreeList.Items))
 	return nil
 }
 
 func toggleTreeViewType(g *gocui.Gui, app *AppState) error {
-	if app.TreeViewType == "directories" {
+	if app.TreeViewType != "purls" {
 		app.TreeViewType = "purls"
 		// When switching to PURL mode, if currently in "all" mode, switch to "matched"
 		if app.ViewFilter == "all" {
@@ -740,12 +1405,7 @@ func toggleTreeViewType(g *gocui.Gui, app *AppState) error {
 		}
 		// Select first PURL if available
 		if len(app.PURLRanking) > 0 {
-			app.TreeState.selectedNode = &TreeNode{
-				Name:  app.PURLRanking[0].PURL,
-				Path:  "purl_0",
-				IsDir: false,
-				Files: app.PURLRanking[0].Files,
-			}
+			app.TreeState.selectedNode = purlNode(app.PURLRanking[0])
 		}
 	} else {
 		app.TreeViewType = "directories"
@@ -760,6 +1420,7 @@ func toggleTreeViewType(g *gocui.Gui, app *AppState) error {
 	updateTreeDisplay(app)
 	displayTree(g, app)
 	updateFileList(g, app)
+	announce(app, "Showing %s, %d items", app.TreeViewType, len(app.TreeList.Items))
 	return nil
 }
 
@@ -778,10 +1439,25 @@ func max(a, b int) int {
 }
 
 func handleEscape(g *gocui.Gui, app *AppState) error {
+	// A running export or re-scan is cancelled before anything else
+	if cancelTask(app) {
+		return nil
+	}
 	if app.ViewMode == "content" {
+		if app.StopPrefetch != nil {
+			app.StopPrefetch()
+		}
+		saveContentScroll(g, app)
 		app.ViewMode = "list"
 		app.CurrentMatch = nil // Clear current match to show general status
+		if app.ContentFromTree {
+			returnToTree(app)
+			updateFileList(g, app)
+			announceTreeSelection(app)
+			return nil
+		}
 		updateFileList(g, app)
+		announceFileSelection(app)
 		return nil
 	}
 	return nil
@@ -794,7 +1470,24 @@ func isAuditDialogOpen(g *gocui.Gui) bool {
 	_, err4 := g.View("audit_error")
 	_, err5 := g.View("export_dialog")
 	_, err6 := g.View("export_error")
-	return err1 == nil || err2 == nil || err3 == nil || err4 == nil || err5 == nil || err6 == nil
+	_, err7 := g.View("error_dialog")
+	_, err8 := g.View("ticket_dialog")
+	_, err9 := g.View("blame_dialog")
+	_, err10 := g.View("checkpoint_dialog")
+	_, err11 := g.View("checkpoint_input")
+	_, err12 := g.View("rescan_dialog")
+	_, err13 := g.View("stats_dialog")
+	_, err14 := g.View("fp_dialog")
+	_, err15 := g.View("assessment_filter")
+	_, err16 := g.View("quick_confirm")
+	_, err17 := g.View("collapse_confirm")
+	_, err18 := g.View("license_dialog")
+	_, err19 := g.View("candidate_dialog")
+	_, err20 := g.View("progress_dialog")
+	_, err21 := g.View("tasks_dialog")
+	_, err22 := g.View("ignore_list_confirm")
+	_, err23 := g.View("jump_input")
+	return err1 == nil || err2 == nil || err3 == nil || err4 == nil || err5 == nil || err6 == nil || err7 == nil || err8 == nil || err9 == nil || err10 == nil || err11 == nil || err12 == nil || err13 == nil || err14 == nil || err15 == nil || err16 == nil || err17 == nil || err18 == nil || err19 == nil || err20 == nil || err21 == nil || err22 == nil || err23 == nil
 }
 
 func updatePaneTitles(g *gocui.Gui, app *AppState) error {
@@ -807,11 +1500,19 @@ func updatePaneTitles(g *gocui.Gui, app *AppState) error {
 			} else {
 				title = "PURLs"
 			}
-		} else {
+		} else if app.TreeViewType == "upstream" {
 			if app.ActivePane == "tree" {
-				title = "[ Directories ]"
+				title = "[ Upstream Files ]"
 			} else {
-				title = "Directories"
+				title = "Upstream Files"
+			}
+		} else {
+			title = "Directories"
+			if app.RootDir != "" {
+				title += ": " + displayPath(app, app.RootDir)
+			}
+			if app.ActivePane == "tree" {
+				title = "[ " + title + " ]"
 			}
 		}
 		
@@ -825,18 +1526,25 @@ func updatePaneTitles(g *gocui.Gui, app *AppState) error {
 	
 	// Update files pane title
 	if v, err := g.View("files"); err == nil {
+		width, _ := v.Size()
 		if app.ActivePane == "files" {
 			if app.ViewMode == "content" {
-				v.Title = fmt.Sprintf("[ %s ]", app.CurrentFile)
+				v.Title = fmt.Sprintf("[ %s%s%s ]", displayPath(app, app.CurrentFile), contentSideLabel(app), contentModeLabel(app))
 			} else {
-				v.Title = "[ Files ]"
+				v.Title = "[ " + filesBreadcrumb(app, width-6) + " ]"
+				if app.FileLayout == "columns" {
+					v.Title = "[ " + fileColumnsTitle(app) + " ]"
+				}
 			}
 			v.TitleColor = gocui.ColorYellow
 		} else {
 			if app.ViewMode == "content" {
-				v.Title = app.CurrentFile
+				v.Title = displayPath(app, app.CurrentFile) + contentSideLabel(app) + contentModeLabel(app)
 			} else {
-				v.Title = "Files"
+				v.Title = filesBreadcrumb(app, width-2)
+				if app.FileLayout == "columns" {
+					v.Title = fileColumnsTitle(app)
+				}
 			}
 			v.TitleColor = gocui.ColorDefault
 		}
@@ -856,15 +1564,27 @@ func updateHelpBar(g *gocui.Gui, app *AppState) error {
 	// Get progress information
 	auditedFiles, totalFiles, percentage := calculateProgress(app)
 	statusText := fmt.Sprintf("%d%% done (%d/%d)", percentage, auditedFiles, totalFiles)
+	if activity := networkStatus(); activity != "" {
+		statusText = activity + " | " + statusText
+	}
+	if tasks := taskStatus(app); tasks != "" {
+		statusText = tasks + " | " + statusText
+	}
 	
 	// Help text
 	var toggleViewText string
-	if app.TreeViewType == "purls" {
+	if groupedView(app) {
 		toggleViewText = "[D]irectories"
 	} else {
 		toggleViewText = "[P]URLs"
 	}
-	helpText := fmt.Sprintf("Tab: Switch panes | [T]oggle view | [a]ccept [A]quick | [i]gnore [I]quick | [E]xport CSV | %s | [Q]uit", toggleViewText)
+	helpText := fmt.Sprintf("Tab: Switch panes | [T]oggle view | [a]ccept [A]quick | [i]gnore [I]quick | [z] defer | [E]xport CSV | %s | [Q]uit", toggleViewText)
+	if app.ViewMode == "content" {
+		helpText = "Esc: Back to list | [ ]: Previous/next file | }: Next pending | [a]ccept | [i]gnore | [z] defer | +/-: Accept/ignore and next | [Q]uit"
+	}
+	if app.Accessible && app.Announcement != "" {
+		helpText = app.Announcement
+	}
 	
 	// Calculate padding to right-justify status
 	maxX, _ := v.Size()
@@ -872,7 +1592,7 @@ func updateHelpBar(g *gocui.Gui, app *AppState) error {
 		maxX = 80 // Fallback width
 	}
 	
-	totalContentLen := len(helpText) + len(statusText)
+	totalContentLen := displayWidth(helpText) + displayWidth(statusText)
 	if totalContentLen < maxX {
 		padding := strings.Repeat(" ", maxX-totalContentLen)
 		fmt.Fprintf(v, "%s%s%s", helpText, padding, statusText)
@@ -888,6 +1608,11 @@ func updateCursorPositions(g *gocui.Gui, app *AppState) error {
 	// Tree cursor is now handled by custom ScrollableList - no need to manage gocui cursor
 	
 	// Files cursor is also handled by custom ScrollableList - no need to manage gocui cursor
+
+	// Screen readers follow the terminal cursor, so accessible mode places it
+	// on the selected line
+	placeAccessibleCursor(g, app)
+	trackFocusedFile(app)
 	
 	return nil
 }
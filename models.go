@@ -4,118 +4,150 @@
 package main
 
 import (
+	"context"
+	"regexp"
 	"time"
-)
-
-type ScanResult struct {
-	Files map[string][]FileMatch `json:",inline"`
-}
-
-type FileMatch struct {
-	Component     string           `json:"component"`
-	Copyrights    []Copyright      `json:"copyrights"`
-	Cryptography  []interface{}    `json:"cryptography"`
-	Dependencies  []interface{}    `json:"dependencies"`
-	File          string           `json:"file"`
-	FileHash      string           `json:"file_hash"`
-	FileURL       string           `json:"file_url"`
-	Health        Health           `json:"health"`
-	ID            string           `json:"id"`
-	Latest        string           `json:"latest"`
-	Licenses      []License        `json:"licenses"`
-	Lines         interface{}      `json:"lines"`
-	OSSLines      interface{}      `json:"oss_lines"`
-	Purl          []string         `json:"purl"`
-	Quality       []Quality        `json:"quality"`
-	ReleaseDate   string           `json:"release_date"`
-	Server        Server           `json:"server"`
-	SourceHash    string           `json:"source_hash"`
-	Status        string           `json:"status"`
-	URL           string           `json:"url"`
-	URLHash       string           `json:"url_hash"`
-	URLStats      URLStats         `json:"url_stats"`
-	Version       string           `json:"version"`
-	AuditCmd      []AuditDecision  `json:"audit,omitempty"`
-}
-
-type Copyright struct {
-	Name   string `json:"name"`
-	Source string `json:"source"`
-}
-
-type Health struct {
-	CreationDate string `json:"creation_date"`
-	Forks        int    `json:"forks"`
-	Issues       int    `json:"issues"`
-	LastPush     string `json:"last_push"`
-	LastUpdate   string `json:"last_update"`
-	Stars        int    `json:"stars"`
-}
-
-type License struct {
-	ChecklistURL  string `json:"checklist_url,omitempty"`
-	Copyleft      string `json:"copyleft,omitempty"`
-	Name          string `json:"name"`
-	OSADLUpdated  string `json:"osadl_updated,omitempty"`
-	PatentHints   string `json:"patent_hints,omitempty"`
-	Source        string `json:"source"`
-	URL           string `json:"url,omitempty"`
-}
 
-type Quality struct {
-	Score  string `json:"score"`
-	Source string `json:"source"`
-}
-
-type Server struct {
-	Elapsed   string            `json:"elapsed"`
-	Flags     string            `json:"flags"`
-	Hostname  string            `json:"hostname"`
-	KBVersion map[string]string `json:"kb_version"`
-	Version   string            `json:"version"`
-}
-
-type URLStats struct {
-	IgnoredFiles  int `json:"ignored_files"`
-	IndexedFiles  int `json:"indexed_files"`
-	PackageSize   int `json:"package_size"`
-	SourceFiles   int `json:"source_files"`
-}
-
-type AuditDecision struct {
-	Decision   string    `json:"decision"`
-	Assessment string    `json:"assessment,omitempty"`
-	Timestamp  time.Time `json:"timestamp"`
-}
+	"auditcmd/pkg/audit"
+)
 
-type PURLRankEntry struct {
-	PURL     string
-	Files    []string
-	Count    int
-}
+// The scan result model lives in pkg/audit so other tools can reuse it
+type (
+	ScanResult    = audit.ScanResult
+	FileMatch     = audit.FileMatch
+	Copyright     = audit.Copyright
+	Health        = audit.Health
+	License       = audit.License
+	Quality       = audit.Quality
+	Server        = audit.Server
+	URLStats      = audit.URLStats
+	AuditDecision = audit.AuditDecision
+	AuditNote     = audit.AuditNote
+	PURLRankEntry = audit.PURLRankEntry
+	PURLVersion   = audit.PURLVersion
+	UpstreamGroup = audit.UpstreamGroup
+)
 
 type AppState struct {
-	ScanData          ScanResult
-	CurrentFile       string
-	CurrentMatch      *FileMatch
-	FileTree          *TreeNode
-	TreeState         *TreeState
-	ActivePane        string
-	FilePath          string
-	CurrentFileList   []string
-	SelectedFileIndex int
-	PendingDecision   string
-	PendingAssessment string
-	PaneWidth         float64
-	ViewFilter        string // "all", "matched", "pending"
-	APIKey            string
-	ViewMode          string // "list" or "content"
-	TreeViewType      string // "directories" or "purls"
-	PURLRanking       []PURLRankEntry
-	InitialFileListDone bool   // Track if initial file list has been populated
-	FileList          *ScrollableList // Custom scrollable file list
-	TreeList          *ScrollableList // Custom scrollable tree list
-	ProcessingQuickAction bool // Flag to prevent concurrent quick actions
+	ScanData              ScanResult
+	CurrentFile           string
+	CurrentMatch          *FileMatch
+	FileTree              *TreeNode
+	TreeState             *TreeState
+	ActivePane            string
+	FilePath              string
+	CurrentFileList       []string
+	SelectedFileIndex     int
+	PendingDecision       string
+	PendingAssessment     string
+	PaneWidth             float64
+	ViewFilter            string // "all", "matched", "pending", "deferred"
+	APIKey                string
+	HostKeys              map[string]string // api_key.<host> keys, see apiKeyFor
+	ViewMode              string            // "list" or "content"
+	TreeViewType          string            // "directories", "purls" or "upstream"
+	TreeOrder             string            // "name" or "pending" (most pending work first)
+	TreeFiles             bool              // Files listed as leaves of the directory tree
+	FlattenDirs           bool              // Chains of single subdirectories shown on one tree line
+	ContentFromTree       bool              // Content opened from a tree leaf; ESC returns to the tree
+	FileLayout            string            // "paths" or "columns"
+	LayoutPreset          string            // Pane arrangement, see layoutPresets
+	SimilarityMode        string            // "", "sort" (least similar match paths first) or "low" (only those below the threshold)
+	QualityMode           string            // "", "sort" (lowest quality first) or "low" (only those below quality_threshold)
+	SimilarityThreshold   int               // Path similarity percentage below which a match is suspicious
+	QualityThreshold      int               // Quality score percentage below which a match is highlighted
+	ColumnOffset          int               // Horizontal scroll of the column layout, in characters
+	PURLRanking           []PURLRankEntry
+	PURLOrder             string                         // "files", "size" (largest component first) or "pending" (most pending files first)
+	PURLOrderBefore       string                         // The order togglePURLOrder goes back to from "pending"
+	UpstreamGroups        []UpstreamGroup                // Files sharing the same matched OSS file
+	DecisionGroup         []string                       // Files the open accept/ignore dialog decides together
+	RestoreMatches        func()                         // Puts back the match order changed by chooseCandidate if the accept is cancelled
+	InitialFileListDone   bool                           // Track if initial file list has been populated
+	FileList              *ScrollableList                // Custom scrollable file list
+	TreeList              *ScrollableList                // Custom scrollable tree list
+	ProcessingQuickAction bool                           // Flag to prevent concurrent quick actions
+	Accessible            bool                           // Plain screen-reader friendly output
+	Announcement          string                         // Last state change, shown in the help bar in accessible mode
+	Redact                bool                           // Hash file paths and PURLs in the UI and exports
+	OnDecisionHook        string                         // Command run after each saved decision
+	Commands              map[rune]CustomCommand         // External commands bound to keys
+	Tracker               TrackerConfig                  // Issue tracker for the [k] action
+	Webhook               WebhookConfig                  // Milestone notifications
+	LastMilestone         int                            // Highest progress milestone already notified
+	GitCommitEvery        int                            // Commit the results file after this many decisions (0 = off)
+	UncommittedDecisions  []string                       // Decisions saved since the last git commit
+	SourceDir             string                         // Local checkout of the scanned code, for git blame
+	BaselinePath          string                         // Earlier scan compared against in delta mode
+	Delta                 map[string]string              // Delta kind of each finding since the baseline
+	DeltaCounts           map[string]int                 // New/removed/unchanged finding counts
+	DeltaOnly             bool                           // Show only findings new since the baseline
+	Drift                 map[string]string              // Files whose local copy no longer matches the scan
+	DriftChecked          bool                           // Local hashes have been verified
+	DriftOnly             bool                           // Show only drifted files
+	HideIdentified        bool                           // Hide identified and ignored files, whatever the view filter
+	AssessmentFilter      string                         // Text entered after [/], see compileAssessmentFilter
+	AssessmentPattern     *regexp.Regexp                 // Compiled AssessmentFilter, nil when not filtering
+	ReviewMode            bool                           // --review: decided files are the queue, see reviewInScope
+	Reviewer              string                         // Identity recorded as the auditor of decisions and with second opinions
+	TimeZone              *time.Location                 // Zone timestamps are shown in, nil for local time
+	TimestampFormat       string                         // Go layout timestamps are shown with, see formatTimestamp
+	ExportOnQuit          []string                       // Artifacts regenerated on exit, see exportOnQuit
+	ExportPreset          string                         // Export dialog preset: exportFull or exportSBOM
+	ExportSplit           string                         // Export dialog split: splitNone, splitDirectory or splitPURL
+	Unexported            bool                           // Changes were saved during the session
+	ProjectState          *audit.ProjectState            // Session history, see projectStatePath
+	SessionStart          time.Time                      // When this session started
+	SessionRecorded       bool                           // This session is the last one in ProjectState
+	RecordDuration        bool                           // Record how long a file was open with its decision
+	ExportHistory         bool                           // export_history: every decision as JSON in the CSV export
+	ExportStale           bool                           // export_stale: the Stale Component column in the CSV export
+	WriteStatus           bool                           // Give every match a status field on save
+	BOMFile               string                         // SCANOSS settings file whose BOM follows the decisions, see bomFilePath
+	QuickActions          string                         // quick_actions: instant, confirm or off
+	CollapseCompleted     string                         // collapse_completed: ask, always or never
+	AcceptReasons         Reasons                        // Comments offered with 1-9 in the accept dialog
+	IgnoreReasons         Reasons                        // Comments offered with 1-9 in the ignore dialog
+	CommentExpanded       bool                           // Accept/ignore comment shown in the large editor
+	Labels                map[string]string              // label.<state> names of the file states
+	Icons                 map[string]string              // icon.<state> markers of the file states
+	ExcludeGlobs          []string                       // --exclude and config exclude patterns
+	IgnoreList            []string                       // always_ignore: components ignored in every scan
+	Checklist             []string                       // review_checklist items
+	ChecklistTicks        []bool                         // Items ticked in the open accept dialog
+	ExcludePatterns       []*regexp.Regexp               // Compiled ExcludeGlobs
+	Excluded              map[string][]FileMatch         // Files left out of the audit, saved back unchanged
+	Duplicates            map[string]audit.DuplicatePath // Paths merged into another differing only in separators, saved back with its decisions
+	RootDir               string                         // --dir: the subdirectory being audited, "" for the whole scan
+	OutsideDir            map[string][]FileMatch         // Files outside RootDir, saved back unchanged
+	FocusedFile           string                         // File in focus, see trackFocusedFile
+	FocusedSince          time.Time                      // When FocusedFile got the focus
+	RescanCommand         string                         // Scanner command for [R], see defaultRescanCommand
+	WFP                   map[string]*audit.WFPFile      // Fingerprints from --wfp, keyed by normalized path
+	ContentSide           string                         // "oss" or "local" file in the content view
+	ContentHex            bool                           // Show file content as a hex dump
+	ContentScroll         map[string]int                 // Content scroll offset per file viewed this session
+	Dependencies          *audit.DependencyView          // Set when auditing a dependencies.json
+	ProjectLicense        string                         // Outbound license components are checked against
+	Stale                 audit.StaleThresholds          // When a matched component is flagged as stale
+	QuotaURL              string                         // API endpoint reporting the remaining quota
+	Quota                 *apiQuota                      // Last reported API quota, nil when unknown
+	ProvenanceURL         string                         // Geoprovenance endpoint, "" to not look up contributor countries
+	ComponentPageURL      string                         // Component page template with {purl}, "" for no component pages
+	Provenance            map[string]string              // Contributor countries by audit.ProvenancePURL, "" when unknown
+	Offline               bool                           // The API was unreachable, see loadFileContent
+	ContentCache          map[string]cachedContent       // Matched file contents fetched this session, by URL
+	LicenseTexts          map[string]string              // License texts fetched this session, by license name
+	NoContentCache        bool                           // Don't keep fetched contents on disk between sessions
+	Prefetch              int                            // Files after the viewed one fetched in the background (0 = off)
+	StopPrefetch          context.CancelFunc             // Cancels the running prefetch, nil when none runs
+	PrefetchHeld          bool                           // A prefetch was skipped as it could exhaust the API quota
+	BatchContentURL       string                         // Batched file content endpoint, see fetchContents
+	BatchUnsupported      bool                           // The batch endpoint answered that it isn't supported
+	MaxFetches            int                            // SCANOSS API requests run at once (0 = defaultMaxFetches)
+	Task                  *backgroundTask                // The long-running operation Esc cancels, see startTask
+	Tasks                 []*backgroundTask              // Running and recently finished tasks, oldest first
+	Progress              *progressState                 // What the progress dialog shows, nil while it is closed
 }
 
 type TreeNode struct {
@@ -131,6 +163,8 @@ type TreeState struct {
 	selectedNode *TreeNode
 	expandedDirs map[string]bool
 	displayLines []TreeDisplayLine
+	pendingDirs  map[string]int            // pending files per directory, when ordered by pending work
+	fileNodes    map[treeFileKey]*TreeNode // file leaves, with tree_files
 }
 
 type TreeDisplayLine struct {
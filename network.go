@@ -0,0 +1,116 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// defaultMaxFetches is how many SCANOSS API requests run at once unless
+// max_fetches says otherwise
+const defaultMaxFetches = 4
+
+// networkActivity limits how many SCANOSS API requests run at once and
+// tracks them for the help bar
+type networkActivity struct {
+	mu        sync.Mutex
+	slots     chan struct{}
+	inFlight  int
+	lastError string
+	onChange  func()
+}
+
+var network = &networkActivity{slots: make(chan struct{}, defaultMaxFetches)}
+
+// setLimit changes how many requests run at once. Requests already running
+// release their slot in the previous limit.
+func (n *networkActivity) setLimit(limit int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if cap(n.slots) != limit {
+		n.slots = make(chan struct{}, limit)
+	}
+}
+
+// do sends a request once a slot is free, unless its context is cancelled
+// first. Transport failures and error statuses are kept as the last error.
+func (n *networkActivity) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	n.mu.Lock()
+	slots := n.slots
+	n.mu.Unlock()
+	select {
+	case slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	n.update(1, "")
+
+	resp, err := client.Do(req)
+	lastError := ""
+	if err != nil {
+		lastError = err.Error()
+	} else if resp.StatusCode >= 400 {
+		lastError = resp.Status
+	}
+	<-slots
+	n.update(-1, lastError)
+	return resp, err
+}
+
+func (n *networkActivity) update(delta int, lastError string) {
+	n.mu.Lock()
+	n.inFlight += delta
+	if lastError != "" {
+		n.lastError = lastError
+	}
+	onChange := n.onChange
+	n.mu.Unlock()
+	if onChange != nil {
+		onChange()
+	}
+}
+
+// status returns the requests in flight and the last error
+func (n *networkActivity) status() (int, string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.inFlight, n.lastError
+}
+
+// watchNetwork applies max_fetches and refreshes the help bar whenever a
+// request starts or ends
+func watchNetwork(g *gocui.Gui, app *AppState) {
+	if app.MaxFetches > 0 {
+		network.setLimit(app.MaxFetches)
+	}
+	network.mu.Lock()
+	network.onChange = func() {
+		g.Update(func(g *gocui.Gui) error {
+			updateHelpBar(g, app)
+			return nil
+		})
+	}
+	network.mu.Unlock()
+}
+
+// networkStatus describes the network activity for the help bar, or
+// returns "" while idle without errors
+func networkStatus() string {
+	inFlight, lastError := network.status()
+	text := ""
+	if inFlight > 0 {
+		text = "net: " + strconv.Itoa(inFlight) + " in flight"
+	}
+	if lastError != "" {
+		if text != "" {
+			text += ", "
+		}
+		text += "last error: " + truncateColumn(lastError, 40, false)
+	}
+	return text
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// defaultMilestones are the completion percentages announced when
+// webhook_milestones isn't set
+var defaultMilestones = []int{25, 50, 75, 100}
+
+// WebhookConfig describes where audit milestones are posted
+type WebhookConfig struct {
+	URL        string
+	Format     string // "json" (default), "slack" or "teams"
+	Milestones []int
+}
+
+// WebhookEvent is the body posted in the "json" format
+type WebhookEvent struct {
+	Event       string    `json:"event"` // "milestone" or "export"
+	Message     string    `json:"message"`
+	ResultsFile string    `json:"results_file"`
+	Percentage  int       `json:"percentage"`
+	Audited     int       `json:"audited"`
+	Total       int       `json:"total"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// parseMilestones reads a comma-separated list such as "25,50,100"
+func parseMilestones(value string) ([]int, error) {
+	milestones := make([]int, 0)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		pct, err := strconv.Atoi(strings.TrimSuffix(part, "%"))
+		if err != nil || pct < 1 || pct > 100 {
+			return nil, fmt.Errorf("invalid webhook milestone %q (use 1-100)", part)
+		}
+		milestones = append(milestones, pct)
+	}
+	return milestones, nil
+}
+
+func formatMilestones(milestones []int) string {
+	parts := make([]string, len(milestones))
+	for i, pct := range milestones {
+		parts[i] = strconv.Itoa(pct)
+	}
+	return strings.Join(parts, ",")
+}
+
+// postWebhook sends an event in the configured format
+func postWebhook(webhook WebhookConfig, event WebhookEvent) error {
+	var payload interface{} = event
+	switch webhook.Format {
+	case "slack", "teams":
+		// Both accept a simple {"text": ...} message
+		payload = map[string]string{"text": event.Message}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(webhook.URL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// notifyWebhook posts an event in the background
+func notifyWebhook(g *gocui.Gui, app *AppState, kind, message string) {
+	if app.Webhook.URL == "" {
+		return
+	}
+
+	audited, total, percentage := calculateProgress(app)
+	event := WebhookEvent{
+		Event:       kind,
+		Message:     message,
+		ResultsFile: app.FilePath,
+		Percentage:  percentage,
+		Audited:     audited,
+		Total:       total,
+		Timestamp:   time.Now().UTC(),
+	}
+	webhook := app.Webhook
+
+	go func() {
+		if err := postWebhook(webhook, event); err != nil {
+			g.Update(func(g *gocui.Gui) error {
+				return showErrorDialog(g, app, "Webhook Error", fmt.Sprintf("Failed to post %s notification: %v", kind, err))
+			})
+		}
+	}()
+}
+
+// initMilestones marks milestones already reached when the audit is opened so
+// resuming a half-finished audit doesn't re-announce them
+func initMilestones(app *AppState) {
+	_, _, percentage := audit.Progress(&app.ScanData)
+	app.LastMilestone = 0
+	for _, pct := range app.Webhook.Milestones {
+		if percentage >= pct && pct > app.LastMilestone {
+			app.LastMilestone = pct
+		}
+	}
+}
+
+// checkMilestones posts a notification when progress crosses a new milestone
+func checkMilestones(g *gocui.Gui, app *AppState) {
+	if app.Webhook.URL == "" {
+		return
+	}
+
+	audited, total, percentage := calculateProgress(app)
+	reached := 0
+	for _, pct := range app.Webhook.Milestones {
+		if percentage >= pct && pct > app.LastMilestone && pct > reached {
+			reached = pct
+		}
+	}
+	if reached == 0 {
+		return
+	}
+	app.LastMilestone = reached
+
+	message := fmt.Sprintf("Audit of %s is %d%% complete (%d/%d files)", filepath.Base(app.FilePath), percentage, audited, total)
+	notifyWebhook(g, app, "milestone", message)
+}
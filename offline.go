@@ -0,0 +1,94 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// offlineRetryInterval is how often connectivity is checked while offline
+const offlineRetryInterval = 30 * time.Second
+
+// errAPIUnreachable marks requests that failed because the API couldn't be
+// reached, as opposed to errors reported by the API
+var errAPIUnreachable = errors.New("API unreachable")
+
+// errOffline is returned for content that isn't cached while offline
+var errOffline = errors.New("offline")
+
+// offlineBanner leads the status pane while working offline
+const offlineBanner = "\033[41m\033[37m OFFLINE \033[0m API unreachable, retrying"
+
+// loadFileContent returns the content of a matched file. Cached copies are
+// revalidated with their ETag, so unchanged files aren't downloaded again.
+// Once the API is found unreachable, auditcmd switches to offline mode:
+// cached content is shown as is and other files fail at once with
+// errOffline instead of waiting for a timeout, until a background check
+// finds the API reachable again.
+func loadFileContent(g *gocui.Gui, app *AppState, url string) (string, error) {
+	cached, hasCache := lookupContent(app, url)
+	if app.Offline {
+		if hasCache {
+			return cached.Content, nil
+		}
+		return "", errOffline
+	}
+
+	response, err := fetchFileContent(context.Background(), url, apiKeyFor(app, url), cached.ETag)
+	if response.Quota != nil {
+		app.Quota = response.Quota
+	}
+	if errors.Is(err, errAPIUnreachable) {
+		goOffline(g, app, url)
+		if hasCache {
+			return cached.Content, nil
+		}
+		return "", err
+	}
+	if err != nil {
+		return "", err
+	}
+	if response.NotModified {
+		return cached.Content, nil
+	}
+
+	storeContent(app, cachedContent{URL: url, ETag: response.ETag, Content: response.Content}, true)
+	return response.Content, nil
+}
+
+// goOffline switches to offline mode and retries url in the background
+// until the API answers again
+func goOffline(g *gocui.Gui, app *AppState, url string) {
+	if app.Offline {
+		return
+	}
+	app.Offline = true
+	announce(app, "SCANOSS API unreachable, working offline")
+	updateStatus(g, app)
+
+	apiKey := apiKeyFor(app, url)
+	go func() {
+		for {
+			time.Sleep(offlineRetryInterval)
+			_, err := fetchFileContent(context.Background(), url, apiKey, "")
+			if errors.Is(err, errAPIUnreachable) {
+				continue
+			}
+			g.Update(func(g *gocui.Gui) error {
+				app.Offline = false
+				announce(app, "SCANOSS API reachable again")
+				updateStatus(g, app)
+				if app.ViewMode == "content" && app.CurrentFile != "" {
+					return displayFileContent(g, app, app.CurrentFile)
+				}
+				return nil
+			})
+			return
+		}
+	}()
+}
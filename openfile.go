@@ -0,0 +1,104 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// resolveScanPath finds the file of the scan named on the command line. The
+// path may be given as in the results, with a leading "./" or backslashes,
+// or as a unique tail of a scanned path such as "src/util.c".
+func resolveScanPath(app *AppState, arg string) (string, error) {
+	if _, ok := app.ScanData.Files[arg]; ok {
+		return arg, checkOpenable(app, arg)
+	}
+	want := audit.CleanDirectory(arg)
+	if want == "" {
+		return "", fmt.Errorf("no file given")
+	}
+
+	var suffixMatches []string
+	for filePath := range app.ScanData.Files {
+		clean := audit.CleanDirectory(filePath)
+		if clean == want {
+			return filePath, checkOpenable(app, filePath)
+		}
+		if strings.HasSuffix(clean, "/"+want) {
+			suffixMatches = append(suffixMatches, filePath)
+		}
+	}
+
+	switch len(suffixMatches) {
+	case 0:
+		return "", fmt.Errorf("%s is not in the scan results", arg)
+	case 1:
+		return suffixMatches[0], checkOpenable(app, suffixMatches[0])
+	default:
+		return "", fmt.Errorf("%s matches %d scanned files, give more of the path", arg, len(suffixMatches))
+	}
+}
+
+// checkOpenable rejects files the tree doesn't list: only files with a
+// match are audited
+func checkOpenable(app *AppState, filePath string) error {
+	if audit.FirstValidMatch(app.ScanData.Files[filePath]) == nil {
+		return fmt.Errorf("%s has no match to audit", filePath)
+	}
+	return nil
+}
+
+// openAtFile expands the tree down to the file's directory, selects the file
+// in the list and shows its content, as if it had been opened by hand
+func openAtFile(g *gocui.Gui, app *AppState, filePath string) error {
+	if groupedView(app) {
+		app.TreeViewType = "directories"
+	}
+
+	dir := path.Dir(audit.NormalizePath(filePath))
+	if dir == "." {
+		dir = ""
+	}
+	for ancestor := dir; ancestor != ""; ancestor = parentDir(ancestor) {
+		app.TreeState.expandedDirs[ancestor] = true
+	}
+	app.TreeState.selectedNode = findTreeNode(app.FileTree, dir)
+
+	// Show decided files too when the saved filter would hide this one
+	if !audit.MatchesFilter(app.ScanData.Files[filePath], app.ViewFilter) {
+		app.ViewFilter = audit.FilterAll
+	}
+
+	app.ActivePane = "files"
+	app.ViewMode = "list"
+	updateTreeDisplay(app)
+	updateFileList(g, app)
+	for i, listed := range app.CurrentFileList {
+		if listed == filePath {
+			app.FileList.SelectedIndex = i
+			app.FileList.adjustScroll()
+			app.SelectedFileIndex = i
+			break
+		}
+	}
+
+	app.ViewMode = "content"
+	app.CurrentFile = filePath
+	announce(app, "Viewing %s, %s. Escape returns to the file list", displayPath(app, filePath), fileAuditState(app, filePath))
+	return openFileContent(g, app, filePath)
+}
+
+// parentDir returns the directory above dir, "" at the top of the scan
+func parentDir(dir string) string {
+	if i := strings.LastIndex(dir, "/"); i >= 0 {
+		return dir[:i]
+	}
+	return ""
+}
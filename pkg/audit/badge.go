@@ -0,0 +1,100 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// Badge is a status badge such as "audit | 73%"
+type Badge struct {
+	Label   string
+	Message string
+	Color   string // Hex colour of the message side, e.g. "#dfb317"
+}
+
+// badgeColors are the shields.io colours used from a completion percentage
+// up, best first
+var badgeColors = []struct {
+	minimum int
+	color   string
+}{
+	{100, "#4c1"},   // brightgreen
+	{75, "#97ca00"}, // green
+	{50, "#dfb317"}, // yellow
+	{25, "#fe7d37"}, // orange
+	{0, "#e05d44"},  // red
+}
+
+// ProgressBadge returns the audit completion of scan as a badge, coloured
+// from red to bright green as files are audited
+func ProgressBadge(scan *ScanResult) Badge {
+	_, total, percentage := Progress(scan)
+	badge := Badge{Label: "audit", Message: fmt.Sprintf("%d%%", percentage)}
+	if total == 0 {
+		badge.Message = "no matches"
+		badge.Color = badgeColors[0].color
+		return badge
+	}
+	for _, step := range badgeColors {
+		if percentage >= step.minimum {
+			badge.Color = step.color
+			break
+		}
+	}
+	return badge
+}
+
+// badgeTextWidth estimates the rendered width of text in the 11px
+// Verdana badges use
+func badgeTextWidth(text string) int {
+	return len([]rune(text))*7 + 10
+}
+
+// WriteBadgeSVG writes the badge as a flat SVG image that renders in
+// READMEs without a badge service
+func WriteBadgeSVG(w io.Writer, badge Badge) error {
+	labelWidth := badgeTextWidth(badge.Label)
+	messageWidth := badgeTextWidth(badge.Message)
+	width := labelWidth + messageWidth
+	label := html.EscapeString(badge.Label)
+	message := html.EscapeString(badge.Message)
+
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">
+  <title>%s: %s</title>
+  <linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+  <clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>
+  <g clip-path="url(#r)">
+    <rect width="%d" height="20" fill="#555"/>
+    <rect x="%d" width="%d" height="20" fill="%s"/>
+    <rect width="%d" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="%d" y="14">%s</text>
+    <text x="%d" y="14">%s</text>
+  </g>
+</svg>
+`, width, label, message, label, message, width,
+		labelWidth, labelWidth, messageWidth, html.EscapeString(badge.Color), width,
+		labelWidth/2, label, labelWidth+messageWidth/2, message)
+	return err
+}
+
+// WriteBadgeJSON writes the badge in the shields.io endpoint format, for
+// badges served through img.shields.io/endpoint
+func WriteBadgeJSON(w io.Writer, badge Badge) error {
+	endpoint := struct {
+		SchemaVersion int    `json:"schemaVersion"`
+		Label         string `json:"label"`
+		Message       string `json:"message"`
+		Color         string `json:"color"`
+	}{1, badge.Label, badge.Message, strings.TrimPrefix(badge.Color, "#")}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(endpoint)
+}
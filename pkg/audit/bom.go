@@ -0,0 +1,188 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BOMAssessment is the assessment of decisions adopted from the bom section
+// of a SCANOSS settings file
+const BOMAssessment = "Recorded by another tool in the SCANOSS settings BOM"
+
+// BOM is the bom section of a SCANOSS settings file (scanoss.json), where
+// SCANOSS Workbench and scanoss-py keep the components included in or
+// removed from the bill of materials. Entries have a purl, a path or both;
+// their other fields, and the rest of the file, are kept as they are.
+type BOM struct {
+	Include  []map[string]any
+	Remove   []map[string]any
+	settings map[string]json.RawMessage // The whole settings file
+	section  map[string]json.RawMessage // Its bom section
+}
+
+// LoadBOM reads the bom section of the settings file at path. A missing
+// file gives an empty BOM.
+func LoadBOM(path string) (*BOM, error) {
+	bom := &BOM{settings: make(map[string]json.RawMessage), section: make(map[string]json.RawMessage)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return bom, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &bom.settings); err != nil {
+		return nil, fmt.Errorf("invalid settings file %s: %v", path, err)
+	}
+	if raw, ok := bom.settings["bom"]; ok {
+		if err := json.Unmarshal(raw, &bom.section); err != nil {
+			return nil, fmt.Errorf("invalid bom in %s: %v", path, err)
+		}
+	}
+	for key, entries := range map[string]*[]map[string]any{"include": &bom.Include, "remove": &bom.Remove} {
+		if raw, ok := bom.section[key]; ok {
+			if err := json.Unmarshal(raw, entries); err != nil {
+				return nil, fmt.Errorf("invalid bom.%s in %s: %v", key, path, err)
+			}
+		}
+	}
+	return bom, nil
+}
+
+// Save writes the settings file back to path with the BOM as it is now
+func (b *BOM) Save(path string) error {
+	for key, entries := range map[string][]map[string]any{"include": b.Include, "remove": b.Remove} {
+		if _, present := b.section[key]; !present && len(entries) == 0 {
+			continue
+		}
+		if entries == nil {
+			entries = []map[string]any{}
+		}
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		b.section[key] = data
+	}
+	section, err := json.Marshal(b.section)
+	if err != nil {
+		return err
+	}
+	b.settings["bom"] = section
+	data, err := json.MarshalIndent(b.settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// entryField is a string field of a BOM entry, "" when it has none
+func entryField(entry map[string]any, field string) string {
+	value, _ := entry[field].(string)
+	return value
+}
+
+// covers reports whether a BOM entry applies to the file at path matched
+// to purl, and whether it names the file rather than the whole component
+func covers(entry map[string]any, path, purl string) (applies, specific bool) {
+	entryPath, entryPURL := NormalizePath(entryField(entry, "path")), entryField(entry, "purl")
+	if entryPath == "" && entryPURL == "" {
+		return false, false
+	}
+	if entryPath != "" && entryPath != NormalizePath(path) {
+		return false, false
+	}
+	if entryPURL != "" && !strings.EqualFold(ComponentPURL(entryPURL), ComponentPURL(purl)) {
+		return false, false
+	}
+	return true, entryPath != ""
+}
+
+// Decision is the decision the BOM records for the file at path matched to
+// purl, or "" when it has none. Entries naming the file win over those for
+// the whole component, and removals over inclusions.
+func (b *BOM) Decision(path, purl string) string {
+	decision, specific := "", false
+	for _, list := range []struct {
+		entries  []map[string]any
+		decision string
+	}{{b.Include, DecisionIdentified}, {b.Remove, DecisionIgnored}} {
+		for _, entry := range list.entries {
+			if applies, named := covers(entry, path, purl); applies && (named || !specific) {
+				decision, specific = list.decision, named
+			}
+		}
+	}
+	return decision
+}
+
+// AdoptBOM records the decisions of the BOM for the matches without an
+// audit array, timestamped now
+func (s *ScanResult) AdoptBOM(bom *BOM, now time.Time) {
+	for path, matches := range s.Files {
+		match := FirstValidMatch(matches)
+		if match == nil || match.IsAudited() || len(match.Purl) == 0 {
+			continue
+		}
+		if decision := bom.Decision(path, match.Purl[0]); decision != "" {
+			match.AuditCmd = append(match.AuditCmd, AuditDecision{Decision: decision, Assessment: BOMAssessment, Timestamp: now})
+		}
+	}
+}
+
+// Sync brings the BOM in line with the accepted and ignored files of
+// the results: each gets an entry naming its path and component in the
+// list of its decision, unless the BOM already records that decision, and
+// entries naming it are taken off the other list. Pending and deferred
+// files, other files and entries for whole components are left alone. It
+// reports whether the BOM changed.
+func (b *BOM) Sync(s *ScanResult) bool {
+	paths := make([]string, 0, len(s.Files))
+	for path := range s.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	changed := false
+	for _, path := range paths {
+		match := FirstValidMatch(s.Files[path])
+		if match == nil || len(match.Purl) == 0 {
+			continue
+		}
+		keep, drop, decision := &b.Include, &b.Remove, DecisionIdentified
+		switch MatchStatus(match) {
+		case StatusIdentified:
+		case StatusIgnored:
+			keep, drop, decision = drop, keep, DecisionIgnored
+		default:
+			continue
+		}
+		purl := ComponentPURL(match.Purl[0])
+
+		// Entries naming the file in the other list go
+		kept := (*drop)[:0]
+		for _, entry := range *drop {
+			if applies, named := covers(entry, path, purl); applies && named {
+				changed = true
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		*drop = kept
+
+		if b.Decision(path, purl) != decision {
+			*keep = append(*keep, map[string]any{"path": NormalizePath(path), "purl": purl})
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"sort"
+	"strings"
+)
+
+// candidateKey identifies what an auditor decides on when accepting a
+// match: the component, whatever the version, and its licenses
+func candidateKey(match *FileMatch) string {
+	purl := ""
+	if len(match.Purl) > 0 {
+		purl = ProvenancePURL(match.Purl[0])
+	}
+	licenses := make([]string, 0, len(match.Licenses))
+	for _, license := range match.Licenses {
+		licenses = append(licenses, strings.ToLower(license.Name))
+	}
+	sort.Strings(licenses)
+	return purl + "\x00" + strings.Join(licenses, ",")
+}
+
+// MatchCandidates returns the indexes of the auditable matches of a file
+// when they disagree on the component or its licenses: one per component
+// and license set, in scan order, so the first is the match decisions
+// apply to. It returns nil when the matches agree.
+func MatchCandidates(matches []FileMatch) []int {
+	var candidates []int
+	seen := make(map[string]bool)
+	for i := range matches {
+		if !IsValidMatch(matches[i]) {
+			continue
+		}
+		if key := candidateKey(&matches[i]); !seen[key] {
+			seen[key] = true
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) < 2 {
+		return nil
+	}
+	return candidates
+}
+
+// PreferMatch moves matches[index] in front of the others, keeping their
+// order, so that decisions, reports and other SCANOSS tools use it as the
+// file's match
+func PreferMatch(matches []FileMatch, index int) {
+	if index <= 0 || index >= len(matches) {
+		return
+	}
+	chosen := matches[index]
+	copy(matches[1:index+1], matches[:index])
+	matches[0] = chosen
+}
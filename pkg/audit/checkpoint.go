@@ -0,0 +1,82 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// Checkpoint is a named copy of every audit decision in a scan, used to roll
+// back bulk changes. Decisions are stored per file, per match index.
+type Checkpoint struct {
+	Name      string                       `json:"name"`
+	Created   time.Time                    `json:"created"`
+	Audited   int                          `json:"audited"`
+	Decisions map[string][][]AuditDecision `json:"decisions"`
+}
+
+// TakeCheckpoint copies the current decisions of scan
+func TakeCheckpoint(scan *ScanResult, name string) *Checkpoint {
+	checkpoint := &Checkpoint{
+		Name:      name,
+		Created:   time.Now().UTC(),
+		Decisions: make(map[string][][]AuditDecision),
+	}
+	checkpoint.Audited, _, _ = Progress(scan)
+
+	for filePath, matches := range scan.Files {
+		perMatch := make([][]AuditDecision, len(matches))
+		audited := false
+		for i := range matches {
+			if len(matches[i].AuditCmd) > 0 {
+				perMatch[i] = append([]AuditDecision(nil), matches[i].AuditCmd...)
+				audited = true
+			}
+		}
+		if audited {
+			checkpoint.Decisions[filePath] = perMatch
+		}
+	}
+	return checkpoint
+}
+
+// Restore replaces every decision in scan with the ones in the checkpoint.
+// Matches that had no decisions when it was taken become pending again.
+// Audit notes such as created issues are left untouched.
+func (c *Checkpoint) Restore(scan *ScanResult) {
+	for filePath, matches := range scan.Files {
+		saved := c.Decisions[filePath]
+		for i := range matches {
+			if i < len(saved) && len(saved[i]) > 0 {
+				matches[i].AuditCmd = append([]AuditDecision(nil), saved[i]...)
+			} else {
+				matches[i].AuditCmd = nil
+			}
+		}
+	}
+}
+
+// SaveCheckpoint writes a checkpoint as JSON
+func SaveCheckpoint(path string, checkpoint *Checkpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadCheckpoint reads a checkpoint written by SaveCheckpoint
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
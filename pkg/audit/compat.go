@@ -0,0 +1,119 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// License kinds used by the compatibility check
+const (
+	kindPermissive = "permissive"
+	kindApache     = "apache"
+	kindWeak       = "weak copyleft"
+	kindGPL2Only   = "GPL-2.0-only"
+	kindGPL2Plus   = "GPL-2.0-or-later"
+	kindGPL3       = "GPL-3.0"
+	kindAGPL3      = "AGPL-3.0"
+	kindLGPL3      = "LGPL-3.0"
+	kindGPLHostile = "GPL-incompatible copyleft"
+	kindUnknown    = "unknown"
+)
+
+// licenseKind classifies an SPDX identifier. Licenses that don't affect
+// compatibility decisions (or aren't known) are kindUnknown.
+func licenseKind(spdx string) string {
+	id := strings.ToUpper(strings.TrimSpace(spdx))
+	switch {
+	case id == "":
+		return kindUnknown
+	case strings.HasPrefix(id, "AGPL-3.0"):
+		return kindAGPL3
+	case strings.HasPrefix(id, "GPL-3.0"):
+		return kindGPL3
+	case id == "GPL-2.0+" || id == "GPL-2.0-OR-LATER":
+		return kindGPL2Plus
+	case strings.HasPrefix(id, "GPL-2.0"):
+		return kindGPL2Only
+	case strings.HasPrefix(id, "LGPL-3.0"):
+		return kindLGPL3
+	case strings.HasPrefix(id, "LGPL-2"), id == "MPL-2.0":
+		return kindWeak
+	case strings.HasPrefix(id, "EPL-"), strings.HasPrefix(id, "CDDL-"), id == "MPL-1.1", id == "MPL-1.0":
+		return kindGPLHostile
+	case id == "APACHE-2.0":
+		return kindApache
+	case id == "MIT", id == "ISC", id == "ZLIB", id == "0BSD", id == "X11", id == "UNLICENSE",
+		id == "CC0-1.0", id == "BSL-1.0", id == "WTFPL", id == "POSTGRESQL", id == "PYTHON-2.0",
+		strings.HasPrefix(id, "BSD-"):
+		return kindPermissive
+	}
+	return kindUnknown
+}
+
+// isStrongCopyleft reports whether using a kind requires the whole work to
+// be distributed under the GPL family
+func isStrongCopyleft(kind string) bool {
+	switch kind {
+	case kindGPL2Only, kindGPL2Plus, kindGPL3, kindAGPL3:
+		return true
+	}
+	return false
+}
+
+// LicenseConflict explains why a component license can't be used in a
+// project distributed under another license
+func LicenseConflict(project, license string) string {
+	projectKind := licenseKind(project)
+	kind := licenseKind(license)
+	if kind == kindUnknown || strings.EqualFold(project, license) {
+		return ""
+	}
+
+	switch projectKind {
+	case kindGPL2Only:
+		switch kind {
+		case kindApache, kindGPL3, kindLGPL3, kindAGPL3, kindGPLHostile:
+			return fmt.Sprintf("%s is not compatible with GPL-2.0-only", license)
+		}
+	case kindGPL2Plus:
+		switch kind {
+		case kindAGPL3, kindGPLHostile:
+			return fmt.Sprintf("%s is not compatible with the GPL", license)
+		}
+	case kindGPL3, kindAGPL3:
+		switch kind {
+		case kindGPL2Only, kindGPLHostile:
+			return fmt.Sprintf("%s is not compatible with %s", license, project)
+		}
+	default:
+		// Permissive, weak copyleft and proprietary projects can't take code
+		// whose license extends to the whole work
+		if isStrongCopyleft(kind) {
+			return fmt.Sprintf("%s requires the combined work to be released under the same license", license)
+		}
+	}
+	return ""
+}
+
+// LicenseConflicts returns the conflicts of every license of a match with
+// the project license
+func LicenseConflicts(project string, match *FileMatch) []string {
+	if project == "" || match == nil {
+		return nil
+	}
+	var conflicts []string
+	seen := make(map[string]bool)
+	for _, license := range match.Licenses {
+		if seen[license.Name] {
+			continue
+		}
+		seen[license.Name] = true
+		if conflict := LicenseConflict(project, license.Name); conflict != "" {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts
+}
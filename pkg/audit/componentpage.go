@@ -0,0 +1,20 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ComponentPageURL is the page of the component of purl on a SCANOSS
+// platform, from a template such as
+// https://platform.example.com/components?purl={purl}. {purl} is replaced by
+// the PURL without version, query-escaped. It is "" when template or purl is.
+func ComponentPageURL(template, purl string) string {
+	if template == "" || purl == "" {
+		return ""
+	}
+	return strings.ReplaceAll(template, "{purl}", url.QueryEscape(ProvenancePURL(purl)))
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Component is one identified (accepted) component release with the
+// licenses found for it
+type Component struct {
+	Name     string
+	PURL     string
+	Version  string
+	Licenses []string
+	Files    int
+}
+
+// componentName falls back to the last PURL segment when the match has no
+// component name
+func componentName(match *FileMatch, purl string) string {
+	if match.Component != "" {
+		return match.Component
+	}
+	name := strings.TrimPrefix(purl, "pkg:")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name, _, _ = strings.Cut(name, "@")
+	return name
+}
+
+// CutPURLVersion splits purl at the '@' before its version, the last one
+// after the final '/' of the name, so the scope of pkg:npm/@babel/core@7.0.0
+// is kept. The version keeps the qualifiers and subpath following it.
+func CutPURLVersion(purl string) (base, version string, found bool) {
+	name := purl
+	if end := strings.IndexAny(name, "?#"); end >= 0 {
+		name = name[:end]
+	}
+	at := strings.LastIndex(name, "@")
+	if at < 0 || at < strings.LastIndex(name, "/") {
+		return purl, "", false
+	}
+	return purl[:at], purl[at+1:], true
+}
+
+// ComponentPURL is the PURL of the component, without version, e.g. to
+// compare matches of any release
+func ComponentPURL(purl string) string {
+	base, _, _ := CutPURLVersion(purl)
+	return base
+}
+
+// CollectComponents lists the identified components by PURL and version,
+// ordered by PURL
+func CollectComponents(scan *ScanResult) []*Component {
+	byKey := make(map[string]*Component)
+	for _, matches := range scan.Files {
+		match := FirstValidMatch(matches)
+		if match == nil || MatchStatus(match) != StatusIdentified || len(match.Purl) == 0 {
+			continue
+		}
+		purl := match.Purl[0]
+		key := purl + "@" + match.Version
+		component := byKey[key]
+		if component == nil {
+			component = &Component{Name: componentName(match, purl), PURL: purl, Version: match.Version}
+			byKey[key] = component
+		}
+		component.Files++
+		for _, license := range match.Licenses {
+			if license.Name != "" && !slices.Contains(component.Licenses, license.Name) {
+				component.Licenses = append(component.Licenses, license.Name)
+			}
+		}
+	}
+
+	components := make([]*Component, 0, len(byKey))
+	for _, component := range byKey {
+		sort.Strings(component.Licenses)
+		components = append(components, component)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].PURL != components[j].PURL {
+			return components[i].PURL < components[j].PURL
+		}
+		return components[i].Version < components[j].Version
+	})
+	return components
+}
+
+// WriteCycloneDX writes the components as a minimal CycloneDX 1.4 BOM, the
+// format compliance tools such as FOSSology import
+func WriteCycloneDX(w io.Writer, components []*Component) error {
+	type license struct {
+		License struct {
+			ID string `json:"id"`
+		} `json:"license"`
+	}
+	type bomComponent struct {
+		Type     string    `json:"type"`
+		Name     string    `json:"name"`
+		Version  string    `json:"version,omitempty"`
+		PURL     string    `json:"purl"`
+		Licenses []license `json:"licenses,omitempty"`
+	}
+	bom := struct {
+		BOMFormat   string         `json:"bomFormat"`
+		SpecVersion string         `json:"specVersion"`
+		Version     int            `json:"version"`
+		Components  []bomComponent `json:"components"`
+	}{BOMFormat: "CycloneDX", SpecVersion: "1.4", Version: 1, Components: make([]bomComponent, 0, len(components))}
+
+	for _, c := range components {
+		entry := bomComponent{Type: "library", Name: c.Name, Version: c.Version, PURL: c.PURL}
+		for _, name := range c.Licenses {
+			var l license
+			l.License.ID = name
+			entry.Licenses = append(entry.Licenses, l)
+		}
+		bom.Components = append(bom.Components, entry)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(bom)
+}
+
+// ExportComponentsCSV writes the SBOM-ready export: one row per identified
+// component release, see CollectComponents, with its licenses and how many
+// files were accepted under it. Pending, deferred and ignored files are left
+// out. Of opts, Context, Progress and MapPURL apply.
+func ExportComponentsCSV(w io.Writer, scan *ScanResult, opts CSVOptions) error {
+	if opts.MapPURL == nil {
+		opts.MapPURL = identity
+	}
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"Component", "Version", "PURL", "License", "Files"}); err != nil {
+		return fmt.Errorf("failed to write header: %v", err)
+	}
+	components := CollectComponents(scan)
+	for i, component := range components {
+		if err := opts.Context.Err(); err != nil {
+			return err
+		}
+		if opts.Progress != nil {
+			opts.Progress(i+1, len(components))
+		}
+		name, purl := component.Name, opts.MapPURL(component.PURL)
+		if purl != component.PURL {
+			// The name would give away a rewritten PURL, e.g. a redacted one
+			name = componentName(&FileMatch{}, purl)
+		}
+		record := []string{name, component.Version, purl, strings.Join(component.Licenses, "; "), strconv.Itoa(component.Files)}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write record: %v", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ComponentCopyrights holds the deduplicated copyright statements of one
+// identified component
+type ComponentCopyrights struct {
+	Component  string
+	PURL       string
+	Version    string
+	Statements []string
+}
+
+var (
+	copyrightMarker = regexp.MustCompile(`(?i)^(copyright|\(c\)|©)[\s:]*`)
+	copyrightYear   = regexp.MustCompile(`\b(19|20)\d{2}\b`)
+	yearRange       = regexp.MustCompile(`\b((?:19|20)\d{2})\s*[-–]\s*((?:19|20)\d{2})\b`)
+	yearSeparators  = regexp.MustCompile(`^[\s,\-–/]+`)
+	rightsReserved  = regexp.MustCompile(`(?i)[\s.,;]*all rights reserved[\s.]*$`)
+)
+
+// holderStatement collects the years of all statements by one holder
+type holderStatement struct {
+	holder string
+	years  map[int]bool
+}
+
+// parseCopyright splits a statement such as
+// "Copyright (c) 2010-2015, Foo Inc. All rights reserved." into its holder
+// and the years it mentions
+func parseCopyright(statement string) (holder string, years []int) {
+	s := strings.TrimSpace(statement)
+	for {
+		next := strings.TrimSpace(copyrightMarker.ReplaceAllString(s, ""))
+		if next == s {
+			break
+		}
+		s = next
+	}
+
+	years = mentionedYears(s)
+
+	// Leading years, e.g. "2010-2015, 2018 Foo"
+	for {
+		loc := copyrightYear.FindStringIndex(s)
+		if loc == nil || loc[0] != 0 {
+			break
+		}
+		s = yearSeparators.ReplaceAllString(s[loc[1]:], "")
+	}
+	s = rightsReserved.ReplaceAllString(copyrightYear.ReplaceAllString(s, ""), "")
+	s = strings.Trim(strings.TrimSpace(s), ".,;- ")
+	return strings.Join(strings.Fields(s), " "), years
+}
+
+// mentionedYears lists the years of a statement, every year of a range
+// such as "2010-2015" included
+func mentionedYears(s string) []int {
+	var years []int
+	for _, match := range yearRange.FindAllStringSubmatch(s, -1) {
+		first, _ := strconv.Atoi(match[1])
+		last, _ := strconv.Atoi(match[2])
+		for year := first; year <= last; year++ {
+			years = append(years, year)
+		}
+	}
+	for _, match := range copyrightYear.FindAllString(yearRange.ReplaceAllString(s, ""), -1) {
+		year, _ := strconv.Atoi(match)
+		years = append(years, year)
+	}
+	return years
+}
+
+// holderKey makes near-duplicate holders equal, e.g. "Foo, Inc." and "foo inc"
+func holderKey(holder string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(holder) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// formatYears turns a set of years into ranges of consecutive years, e.g.
+// "2010-2012, 2015"
+func formatYears(years map[int]bool) string {
+	sorted := make([]int, 0, len(years))
+	for year := range years {
+		sorted = append(sorted, year)
+	}
+	sort.Ints(sorted)
+
+	ranges := make([]string, 0)
+	for i := 0; i < len(sorted); {
+		end := i
+		for end+1 < len(sorted) && sorted[end+1] == sorted[end]+1 {
+			end++
+		}
+		if end == i {
+			ranges = append(ranges, strconv.Itoa(sorted[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", sorted[i], sorted[end]))
+		}
+		i = end + 1
+	}
+	return strings.Join(ranges, ", ")
+}
+
+// MergeCopyrights collapses statements that name the same holder into one
+// "Copyright (c) <years> <holder>" line covering all years mentioned
+func MergeCopyrights(statements []string) []string {
+	byHolder := make(map[string]*holderStatement)
+	order := make([]string, 0)
+
+	for _, statement := range statements {
+		holder, years := parseCopyright(statement)
+		key := holderKey(holder)
+		if key == "" {
+			continue
+		}
+		entry := byHolder[key]
+		if entry == nil {
+			entry = &holderStatement{holder: holder, years: make(map[int]bool)}
+			byHolder[key] = entry
+			order = append(order, key)
+		} else if len(holder) > len(entry.holder) {
+			// Prefer the most complete spelling, e.g. "Foo, Inc." over "Foo Inc"
+			entry.holder = holder
+		}
+		for _, year := range years {
+			entry.years[year] = true
+		}
+	}
+
+	merged := make([]string, 0, len(order))
+	for _, key := range order {
+		entry := byHolder[key]
+		if years := formatYears(entry.years); years != "" {
+			merged = append(merged, fmt.Sprintf("Copyright (c) %s %s", years, entry.holder))
+		} else {
+			merged = append(merged, fmt.Sprintf("Copyright (c) %s", entry.holder))
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// CollectCopyrights groups the copyright statements of all identified
+// matches by component, ordered by PURL
+func CollectCopyrights(scan *ScanResult) []*ComponentCopyrights {
+	byPURL := make(map[string]*ComponentCopyrights)
+	statements := make(map[string][]string)
+
+	for _, matches := range scan.Files {
+		match := FirstValidMatch(matches)
+		if match == nil || MatchStatus(match) != StatusIdentified || len(match.Copyrights) == 0 {
+			continue
+		}
+		purl := ""
+		if len(match.Purl) > 0 {
+			purl = match.Purl[0]
+		}
+		entry := byPURL[purl]
+		if entry == nil {
+			entry = &ComponentCopyrights{Component: match.Component, PURL: purl, Version: match.Version}
+			byPURL[purl] = entry
+		}
+		for _, copyright := range match.Copyrights {
+			statements[purl] = append(statements[purl], copyright.Name)
+		}
+	}
+
+	result := make([]*ComponentCopyrights, 0, len(byPURL))
+	for purl, entry := range byPURL {
+		entry.Statements = MergeCopyrights(statements[purl])
+		if len(entry.Statements) > 0 {
+			result = append(result, entry)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PURL < result[j].PURL
+	})
+	return result
+}
+
+func componentTitle(c *ComponentCopyrights) string {
+	title := c.Component
+	if title == "" {
+		title = c.PURL
+	}
+	if c.Version != "" {
+		title += " " + c.Version
+	}
+	return title
+}
+
+// WriteCopyrightsText writes the plain text block used in attribution
+// documents: each component followed by its statements
+func WriteCopyrightsText(w io.Writer, components []*ComponentCopyrights) error {
+	for i, c := range components {
+		if i > 0 {
+			fmt.Fprintf(w, "\n")
+		}
+		fmt.Fprintf(w, "%s (%s)\n", componentTitle(c), c.PURL)
+		for _, statement := range c.Statements {
+			if _, err := fmt.Fprintf(w, "  %s\n", statement); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteCopyrightsMarkdown writes one section per component
+func WriteCopyrightsMarkdown(w io.Writer, components []*ComponentCopyrights) error {
+	fmt.Fprintf(w, "# Copyright Notices\n")
+	if len(components) == 0 {
+		fmt.Fprintf(w, "\nNo identified components with copyright statements.\n")
+		return nil
+	}
+	for _, c := range components {
+		fmt.Fprintf(w, "\n## %s\n\n`%s`\n\n", componentTitle(c), c.PURL)
+		for _, statement := range c.Statements {
+			if _, err := fmt.Fprintf(w, "- %s\n", statement); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CryptoAlgorithm is one algorithm the scan detected in a match, e.g.
+// {"algorithm": "aes", "strength": "256"}
+type CryptoAlgorithm struct {
+	Algorithm string
+	Strength  string // Key or digest length in bits, "" when not reported
+}
+
+// CryptoAlgorithms reads the cryptography the scan reported for a match.
+// Entries are objects with an algorithm (or name) and a strength, or plain
+// algorithm names; anything else is skipped.
+func (m *FileMatch) CryptoAlgorithms() []CryptoAlgorithm {
+	algorithms := make([]CryptoAlgorithm, 0, len(m.Cryptography))
+	for _, entry := range m.Cryptography {
+		switch value := entry.(type) {
+		case string:
+			if value != "" {
+				algorithms = append(algorithms, CryptoAlgorithm{Algorithm: value})
+			}
+		case map[string]interface{}:
+			name, _ := value["algorithm"].(string)
+			if name == "" {
+				name, _ = value["name"].(string)
+			}
+			if name == "" {
+				continue
+			}
+			algorithm := CryptoAlgorithm{Algorithm: name}
+			switch strength := value["strength"].(type) {
+			case string:
+				algorithm.Strength = strength
+			case float64:
+				algorithm.Strength = strconv.FormatFloat(strength, 'f', -1, 64)
+			}
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+	return algorithms
+}
+
+// Categories of CryptoCategory, in report order
+const (
+	CryptoSymmetric  = "Symmetric encryption"
+	CryptoAsymmetric = "Asymmetric encryption and key exchange"
+	CryptoSignature  = "Digital signatures"
+	CryptoHash       = "Hashes, MACs and key derivation"
+	CryptoOther      = "Other"
+)
+
+var cryptoCategories = []string{CryptoSymmetric, CryptoAsymmetric, CryptoSignature, CryptoHash, CryptoOther}
+
+// cryptoFamilies maps algorithm families to their category, see
+// cryptoFamily
+var cryptoFamilies = map[string]string{
+	"aes": CryptoSymmetric, "des": CryptoSymmetric, "3des": CryptoSymmetric, "tripledes": CryptoSymmetric,
+	"triple": CryptoSymmetric, "desede": CryptoSymmetric, "tdes": CryptoSymmetric, "blowfish": CryptoSymmetric,
+	"twofish": CryptoSymmetric, "threefish": CryptoSymmetric, "serpent": CryptoSymmetric, "camellia": CryptoSymmetric,
+	"cast": CryptoSymmetric, "idea": CryptoSymmetric, "rc": CryptoSymmetric, "arc4": CryptoSymmetric, "arcfour": CryptoSymmetric,
+	"chacha": CryptoSymmetric, "salsa": CryptoSymmetric, "xsalsa": CryptoSymmetric, "seed": CryptoSymmetric,
+	"aria": CryptoSymmetric, "sm4": CryptoSymmetric, "tea": CryptoSymmetric, "xtea": CryptoSymmetric,
+	"skipjack": CryptoSymmetric, "gost28147": CryptoSymmetric,
+
+	"rsa": CryptoAsymmetric, "dh": CryptoAsymmetric, "diffiehellman": CryptoAsymmetric, "ecdh": CryptoAsymmetric,
+	"x25519": CryptoAsymmetric, "x448": CryptoAsymmetric, "curve": CryptoAsymmetric, "elgamal": CryptoAsymmetric, "ecc": CryptoAsymmetric,
+	"ecies": CryptoAsymmetric, "mqv": CryptoAsymmetric, "ntru": CryptoAsymmetric, "kyber": CryptoAsymmetric,
+	"mlkem": CryptoAsymmetric, "sm2": CryptoAsymmetric,
+
+	"dsa": CryptoSignature, "ecdsa": CryptoSignature, "ed": CryptoSignature, "eddsa": CryptoSignature,
+	"schnorr": CryptoSignature, "dilithium": CryptoSignature, "mldsa": CryptoSignature, "sphincs": CryptoSignature,
+
+	"md": CryptoHash, "sha": CryptoHash, "shake": CryptoHash, "ripemd": CryptoHash, "whirlpool": CryptoHash,
+	"blake": CryptoHash, "blake2b": CryptoHash, "blake2s": CryptoHash, "sm3": CryptoHash, "tiger": CryptoHash,
+	"hmac": CryptoHash, "cmac": CryptoHash, "gmac": CryptoHash, "poly": CryptoHash, "siphash": CryptoHash,
+	"pbkdf": CryptoHash, "bcrypt": CryptoHash, "scrypt": CryptoHash, "argon": CryptoHash, "hkdf": CryptoHash,
+	"crc": CryptoHash,
+}
+
+// ellipticAlgorithms use key lengths compared with the elliptic curve
+// threshold rather than the one for RSA and finite field keys
+var ellipticAlgorithms = map[string]bool{"ecdh": true, "ecc": true, "ecies": true, "x25519": true, "x448": true, "curve": true, "sm2": true}
+
+// cryptoFamily normalizes an algorithm name, e.g. "AES-256-GCM", "sha_512"
+// or "X25519", to its family, e.g. "aes", "sha" or "x25519": the whole name,
+// its first word, or that word without its digits, whichever is known first.
+// The X25519 and X448 curves are families of their own, so names such as
+// "X.509" aren't taken for them.
+func cryptoFamily(algorithm string) string {
+	notAlnum := func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+	name := strings.ToLower(algorithm)
+	first := ""
+	if fields := strings.FieldsFunc(name, notAlnum); len(fields) > 0 {
+		first = fields[0]
+	}
+	candidates := []string{
+		strings.Join(strings.FieldsFunc(name, notAlnum), ""),
+		first,
+		strings.TrimRightFunc(first, unicode.IsDigit),
+	}
+	if i := strings.IndexFunc(first, unicode.IsDigit); i > 0 {
+		candidates = append(candidates, first[:i])
+	}
+	for _, candidate := range candidates {
+		if _, ok := cryptoFamilies[candidate]; ok {
+			return candidate
+		}
+	}
+	return candidates[0]
+}
+
+// CryptoCategory returns the category of an algorithm, CryptoOther when it
+// isn't known
+func CryptoCategory(algorithm string) string {
+	if category, ok := cryptoFamilies[cryptoFamily(algorithm)]; ok {
+		return category
+	}
+	return CryptoOther
+}
+
+// ECCNRelevance says how an algorithm bears on export control under
+// category 5 part 2 of the Wassenaar Arrangement, as used by ECCN 5A002 and
+// 5D002: encryption for confidentiality above the key length thresholds
+// (56 bits symmetric, 512 bits RSA and finite field, 112 bits elliptic
+// curve) is controlled, authentication and integrity alone aren't. It is a
+// starting point for classification, not a classification.
+func ECCNRelevance(algorithm CryptoAlgorithm) string {
+	bits, err := strconv.Atoi(strings.TrimSpace(algorithm.Strength))
+	known := err == nil && bits > 0
+	switch CryptoCategory(algorithm.Algorithm) {
+	case CryptoSymmetric:
+		if known && bits <= 56 {
+			return "below the 5A002 key length threshold"
+		}
+		return "5A002/5D002 candidate"
+	case CryptoAsymmetric:
+		threshold := 512
+		if ellipticAlgorithms[cryptoFamily(algorithm.Algorithm)] {
+			threshold = 112
+		}
+		if known && bits <= threshold {
+			return "below the 5A002 key length threshold"
+		}
+		return "5A002/5D002 candidate"
+	case CryptoSignature, CryptoHash:
+		return "authentication and integrity only"
+	}
+	return "classify manually"
+}
+
+// CryptoFinding is one algorithm detected in one file
+type CryptoFinding struct {
+	File      string
+	PURL      string
+	Version   string
+	Algorithm string
+	Strength  string
+	Category  string
+	ECCN      string // See ECCNRelevance
+	Status    string // See CSVStatus
+}
+
+// CollectCrypto lists the algorithms detected in the matched files that
+// weren't ignored, by category, algorithm, component and file
+func CollectCrypto(scan *ScanResult) []CryptoFinding {
+	findings := make([]CryptoFinding, 0)
+	for filePath, matches := range scan.Files {
+		match := FirstValidMatch(matches)
+		if match == nil || MatchStatus(match) == StatusIgnored {
+			continue
+		}
+		purl := ""
+		if len(match.Purl) > 0 {
+			purl = match.Purl[0]
+		}
+		for _, algorithm := range match.CryptoAlgorithms() {
+			findings = append(findings, CryptoFinding{
+				File:      filePath,
+				PURL:      purl,
+				Version:   match.Version,
+				Algorithm: algorithm.Algorithm,
+				Strength:  algorithm.Strength,
+				Category:  CryptoCategory(algorithm.Algorithm),
+				ECCN:      ECCNRelevance(algorithm),
+				Status:    CSVStatus(match),
+			})
+		}
+	}
+	order := make(map[string]int, len(cryptoCategories))
+	for i, category := range cryptoCategories {
+		order[category] = i
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Category != b.Category {
+			return order[a.Category] < order[b.Category]
+		}
+		if !strings.EqualFold(a.Algorithm, b.Algorithm) {
+			return strings.ToLower(a.Algorithm) < strings.ToLower(b.Algorithm)
+		}
+		if a.Strength != b.Strength {
+			return a.Strength < b.Strength
+		}
+		if a.PURL != b.PURL {
+			return a.PURL < b.PURL
+		}
+		return a.File < b.File
+	})
+	return findings
+}
+
+// WriteCryptoCSV writes one row per algorithm and file
+func WriteCryptoCSV(w io.Writer, findings []CryptoFinding) error {
+	writer := csv.NewWriter(w)
+	header := []string{"File Path", "PURL", "Version", "Algorithm", "Strength", "Category", "ECCN Relevance", "Status"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %v", err)
+	}
+	for _, f := range findings {
+		record := []string{f.File, f.PURL, f.Version, f.Algorithm, f.Strength, f.Category, f.ECCN, f.Status}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write record: %v", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// cryptoUse aggregates the findings of one algorithm and strength
+type cryptoUse struct {
+	finding    CryptoFinding
+	components []string
+	files      int
+}
+
+// WriteCryptoMarkdown writes one section per category with the algorithms
+// found, their export-control relevance and where they are used
+func WriteCryptoMarkdown(w io.Writer, findings []CryptoFinding) error {
+	fmt.Fprintf(w, "# Cryptography Report\n\n")
+	if len(findings) == 0 {
+		fmt.Fprintf(w, "No cryptographic algorithms detected.\n")
+		return nil
+	}
+	fmt.Fprintf(w, "ECCN relevance follows the key length thresholds of ECCN 5A002/5D002. It is a starting point for an export-control review, not a classification.\n")
+
+	var uses []*cryptoUse
+	byKey := make(map[string]*cryptoUse)
+	for _, f := range findings {
+		key := f.Category + "\x00" + strings.ToLower(f.Algorithm) + "\x00" + f.Strength
+		use := byKey[key]
+		if use == nil {
+			use = &cryptoUse{finding: f}
+			byKey[key] = use
+			uses = append(uses, use)
+		}
+		use.files++
+		component := f.PURL
+		if component != "" && f.Version != "" {
+			component += "@" + f.Version
+		}
+		if component != "" && !slices.Contains(use.components, component) {
+			use.components = append(use.components, component)
+		}
+	}
+
+	category := ""
+	for _, use := range uses {
+		if use.finding.Category != category {
+			category = use.finding.Category
+			fmt.Fprintf(w, "\n## %s\n\n", category)
+			fmt.Fprintf(w, "| Algorithm | Strength | ECCN relevance | Files | Components |\n")
+			fmt.Fprintf(w, "|---|---|---|---|---|\n")
+		}
+		strength := use.finding.Strength
+		if strength == "" {
+			strength = "-"
+		}
+		components := make([]string, 0, len(use.components))
+		for _, component := range use.components {
+			components = append(components, "`"+component+"`")
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %d | %s |\n", use.finding.Algorithm, strength, use.finding.ECCN, use.files, strings.Join(components, ", "))
+	}
+	return nil
+}
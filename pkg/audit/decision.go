@@ -0,0 +1,135 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"strings"
+	"time"
+)
+
+// Decisions recorded in the audit array
+const (
+	DecisionIdentified = "identified"
+	DecisionIgnored    = "ignored"
+	// DecisionDeferred parks a file awaiting input, e.g. from legal. It
+	// isn't final: deferred files still count as open in the progress.
+	DecisionDeferred = "deferred"
+)
+
+// File states derived from the latest decision
+const (
+	StatusIdentified = "identified"
+	StatusIgnored    = "ignored"
+	StatusDeferred   = "deferred"
+	StatusPending    = "pending"
+	StatusNoMatch    = "none"
+)
+
+// IsValidMatch reports whether a match is auditable: id "file" or "snippet",
+// or a single dependency from ExpandDependencies. Manifest entries of a
+// dependency scan carry no PURL of their own and aren't auditable.
+func IsValidMatch(match FileMatch) bool {
+	return match.ID == "file" || match.ID == "snippet" || (match.ID == MatchDependency && len(match.Purl) > 0)
+}
+
+// FirstValidMatch returns the match that audit decisions apply to, or nil
+// when the file has no "file" or "snippet" match
+func FirstValidMatch(matches []FileMatch) *FileMatch {
+	for i := range matches {
+		if IsValidMatch(matches[i]) {
+			return &matches[i]
+		}
+	}
+	return nil
+}
+
+// LatestDecision returns the most recent decision, or nil if none was made
+func (m *FileMatch) LatestDecision() *AuditDecision {
+	if len(m.AuditCmd) == 0 {
+		return nil
+	}
+	return &m.AuditCmd[len(m.AuditCmd)-1]
+}
+
+// IsAudited reports whether any decision has been recorded
+func (m *FileMatch) IsAudited() bool {
+	return len(m.AuditCmd) > 0
+}
+
+// AddDecision appends a timestamped decision to the match
+func (m *FileMatch) AddDecision(decision, assessment string) AuditDecision {
+	entry := AuditDecision{
+		Decision:   decision,
+		Assessment: assessment,
+		Timestamp:  time.Now().UTC(),
+	}
+	m.AuditCmd = append(m.AuditCmd, entry)
+	return entry
+}
+
+// AddNote appends a timestamped note to the match
+func (m *FileMatch) AddNote(note, ticket, url string) AuditNote {
+	entry := AuditNote{
+		Note:      note,
+		Ticket:    ticket,
+		URL:       url,
+		Timestamp: time.Now().UTC(),
+	}
+	m.AuditNotes = append(m.AuditNotes, entry)
+	return entry
+}
+
+// Tickets returns the IDs of all issues recorded for the match
+func (m *FileMatch) Tickets() []string {
+	tickets := make([]string, 0)
+	for _, note := range m.AuditNotes {
+		if note.Ticket != "" {
+			tickets = append(tickets, note.Ticket)
+		}
+	}
+	return tickets
+}
+
+// MatchStatus returns the audit state of a single match
+func MatchStatus(match *FileMatch) string {
+	if match == nil {
+		return StatusNoMatch
+	}
+	latest := match.LatestDecision()
+	if latest == nil {
+		return StatusPending
+	}
+	switch strings.ToLower(strings.TrimSpace(latest.Decision)) {
+	case DecisionIdentified:
+		return StatusIdentified
+	case DecisionIgnored:
+		return StatusIgnored
+	case DecisionDeferred:
+		return StatusDeferred
+	default:
+		return StatusPending
+	}
+}
+
+// NormalizeTimestamps converts the decision and note timestamps to UTC, so
+// files audited from several time zones don't mix local offsets. Rendering
+// them in a zone is left to the reader.
+func (s *ScanResult) NormalizeTimestamps() {
+	for path := range s.Files {
+		for i := range s.Files[path] {
+			match := &s.Files[path][i]
+			for j := range match.AuditCmd {
+				match.AuditCmd[j].Timestamp = match.AuditCmd[j].Timestamp.UTC()
+			}
+			for j := range match.AuditNotes {
+				match.AuditNotes[j].Timestamp = match.AuditNotes[j].Timestamp.UTC()
+			}
+		}
+	}
+}
+
+// FileStatus returns the audit state of a file from its list of matches
+func FileStatus(matches []FileMatch) string {
+	return MatchStatus(FirstValidMatch(matches))
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// LinkProvider is a code host deeplinks can point into, with its URL layout
+// and line anchor syntax
+type LinkProvider struct {
+	// PURLType is the PURL type of the host's repositories, e.g. "github"
+	PURLType string
+	// FileURL is the URL of filePath in the repository at revision
+	FileURL func(owner, repo, revision, filePath string) string
+	// LineAnchor highlights the lines start to end, or the single line
+	// start when end is ""
+	LineAnchor func(start, end string) string
+	// DefaultRevision is used when the revision isn't known. It is ""
+	// when the default branch has to be resolved, as on GitHub.
+	DefaultRevision string
+}
+
+// LinkProviders are the code hosts Deeplinks supports
+var LinkProviders = []LinkProvider{
+	{
+		PURLType: "github",
+		FileURL: func(owner, repo, revision, filePath string) string {
+			return fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s", owner, repo, revision, filePath)
+		},
+		LineAnchor: func(start, end string) string {
+			if end == "" {
+				return "#L" + start
+			}
+			return fmt.Sprintf("#L%s-L%s", start, end)
+		},
+	},
+	{
+		PURLType: "gitlab",
+		FileURL: func(owner, repo, revision, filePath string) string {
+			return fmt.Sprintf("https://gitlab.com/%s/%s/-/blob/%s/%s", owner, repo, revision, filePath)
+		},
+		LineAnchor: func(start, end string) string {
+			if end == "" {
+				return "#L" + start
+			}
+			return fmt.Sprintf("#L%s-%s", start, end)
+		},
+		DefaultRevision: "HEAD",
+	},
+	{
+		PURLType: "bitbucket",
+		FileURL: func(owner, repo, revision, filePath string) string {
+			return fmt.Sprintf("https://bitbucket.org/%s/%s/src/%s/%s", owner, repo, revision, filePath)
+		},
+		LineAnchor: func(start, end string) string {
+			if end == "" {
+				return "#lines-" + start
+			}
+			return fmt.Sprintf("#lines-%s:%s", start, end)
+		},
+		DefaultRevision: "HEAD",
+	},
+}
+
+// LinkProviderFor returns the provider hosting the repository of purl, or
+// nil when purl isn't a repository PURL of a supported host
+func LinkProviderFor(purl string) *LinkProvider {
+	for i, provider := range LinkProviders {
+		if strings.HasPrefix(purl, "pkg:"+provider.PURLType+"/") {
+			return &LinkProviders[i]
+		}
+	}
+	return nil
+}
+
+// Deeplinks creates one deeplink per line range of a match into the
+// repository of its first GitHub, GitLab or Bitbucket PURL, pinned to the
+// revision of the match when it is known
+func Deeplinks(match *FileMatch, lineRanges string, maxRanges int, resolveBranch func(owner, repo string) string) []string {
+	deeplinks := make([]string, maxRanges)
+
+	var purl string
+	var provider *LinkProvider
+	for _, candidate := range match.Purl {
+		if provider = LinkProviderFor(candidate); provider != nil {
+			purl = candidate
+			break
+		}
+	}
+	if provider == nil {
+		return deeplinks // All empty strings
+	}
+
+	// Parse individual ranges and create deeplinks
+	if match.ID == "snippet" && lineRanges != "" && lineRanges != "all" {
+		ranges := strings.Split(lineRanges, ",")
+		for i, rangeStr := range ranges {
+			if i >= maxRanges {
+				break
+			}
+			deeplinks[i] = Deeplink(provider, purl, PinnedRevision(purl), match.File, strings.TrimSpace(rangeStr), resolveBranch)
+		}
+	} else {
+		// Single deeplink for file matches or snippet without ranges
+		deeplinks[0] = Deeplink(provider, purl, PinnedRevision(purl), match.File, "", resolveBranch)
+	}
+
+	return deeplinks
+}
+
+// Deeplink creates a URL of filePath in the repository of purl at revision,
+// highlighting lineRange ("11-14" or "11") when given. Without a revision
+// the provider's default is used, or resolveBranch on GitHub.
+func Deeplink(provider *LinkProvider, purl, revision, filePath, lineRange string, resolveBranch func(owner, repo string) string) string {
+	// pkg:gitlab/group/subgroup/project: the project is the last element
+	name, _, _ := strings.Cut(strings.TrimPrefix(purl, "pkg:"+provider.PURLType+"/"), "#")
+	name, _, _ = strings.Cut(name, "?")
+	name, _, _ = strings.Cut(name, "@")
+	slash := strings.LastIndex(name, "/")
+	if slash <= 0 || slash == len(name)-1 {
+		return ""
+	}
+	owner, repo := name[:slash], name[slash+1:]
+	if revision == "" {
+		revision = provider.DefaultRevision
+	}
+	if revision == "" {
+		revision = resolveBranch(owner, repo)
+	}
+
+	link := provider.FileURL(owner, repo, revision, filePath)
+	if lineRange != "" {
+		start, end, _ := strings.Cut(lineRange, "-")
+		link += provider.LineAnchor(strings.TrimSpace(start), strings.TrimSpace(end))
+	}
+	return link
+}
+
+// PinnedRevision returns the revision a deeplink into the repository of purl
+// can be pinned to, so it needn't guess the default branch: its commit
+// qualifier, the revision of its vcs_url qualifier or, failing both, the PURL
+// version. It is "" when none of them is known. Exact commits come first, as
+// a version needn't name a tag of the repository; the matched version isn't
+// used for the same reason.
+func PinnedRevision(purl string) string {
+	purl, _, _ = strings.Cut(purl, "#")
+	base, query, _ := strings.Cut(purl, "?")
+	if qualifiers, err := url.ParseQuery(query); err == nil {
+		if commit := qualifiers.Get("commit"); commit != "" {
+			return commit
+		}
+		// e.g. git+https://github.com/acme/lib.git@4f2a1c0
+		vcsURL := qualifiers.Get("vcs_url")
+		if at := strings.LastIndex(vcsURL, "@"); at >= 0 && !strings.ContainsAny(vcsURL[at+1:], "/:") {
+			return vcsURL[at+1:]
+		}
+	}
+	if _, version, ok := CutPURLVersion(base); ok && version != "" {
+		if unescaped, err := url.PathUnescape(version); err == nil {
+			return unescaped
+		}
+		return version
+	}
+	return ""
+}
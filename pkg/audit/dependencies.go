@@ -0,0 +1,162 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// MatchDependency is the id scanoss-py uses for manifest entries in its
+// dependency scan output (dependencies.json)
+const MatchDependency = "dependency"
+
+// Dependency is one declared dependency of a manifest
+type Dependency struct {
+	Component string          `json:"component"`
+	Purl      string          `json:"purl"`
+	Version   string          `json:"version"`
+	Licenses  []License       `json:"licenses"`
+	URL       string          `json:"url,omitempty"`
+	Scope     string          `json:"scope,omitempty"`
+	AuditCmd  []AuditDecision `json:"audit,omitempty"`
+
+	// raw is the dependency as read, so saving keeps the fields and license
+	// details not modelled here
+	raw map[string]json.RawMessage
+}
+
+// UnmarshalJSON reads a dependency and keeps its original fields
+func (d *Dependency) UnmarshalJSON(data []byte) error {
+	type fields Dependency
+	if err := json.Unmarshal(data, (*fields)(d)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &d.raw)
+}
+
+// MarshalJSON writes a dependency as it was read, with its audit decisions
+// as the only change
+func (d Dependency) MarshalJSON() ([]byte, error) {
+	type fields Dependency
+	if d.raw == nil {
+		return json.Marshal(fields(d))
+	}
+	out := make(map[string]json.RawMessage, len(d.raw)+1)
+	for key, value := range d.raw {
+		out[key] = value
+	}
+	delete(out, "audit")
+	if len(d.AuditCmd) > 0 {
+		decisions, err := json.Marshal(d.AuditCmd)
+		if err != nil {
+			return nil, err
+		}
+		out["audit"] = decisions
+	}
+	return json.Marshal(out)
+}
+
+// IsDependencyDocument reports whether every entry of a results document is
+// a dependency manifest, i.e. it is a dependencies.json rather than a scan
+func IsDependencyDocument(doc *ScanResult) bool {
+	found := false
+	for _, matches := range doc.Files {
+		for _, match := range matches {
+			if match.ID != MatchDependency {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// dependencyRef locates a dependency inside the original document
+type dependencyRef struct {
+	manifest   string
+	matchIndex int
+	depIndex   int
+}
+
+// DependencyView presents a dependencies.json as a scan result with one
+// entry per dependency, at "<manifest>/<purl>@<version>", so the tree,
+// decisions and exports work unchanged. Apply copies decisions back into the
+// original document before it is saved.
+type DependencyView struct {
+	Source *ScanResult
+	refs   map[string]dependencyRef
+}
+
+// dependencyName turns a PURL into a single path element
+func dependencyName(dep Dependency) string {
+	name := dep.Purl
+	if name == "" {
+		name = dep.Component
+	}
+	name = strings.ReplaceAll(strings.TrimPrefix(name, "pkg:"), "/", "%2F")
+	if dep.Version != "" && !strings.Contains(name, "@") {
+		name += "@" + dep.Version
+	}
+	return name
+}
+
+// ExpandDependencies builds the per-dependency view of a dependencies.json
+func ExpandDependencies(doc *ScanResult) (*ScanResult, *DependencyView) {
+	view := &DependencyView{Source: doc, refs: make(map[string]dependencyRef)}
+	expanded := &ScanResult{Files: make(map[string][]FileMatch)}
+
+	manifests := make([]string, 0, len(doc.Files))
+	for manifest := range doc.Files {
+		manifests = append(manifests, manifest)
+	}
+	sort.Strings(manifests)
+
+	for _, manifest := range manifests {
+		for mi, match := range doc.Files[manifest] {
+			for di, dep := range match.Dependencies {
+				path := NormalizePath(manifest) + "/" + dependencyName(dep)
+				if _, taken := expanded.Files[path]; taken {
+					continue // the same dependency declared twice in one manifest
+				}
+				entry := FileMatch{
+					ID:        MatchDependency,
+					Component: dep.Component,
+					Purl:      []string{dep.Purl},
+					Version:   dep.Version,
+					Licenses:  dep.Licenses,
+					URL:       dep.URL,
+					File:      manifest,
+					Lines:     "all",
+					AuditCmd:  append([]AuditDecision(nil), dep.AuditCmd...),
+				}
+				expanded.Files[path] = []FileMatch{entry}
+				view.refs[path] = dependencyRef{manifest: manifest, matchIndex: mi, depIndex: di}
+			}
+		}
+	}
+	return expanded, view
+}
+
+// Dependency returns the original dependency behind an expanded path
+func (d *DependencyView) Dependency(path string) *Dependency {
+	ref, ok := d.refs[path]
+	if !ok {
+		return nil
+	}
+	return &d.Source.Files[ref.manifest][ref.matchIndex].Dependencies[ref.depIndex]
+}
+
+// Apply copies the decisions made on the expanded view back into Source
+func (d *DependencyView) Apply(expanded *ScanResult) {
+	for path, ref := range d.refs {
+		matches := expanded.Files[path]
+		if len(matches) == 0 {
+			continue
+		}
+		dep := &d.Source.Files[ref.manifest][ref.matchIndex].Dependencies[ref.depIndex]
+		dep.AuditCmd = append([]AuditDecision(nil), matches[0].AuditCmd...)
+	}
+}
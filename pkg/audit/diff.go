@@ -0,0 +1,83 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import "sort"
+
+// Classification of a finding when comparing two scans
+const (
+	DeltaNew       = "new"
+	DeltaRemoved   = "removed"
+	DeltaUnchanged = "unchanged"
+)
+
+// Delta is a finding (a file matched to a component) and how it changed
+// between two scans. Path is the key used in the scan it was found in.
+type Delta struct {
+	Path string
+	PURL string
+	Kind string
+}
+
+// findingKey identifies a finding independently of path separators
+type findingKey struct {
+	path string
+	purl string
+}
+
+func findings(scan *ScanResult) map[findingKey]string {
+	result := make(map[findingKey]string)
+	for filePath, matches := range scan.Files {
+		match := FirstValidMatch(matches)
+		if match == nil {
+			continue
+		}
+		purl := ""
+		if len(match.Purl) > 0 {
+			purl = match.Purl[0]
+		}
+		result[findingKey{NormalizePath(filePath), purl}] = filePath
+	}
+	return result
+}
+
+// Diff compares the findings of two scans. A file whose match moved to a
+// different component is reported as one removed and one new finding.
+// Deltas are sorted by path, then PURL.
+func Diff(oldScan, newScan *ScanResult) []Delta {
+	oldFindings := findings(oldScan)
+	newFindings := findings(newScan)
+
+	deltas := make([]Delta, 0, len(newFindings))
+	for key, filePath := range newFindings {
+		kind := DeltaNew
+		if _, ok := oldFindings[key]; ok {
+			kind = DeltaUnchanged
+		}
+		deltas = append(deltas, Delta{Path: filePath, PURL: key.purl, Kind: kind})
+	}
+	for key, filePath := range oldFindings {
+		if _, ok := newFindings[key]; !ok {
+			deltas = append(deltas, Delta{Path: filePath, PURL: key.purl, Kind: DeltaRemoved})
+		}
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		pi, pj := NormalizePath(deltas[i].Path), NormalizePath(deltas[j].Path)
+		if pi != pj {
+			return pi < pj
+		}
+		return deltas[i].PURL < deltas[j].PURL
+	})
+	return deltas
+}
+
+// CountDeltas returns how many deltas there are of each kind
+func CountDeltas(deltas []Delta) map[string]int {
+	counts := map[string]int{DeltaNew: 0, DeltaRemoved: 0, DeltaUnchanged: 0}
+	for _, delta := range deltas {
+		counts[delta.Kind]++
+	}
+	return counts
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// globExpression translates an exclude glob into a regular expression body:
+// "**" crosses directories, "*" and "?" stay within one path element
+func globExpression(glob string) string {
+	var expression strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			expression.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			expression.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			expression.WriteString("[^/]*")
+		case glob[i] == '?':
+			expression.WriteString("[^/]")
+		default:
+			expression.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	return expression.String()
+}
+
+// CompileExcludes turns glob patterns such as "node_modules/**" or "*.min.js"
+// into matchers for IsExcluded. A pattern matches at any directory level
+// unless it starts with "/", and a matched directory excludes everything
+// below it. Empty patterns are skipped.
+func CompileExcludes(globs []string) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, 0, len(globs))
+	for _, glob := range globs {
+		glob = strings.TrimSpace(NormalizePath(glob))
+		if glob == "" {
+			continue
+		}
+		prefix := "(?:^|/)"
+		if strings.HasPrefix(glob, "/") {
+			prefix = "^"
+			glob = strings.TrimPrefix(glob, "/")
+		}
+		glob = strings.TrimSuffix(glob, "/")
+		patterns = append(patterns, regexp.MustCompile(prefix+globExpression(glob)+"(?:/.*)?$"))
+	}
+	return patterns
+}
+
+// IsExcluded reports whether a scanned path matches any exclude pattern
+func IsExcluded(path string, patterns []*regexp.Regexp) bool {
+	slashPath := NormalizePath(path)
+	for _, pattern := range patterns {
+		if pattern.MatchString(slashPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// Exclude removes the files matching patterns from the results and returns
+// them, so they can be put back with WithFiles before saving
+func (s *ScanResult) Exclude(patterns []*regexp.Regexp) map[string][]FileMatch {
+	excluded := make(map[string][]FileMatch)
+	if len(patterns) == 0 {
+		return excluded
+	}
+	for path, matches := range s.Files {
+		if IsExcluded(path, patterns) {
+			excluded[path] = matches
+			delete(s.Files, path)
+		}
+	}
+	return excluded
+}
+
+// KeepDirectory removes the files outside dir and its subdirectories from
+// the results and returns them, like Exclude
+func (s *ScanResult) KeepDirectory(dir string) map[string][]FileMatch {
+	outside := make(map[string][]FileMatch)
+	if dir == "" {
+		return outside
+	}
+	for path, matches := range s.Files {
+		if !InDirectory(path, dir) {
+			outside[path] = matches
+			delete(s.Files, path)
+		}
+	}
+	return outside
+}
+
+// CleanDirectory normalizes a directory given on the command line, e.g.
+// "./src/lib/", to the form tree paths use: "src/lib"
+func CleanDirectory(dir string) string {
+	dir = strings.Trim(NormalizePath(dir), "/")
+	for strings.HasPrefix(dir, "./") {
+		dir = strings.TrimPrefix(dir, "./")
+	}
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// WithFiles returns the results with more files added, such as the ones
+// Exclude removed. The receiver is left unchanged.
+func (s *ScanResult) WithFiles(files map[string][]FileMatch) *ScanResult {
+	if len(files) == 0 {
+		return s
+	}
+	combined := &ScanResult{Files: make(map[string][]FileMatch, len(s.Files)+len(files))}
+	for path, matches := range files {
+		combined.Files[path] = matches
+	}
+	for path, matches := range s.Files {
+		combined.Files[path] = matches
+	}
+	return combined
+}
+
+// MergeDuplicatePaths merges the files whose paths only differ in their
+// separators or a leading "./", as mixed-OS scans produce, so each file is
+// listed once. The entry with the latest decision is kept, or else the one
+// whose path is already normalized. It returns the paths removed, for
+// WithDuplicates.
+func (s *ScanResult) MergeDuplicatePaths() map[string]DuplicatePath {
+	groups := make(map[string][]string)
+	for path := range s.Files {
+		normalized := NormalizePath(path)
+		groups[normalized] = append(groups[normalized], path)
+	}
+
+	merged := make(map[string]DuplicatePath)
+	for normalized, paths := range groups {
+		if len(paths) < 2 {
+			continue
+		}
+		sort.Strings(paths)
+		kept := paths[0]
+		for _, path := range paths[1:] {
+			if decidedLater(s.Files[path], s.Files[kept]) || (!decidedLater(s.Files[kept], s.Files[path]) && path == normalized) {
+				kept = path
+			}
+		}
+		for _, path := range paths {
+			if path != kept {
+				merged[path] = DuplicatePath{Into: kept, Matches: s.Files[path]}
+				delete(s.Files, path)
+			}
+		}
+	}
+	return merged
+}
+
+// decidedLater reports whether the latest decision of a is more recent than
+// that of b
+func decidedLater(a, b []FileMatch) bool {
+	var latestA, latestB time.Time
+	if match := FirstValidMatch(a); match != nil && match.LatestDecision() != nil {
+		latestA = match.LatestDecision().Timestamp
+	}
+	if match := FirstValidMatch(b); match != nil && match.LatestDecision() != nil {
+		latestB = match.LatestDecision().Timestamp
+	}
+	return latestA.After(latestB)
+}
+
+// DuplicatePath is a file MergeDuplicatePaths removed: the path it was
+// merged into, and its own matches
+type DuplicatePath struct {
+	Into    string
+	Matches []FileMatch
+}
+
+// WithDuplicates returns the results with the paths MergeDuplicatePaths
+// removed put back with their own matches. Only the decisions of the entry
+// each was merged into are copied to it, so both keep the same decisions.
+// The receiver is left unchanged.
+func (s *ScanResult) WithDuplicates(merged map[string]DuplicatePath) *ScanResult {
+	duplicates := make(map[string][]FileMatch, len(merged))
+	for path, duplicate := range merged {
+		matches := slices.Clone(duplicate.Matches)
+		if kept, own := FirstValidMatch(s.Files[duplicate.Into]), FirstValidMatch(matches); kept != nil && own != nil {
+			own.AuditCmd = slices.Clone(kept.AuditCmd)
+		}
+		duplicates[path] = matches
+	}
+	return s.WithFiles(duplicates)
+}
@@ -0,0 +1,432 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CSVOptions customises ExportCSV. Every field is optional.
+type CSVOptions struct {
+	// Context stops the export before the next file once it is cancelled,
+	// and ExportCSV returns its error. Defaults to context.Background().
+	Context context.Context
+	// Progress is called before each file is written
+	Progress func(processed, total int)
+	// ResolveBranch returns the branch used for GitHub deeplinks that can't
+	// be pinned to a revision, see PinnedRevision. Defaults to DefaultBranch.
+	ResolveBranch func(owner, repo string) string
+	// MapPath, MapPURL and MapURL rewrite values before they are written,
+	// e.g. to redact them
+	MapPath func(string) string
+	MapPURL func(string) string
+	MapURL  func(string) string
+	// SkipDeeplinks leaves the deeplink columns empty
+	SkipDeeplinks bool
+	// ProjectLicense adds a final "License Conflict" column explaining why a
+	// match can't be used under this license
+	ProjectLicense string
+	// Stale adds a final "Stale Component" column saying why the matched
+	// component looks abandoned, see StaleReasons
+	Stale StaleThresholds
+	// Provenance adds a final "Contributor Countries" column with the
+	// summaries of Provenance.Countries, keyed by ProvenancePURL
+	Provenance map[string]string
+	// FormatTime renders the time of the latest decision. Defaults to
+	// RFC 3339 in UTC.
+	FormatTime func(time.Time) string
+	// Durations adds a final "Seconds Open" column with how long the file
+	// was open before its latest decision, where that was recorded
+	Durations bool
+	// StatusLabels replaces the Status column labels, keyed by status
+	// (StatusIdentified, StatusIgnored, StatusDeferred, StatusPending)
+	StatusLabels map[string]string
+	// Checklist adds a final "Checklist" column with the review checklist
+	// of the latest decision, see ChecklistSummary
+	Checklist bool
+	// History adds a final "History" column with every decision of the
+	// match as a JSON array, as stored in the results
+	History bool
+	// ComponentPage adds a final "Component Page" column linking to the
+	// page of the matched component, see ComponentPageURL
+	ComponentPage string
+}
+
+// statusLabel is the Status column of a match, see StatusLabels
+func (opts CSVOptions) statusLabel(match *FileMatch) string {
+	status := MatchStatus(match)
+	if status == StatusNoMatch {
+		status = StatusPending
+	}
+	if label := opts.StatusLabels[status]; label != "" {
+		return label
+	}
+	return CSVStatus(match)
+}
+
+func identity(s string) string { return s }
+
+func utcRFC3339(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+
+// ExportCSV writes one row per file in the results with its match details,
+// audit status and deeplinks into the matched repository
+func ExportCSV(w io.Writer, scan *ScanResult, opts CSVOptions) error {
+	if opts.ResolveBranch == nil {
+		opts.ResolveBranch = DefaultBranch
+	}
+	if opts.MapPath == nil {
+		opts.MapPath = identity
+	}
+	if opts.MapPURL == nil {
+		opts.MapPURL = identity
+	}
+	if opts.MapURL == nil {
+		opts.MapURL = identity
+	}
+	if opts.FormatTime == nil {
+		opts.FormatTime = utcRFC3339
+	}
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
+
+	writer := csv.NewWriter(w)
+
+	// First, determine max number of line ranges across all data
+	maxRanges := MaxLineRanges(scan)
+
+	header := CSVHeader(maxRanges)
+	if opts.ProjectLicense != "" {
+		header = append(header, "License Conflict")
+	}
+	if opts.Stale.Enabled() {
+		header = append(header, "Stale Component")
+	}
+	if opts.Provenance != nil {
+		header = append(header, "Contributor Countries")
+	}
+	if opts.Durations {
+		header = append(header, "Seconds Open")
+	}
+	if opts.Checklist {
+		header = append(header, "Checklist")
+	}
+	if opts.History {
+		header = append(header, "History")
+	}
+	if opts.ComponentPage != "" {
+		header = append(header, "Component Page")
+	}
+	// Added last, so the columns of earlier exports keep their place
+	header = append(header, "Decided", "Auditor")
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %v", err)
+	}
+
+	paths := make([]string, 0, len(scan.Files))
+	for filePath := range scan.Files {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+
+	for i, filePath := range paths {
+		if err := opts.Context.Err(); err != nil {
+			return err
+		}
+		if opts.Progress != nil {
+			opts.Progress(i+1, len(paths))
+		}
+
+		match := FirstValidMatch(scan.Files[filePath])
+		if match == nil {
+			// No valid match - fill matched lines, OSS lines, matched URL, file, version, and deeplink columns with empty strings
+			record := []string{opts.MapPath(filePath), "no-match", "", "", opts.statusLabel(nil), "", "", "", "", "", ""}
+			for i := 0; i < maxRanges; i++ {
+				record = append(record, "")
+			}
+			if opts.ProjectLicense != "" {
+				record = append(record, "")
+			}
+			if opts.Stale.Enabled() {
+				record = append(record, "")
+			}
+			if opts.Provenance != nil {
+				record = append(record, "")
+			}
+			if opts.Durations {
+				record = append(record, "")
+			}
+			if opts.Checklist {
+				record = append(record, "")
+			}
+			if opts.History {
+				record = append(record, "")
+			}
+			if opts.ComponentPage != "" {
+				record = append(record, "")
+			}
+			record = append(record, "", "")
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write record: %v", err)
+			}
+			continue
+		}
+
+		// Extract license information
+		licenses := make([]string, 0)
+		for _, license := range match.Licenses {
+			licenses = append(licenses, license.Name)
+		}
+		licenseStr := strings.Join(licenses, "; ")
+
+		// Extract PURL information
+		purls := make([]string, 0, len(match.Purl))
+		for _, purl := range match.Purl {
+			purls = append(purls, opts.MapPURL(purl))
+		}
+		purlStr := strings.Join(purls, "; ")
+
+		// Determine status, comment, and when and by whom the decision was made
+		status := opts.statusLabel(match)
+		comment, decided, auditor := "", "", ""
+		if latest := match.LatestDecision(); latest != nil {
+			comment = latest.Assessment
+			if !latest.Timestamp.IsZero() {
+				decided = opts.FormatTime(latest.Timestamp)
+			}
+			auditor = DecisionAuditor(latest)
+		}
+
+		// Extract matched lines (in analyzed file) and OSS line ranges (in matched OSS file)
+		matchedLines := ExtractMatchedLines(match)
+		ossLineRanges := ExtractLineRanges(match)
+		deeplinks := make([]string, maxRanges)
+		if !opts.SkipDeeplinks {
+			deeplinks = Deeplinks(match, ossLineRanges, maxRanges, opts.ResolveBranch)
+		}
+
+		// Build record with dynamic deeplink columns
+		record := []string{opts.MapPath(filePath), match.ID, purlStr, licenseStr, status, comment, matchedLines, ossLineRanges, opts.MapURL(match.URL), opts.MapPath(match.File), match.Latest}
+		record = append(record, deeplinks...)
+		if opts.ProjectLicense != "" {
+			record = append(record, strings.Join(LicenseConflicts(opts.ProjectLicense, match), "; "))
+		}
+		if opts.Stale.Enabled() {
+			record = append(record, strings.Join(StaleReasons(match, opts.Stale, time.Now()), "; "))
+		}
+		if opts.Provenance != nil {
+			countries := ""
+			if len(match.Purl) > 0 {
+				countries = opts.Provenance[ProvenancePURL(match.Purl[0])]
+			}
+			record = append(record, countries)
+		}
+		if opts.Durations {
+			seconds := ""
+			if latest := match.LatestDecision(); latest != nil && latest.Duration > 0 {
+				seconds = strconv.Itoa(latest.Duration)
+			}
+			record = append(record, seconds)
+		}
+		if opts.Checklist {
+			checklist := ""
+			if latest := match.LatestDecision(); latest != nil {
+				checklist = ChecklistSummary(latest.Checklist)
+			}
+			record = append(record, checklist)
+		}
+		if opts.History {
+			history := ""
+			if len(match.AuditCmd) > 0 {
+				data, err := json.Marshal(match.AuditCmd)
+				if err != nil {
+					return fmt.Errorf("failed to encode the history of %s: %v", filePath, err)
+				}
+				history = string(data)
+			}
+			record = append(record, history)
+		}
+		if opts.ComponentPage != "" {
+			page := ""
+			if len(match.Purl) > 0 {
+				page = opts.MapURL(ComponentPageURL(opts.ComponentPage, match.Purl[0]))
+			}
+			record = append(record, page)
+		}
+		record = append(record, decided, auditor)
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write record: %v", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// CSVHeader returns the export header with one deeplink column per line range
+func CSVHeader(maxRanges int) []string {
+	header := []string{"File Path", "Match Type", "PURL", "License", "Status", "Comment", "Matched Lines", "OSS Lines", "Matched URL", "Matched File", "Matched Version"}
+	if maxRanges > 1 {
+		for i := 1; i <= maxRanges; i++ {
+			header = append(header, fmt.Sprintf("Deeplink %d", i))
+		}
+	} else {
+		header = append(header, "Deeplink")
+	}
+	return header
+}
+
+// ChecklistSummary lists the review checklist of a decision with the ticked
+// items marked, e.g. "[x] license verified; [ ] attribution present"
+func ChecklistSummary(items []ChecklistItem) string {
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		mark := "[ ]"
+		if item.Checked {
+			mark = "[x]"
+		}
+		parts = append(parts, mark+" "+item.Item)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// DecisionAuditor is who made a decision: the auditor, or the reviewer for
+// second opinions recorded before auditors were, or the always-ignore list
+func DecisionAuditor(decision *AuditDecision) string {
+	switch {
+	case decision.Auditor != "":
+		return decision.Auditor
+	case decision.Reviewer != "":
+		return decision.Reviewer
+	case decision.Source == SourceIgnoreList:
+		return "always-ignore list"
+	}
+	return ""
+}
+
+// CSVStatus returns the status label used in exports
+func CSVStatus(match *FileMatch) string {
+	switch MatchStatus(match) {
+	case StatusIdentified:
+		return "Accepted"
+	case StatusIgnored:
+		return "Ignored"
+	case StatusDeferred:
+		return "Deferred"
+	default:
+		return "Pending"
+	}
+}
+
+// ExtractLineRanges extracts line ranges from oss_lines field
+func ExtractLineRanges(match *FileMatch) string {
+	return lineField(match.OSSLines)
+}
+
+// ExtractMatchedLines extracts line ranges from lines field (matched lines in analyzed file)
+func ExtractMatchedLines(match *FileMatch) string {
+	return lineField(match.Lines)
+}
+
+func lineField(value interface{}) string {
+	if v, ok := value.(string); ok {
+		return v
+	}
+	return ""
+}
+
+// MaxLineRanges determines the maximum number of line ranges in any snippet match
+func MaxLineRanges(scan *ScanResult) int {
+	maxRanges := 1 // At least one deeplink column
+
+	for _, matches := range scan.Files {
+		for i := range matches {
+			if matches[i].ID != "snippet" {
+				continue
+			}
+			lineRanges := ExtractLineRanges(&matches[i])
+			if lineRanges != "" && lineRanges != "all" {
+				ranges := strings.Split(lineRanges, ",")
+				if len(ranges) > maxRanges {
+					maxRanges = len(ranges)
+				}
+			}
+		}
+	}
+
+	return maxRanges
+}
+
+// gitHubRepoInfo represents the GitHub API response for repository info
+type gitHubRepoInfo struct {
+	DefaultBranch string `json:"default_branch"`
+}
+
+// Cache for default branches to avoid repeated API calls
+var (
+	defaultBranchCache = make(map[string]string)
+	defaultBranchMu    sync.Mutex
+)
+
+// DefaultBranch fetches the default branch name for a GitHub repository,
+// falling back to "master" (GitHub redirects to main if needed)
+func DefaultBranch(owner, repo string) string {
+	repoKey := fmt.Sprintf("%s/%s", owner, repo)
+
+	defaultBranchMu.Lock()
+	branch, exists := defaultBranchCache[repoKey]
+	defaultBranchMu.Unlock()
+	if exists {
+		return branch
+	}
+
+	branch = fetchDefaultBranch(owner, repo)
+
+	defaultBranchMu.Lock()
+	defaultBranchCache[repoKey] = branch
+	defaultBranchMu.Unlock()
+	return branch
+}
+
+// IsDefaultBranchCached reports whether DefaultBranch can answer without a request
+func IsDefaultBranchCached(owner, repo string) bool {
+	defaultBranchMu.Lock()
+	defer defaultBranchMu.Unlock()
+	_, exists := defaultBranchCache[owner+"/"+repo]
+	return exists
+}
+
+func fetchDefaultBranch(owner, repo string) string {
+	// Try to get default branch from GitHub API with short timeout
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
+
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "master"
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		// Fallback for private repos or API limits
+		return "master"
+	}
+
+	var repoInfo gitHubRepoInfo
+	if err := json.NewDecoder(resp.Body).Decode(&repoInfo); err != nil || repoInfo.DefaultBranch == "" {
+		return "master"
+	}
+
+	return repoInfo.DefaultBranch
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Heuristics used by FalsePositiveSignals
+const (
+	// MinSnippetLines is the smallest snippet that isn't considered tiny
+	MinSnippetLines = 10
+	// FalsePositiveMinSignals is how many signals flag a likely false positive
+	FalsePositiveMinSignals = 2
+	// DefaultQualityThreshold is the quality score percentage below which a
+	// component is considered low quality, unless another one is configured
+	DefaultQualityThreshold = 40
+)
+
+// genericFileNames match files that exist in countless unrelated projects,
+// compared without their extension
+var genericFileNames = map[string]bool{
+	"__init__": true, "app": true, "common": true, "config": true, "constants": true,
+	"helpers": true, "index": true, "main": true, "makefile": true, "setup": true,
+	"test": true, "types": true, "util": true, "utils": true, "version": true,
+}
+
+// SnippetLineCount returns how many local lines a snippet match covers, or
+// -1 for matches that aren't snippets or have no usable line ranges
+func SnippetLineCount(match *FileMatch) int {
+	if match.ID != "snippet" {
+		return -1
+	}
+	count := 0
+	for _, part := range splitRanges(ExtractMatchedLines(match)) {
+		startText, endText, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(startText))
+		if err != nil {
+			continue
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(strings.TrimSpace(endText)); err != nil || end < start {
+				continue
+			}
+		}
+		count += end - start + 1
+	}
+	if count == 0 {
+		return -1
+	}
+	return count
+}
+
+// parseQualityScore reads a quality score such as "2/5" as a fraction
+func parseQualityScore(score string) (float64, bool) {
+	scoreText, maxText, found := strings.Cut(score, "/")
+	if !found {
+		return 0, false
+	}
+	value, err1 := strconv.ParseFloat(strings.TrimSpace(scoreText), 64)
+	maximum, err2 := strconv.ParseFloat(strings.TrimSpace(maxText), 64)
+	if err1 != nil || err2 != nil || maximum <= 0 {
+		return 0, false
+	}
+	return value / maximum, true
+}
+
+// BestQuality returns the best quality score of a match as reported, e.g.
+// "2/5", and as a percentage, or "" and -1 when none is reported
+func BestQuality(match *FileMatch) (string, int) {
+	if match == nil {
+		return "", -1
+	}
+	best, percent := "", -1
+	for _, quality := range match.Quality {
+		if ratio, ok := parseQualityScore(quality.Score); ok && int(math.Round(100*ratio)) > percent {
+			best, percent = strings.TrimSpace(quality.Score), int(math.Round(100*ratio))
+		}
+	}
+	return best, percent
+}
+
+// FalsePositiveSignals returns why a match looks like a false positive: a
+// tiny snippet, a generic file name, a path similarity below
+// similarityThreshold or a quality score below qualityThreshold, both
+// percentages. Matches with at least FalsePositiveMinSignals signals are
+// likely false positives.
+func FalsePositiveSignals(filePath string, match *FileMatch, similarityThreshold, qualityThreshold int) []string {
+	signals := make([]string, 0)
+	if match == nil || match.ID == MatchDependency {
+		return signals
+	}
+
+	if lines := SnippetLineCount(match); lines >= 0 && lines < MinSnippetLines {
+		signals = append(signals, fmt.Sprintf("tiny snippet (%d lines)", lines))
+	}
+
+	name := strings.ToLower(path.Base(NormalizePath(filePath)))
+	if genericFileNames[strings.TrimSuffix(name, path.Ext(name))] {
+		signals = append(signals, "generic file name "+name)
+	}
+
+	if match.File != "" {
+		if similarity := PathSimilarity(filePath, match.File); similarity < similarityThreshold {
+			signals = append(signals, fmt.Sprintf("path similarity %d%%", similarity))
+		}
+	}
+
+	if _, percent := BestQuality(match); percent >= 0 && percent < qualityThreshold {
+		signals = append(signals, fmt.Sprintf("low quality score (%d%%)", percent))
+	}
+	return signals
+}
+
+// LikelyFalsePositive reports whether a pending match has enough false
+// positive signals to be flagged, and returns the signals
+func LikelyFalsePositive(filePath string, matches []FileMatch, similarityThreshold, qualityThreshold int) (bool, []string) {
+	match := FirstValidMatch(matches)
+	if match == nil || MatchStatus(match) != StatusPending {
+		return false, nil
+	}
+	signals := FalsePositiveSignals(filePath, match, similarityThreshold, qualityThreshold)
+	return len(signals) >= FalsePositiveMinSignals, signals
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// View filters understood by FilesInDirectory and CountFilesInDirectory
+const (
+	FilterAll      = "all"
+	FilterMatched  = "matched"
+	FilterPending  = "pending"
+	FilterDeferred = "deferred"
+)
+
+// NormalizePath converts a scan result path to forward slashes and drops a
+// leading "./" so results produced on Windows (e.g. "src\\main.c") or by
+// scanners run on "." build the same tree as Unix ones. The original key is
+// still used to look up matches in the scan data.
+func NormalizePath(path string) string {
+	path = strings.ReplaceAll(path, "\\", "/")
+	for strings.HasPrefix(path, "./") {
+		path = strings.TrimPrefix(path, "./")
+	}
+	return path
+}
+
+// InDirectory reports whether filePath lives in dirPath or one of its
+// subdirectories. The empty dirPath only contains files without a directory.
+func InDirectory(filePath, dirPath string) bool {
+	slashPath := NormalizePath(filePath)
+	if dirPath == "" {
+		return !strings.Contains(slashPath, "/")
+	}
+	return strings.HasPrefix(slashPath, dirPath+"/")
+}
+
+// MatchesFilter reports whether a file should be listed under the given view filter
+func MatchesFilter(matches []FileMatch, filter string) bool {
+	match := FirstValidMatch(matches)
+	switch filter {
+	case FilterAll, "":
+		return true
+	case FilterPending:
+		return match != nil && !match.IsAudited()
+	case FilterDeferred:
+		return MatchStatus(match) == StatusDeferred
+	default:
+		return match != nil
+	}
+}
+
+// MatchesAssessment reports whether the latest decision's assessment, or a
+// note or ticket recorded for the file, matches pattern
+func MatchesAssessment(matches []FileMatch, pattern *regexp.Regexp) bool {
+	match := FirstValidMatch(matches)
+	if match == nil {
+		return false
+	}
+	if latest := match.LatestDecision(); latest != nil && pattern.MatchString(latest.Assessment) {
+		return true
+	}
+	for _, note := range match.AuditNotes {
+		if pattern.MatchString(note.Note) || pattern.MatchString(note.Ticket) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilesInDirectory returns the sorted files in dirPath that pass the filter
+func FilesInDirectory(scan *ScanResult, dirPath, filter string) []string {
+	files := make([]string, 0)
+	for filePath, matches := range scan.Files {
+		if InDirectory(filePath, dirPath) && MatchesFilter(matches, filter) {
+			files = append(files, filePath)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// CountFilesInDirectory counts the files in dirPath that pass the filter
+func CountFilesInDirectory(scan *ScanResult, dirPath, filter string) int {
+	count := 0
+	for filePath, matches := range scan.Files {
+		if InDirectory(filePath, dirPath) && MatchesFilter(matches, filter) {
+			count++
+		}
+	}
+	return count
+}
+
+// CountFiles counts how many of the given files pass the filter
+func CountFiles(scan *ScanResult, files []string, filter string) int {
+	count := 0
+	for _, filePath := range files {
+		matches, exists := scan.Files[filePath]
+		if !exists || FirstValidMatch(matches) == nil {
+			continue
+		}
+		if MatchesFilter(matches, filter) {
+			count++
+		}
+	}
+	return count
+}
+
+// MatchedPaths returns the sorted paths of all files with a valid match
+func MatchedPaths(scan *ScanResult) []string {
+	paths := make([]string, 0)
+	for filePath, matches := range scan.Files {
+		if FirstValidMatch(matches) != nil {
+			paths = append(paths, filePath)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// BuildPURLRanking groups files by the first PURL of their match, without
+// version, most files first. Within a component the files are grouped again
+// by version, taken from the PURL or else from the match.
+func BuildPURLRanking(scan *ScanResult) []PURLRankEntry {
+	purlMap := make(map[string]map[string][]string)
+
+	for filePath, matches := range scan.Files {
+		match := FirstValidMatch(matches)
+		if match == nil || len(match.Purl) == 0 {
+			continue
+		}
+		purl, version, versioned := CutPURLVersion(match.Purl[0])
+		if !versioned {
+			version = match.Version
+		}
+		if purlMap[purl] == nil {
+			purlMap[purl] = make(map[string][]string)
+		}
+		purlMap[purl][version] = append(purlMap[purl][version], filePath)
+	}
+
+	ranking := make([]PURLRankEntry, 0, len(purlMap))
+	for purl, versionMap := range purlMap {
+		entry := PURLRankEntry{PURL: purl}
+		for version, files := range versionMap {
+			sort.Strings(files)
+			entry.Versions = append(entry.Versions, PURLVersion{Version: version, Files: files})
+			entry.Files = append(entry.Files, files...)
+		}
+		sort.Strings(entry.Files)
+		sort.Slice(entry.Versions, func(i, j int) bool {
+			if len(entry.Versions[i].Files) != len(entry.Versions[j].Files) {
+				return len(entry.Versions[i].Files) > len(entry.Versions[j].Files)
+			}
+			return entry.Versions[i].Version < entry.Versions[j].Version
+		})
+		entry.Count = len(entry.Files)
+		entry.Stats = ComponentStats(scan, entry.Files)
+		ranking = append(ranking, entry)
+	}
+
+	// Sort by count descending, then by PURL name ascending
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].Count != ranking[j].Count {
+			return ranking[i].Count > ranking[j].Count
+		}
+		return ranking[i].PURL < ranking[j].PURL
+	})
+
+	return ranking
+}
+
+// ComponentStats returns the size of the component matched by files, as
+// reported in the url_stats of the first match that has them
+func ComponentStats(scan *ScanResult, files []string) URLStats {
+	for _, filePath := range files {
+		if match := FirstValidMatch(scan.Files[filePath]); match != nil && match.URLStats != (URLStats{}) {
+			return match.URLStats
+		}
+	}
+	return URLStats{}
+}
+
+// SortPURLRankingBySize orders a ranking by package size, largest first,
+// then by file count. Components without url_stats come last.
+func SortPURLRankingBySize(ranking []PURLRankEntry) {
+	sort.SliceStable(ranking, func(i, j int) bool {
+		if ranking[i].Stats.PackageSize != ranking[j].Stats.PackageSize {
+			return ranking[i].Stats.PackageSize > ranking[j].Stats.PackageSize
+		}
+		return ranking[i].Count > ranking[j].Count
+	})
+}
+
+// BuildUpstreamGroups groups files whose match points at the same open
+// source file of the same component, most files first. Only files that
+// share their upstream file with at least one other file are grouped.
+func BuildUpstreamGroups(scan *ScanResult) []UpstreamGroup {
+	type upstreamKey struct{ purl, file string }
+	groupMap := make(map[upstreamKey][]string)
+
+	for filePath, matches := range scan.Files {
+		match := FirstValidMatch(matches)
+		if match == nil || match.ID == MatchDependency || len(match.Purl) == 0 || match.File == "" {
+			continue
+		}
+		key := upstreamKey{match.Purl[0], match.File}
+		groupMap[key] = append(groupMap[key], filePath)
+	}
+
+	groups := make([]UpstreamGroup, 0)
+	for key, files := range groupMap {
+		if len(files) < 2 {
+			continue
+		}
+		sort.Strings(files)
+		groups = append(groups, UpstreamGroup{PURL: key.purl, File: key.file, Files: files})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].Files) != len(groups[j].Files) {
+			return len(groups[i].Files) > len(groups[j].Files)
+		}
+		if groups[i].PURL != groups[j].PURL {
+			return groups[i].PURL < groups[j].PURL
+		}
+		return groups[i].File < groups[j].File
+	})
+	return groups
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"sort"
+	"strings"
+)
+
+// SourceIgnoreList marks decisions recorded because the component is on
+// the user's always-ignore list rather than by a person
+const SourceIgnoreList = "ignore_list"
+
+// IgnoreListPURL returns the entry of list covering the component of a
+// match, compared without versions, or "" when there is none
+func IgnoreListPURL(match *FileMatch, list []string) string {
+	if match == nil || len(list) == 0 {
+		return ""
+	}
+	for _, purl := range match.Purl {
+		for _, entry := range list {
+			if strings.EqualFold(ComponentPURL(purl), ComponentPURL(entry)) {
+				return entry
+			}
+		}
+	}
+	return ""
+}
+
+// ApplyIgnoreList ignores the pending files whose component is on list and
+// returns them, sorted. Their decisions are marked with SourceIgnoreList.
+func (s *ScanResult) ApplyIgnoreList(list []string) []string {
+	ignored := make([]string, 0)
+	for path, matches := range s.Files {
+		match := FirstValidMatch(matches)
+		if match == nil || MatchStatus(match) != StatusPending {
+			continue
+		}
+		purl := IgnoreListPURL(match, list)
+		if purl == "" {
+			continue
+		}
+		match.AddDecision(DecisionIgnored, "On the always-ignore list: "+purl)
+		match.AuditCmd[len(match.AuditCmd)-1].Source = SourceIgnoreList
+		ignored = append(ignored, path)
+	}
+	sort.Strings(ignored)
+	return ignored
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CSVChange is a decision that importing an edited export would record
+type CSVChange struct {
+	Line       int
+	Path       string
+	OldStatus  string // export labels: Pending, Accepted, Ignored or Deferred
+	NewStatus  string
+	OldComment string
+	NewComment string
+}
+
+// CSVImportIssue is a row that can't be imported
+type CSVImportIssue struct {
+	Line    int
+	Path    string
+	Message string
+}
+
+// csvStatusLabels maps the accepted spellings of the Status column to the
+// export labels
+var csvStatusLabels = map[string]string{
+	"pending":    "Pending",
+	"accepted":   "Accepted",
+	"identified": "Accepted",
+	"ignored":    "Ignored",
+	"deferred":   "Deferred",
+}
+
+// PlanCSVImport compares an export produced by ExportCSV, possibly edited in
+// a spreadsheet, with the current results and returns the decisions that
+// importing it would record. Rows whose file is unknown, whose PURL changed
+// since the export, or whose status can't be applied are returned as issues.
+func PlanCSVImport(r io.Reader, scan *ScanResult) ([]CSVChange, []CSVImportIssue, error) {
+	return PlanCSVImportLabels(r, scan, nil)
+}
+
+// PlanCSVImportLabels is PlanCSVImport for exports written with
+// CSVOptions.StatusLabels: those labels are accepted in the Status column
+// besides the default ones
+func PlanCSVImportLabels(r io.Reader, scan *ScanResult, labels map[string]string) ([]CSVChange, []CSVImportIssue, error) {
+	statusLabels := make(map[string]string, len(csvStatusLabels)+len(labels))
+	for spelling, label := range csvStatusLabels {
+		statusLabels[spelling] = label
+	}
+	// Problems name the states as the export spells them
+	names := map[string]string{"Pending": "Pending", "Accepted": "Accepted", "Ignored": "Ignored", "Deferred": "Deferred"}
+	for status, label := range labels {
+		if export, ok := csvStatusLabels[status]; ok && label != "" {
+			statusLabels[strings.ToLower(label)] = export
+			names[export] = label
+		}
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Spreadsheets add a byte order mark, and use ";" in some locales
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if !bytes.Contains(firstLine, []byte(",")) && bytes.Contains(firstLine, []byte(";")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %v", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"File Path", "Status"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("missing %q column; only CSV files exported by auditcmd can be imported", required)
+		}
+	}
+	field := func(record []string, name string) (string, bool) {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return "", false
+		}
+		return strings.TrimSpace(record[i]), true
+	}
+
+	changes := make([]CSVChange, 0)
+	issues := make([]CSVImportIssue, 0)
+	seen := make(map[string]int)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		filePath, _ := field(record, "File Path")
+		if filePath == "" {
+			continue
+		}
+		issue := func(format string, args ...interface{}) {
+			issues = append(issues, CSVImportIssue{Line: line, Path: filePath, Message: fmt.Sprintf(format, args...)})
+		}
+
+		if first, dup := seen[filePath]; dup {
+			issue("duplicate row, first seen on line %d", first)
+			continue
+		}
+		seen[filePath] = line
+
+		matches, exists := scan.Files[filePath]
+		if !exists {
+			issue("file is not in the results")
+			continue
+		}
+
+		statusText, _ := field(record, "Status")
+		newStatus, valid := statusLabels[strings.ToLower(statusText)]
+		if !valid {
+			issue("unknown status %q (use %s, %s, %s or %s)", statusText, names["Pending"], names["Accepted"], names["Ignored"], names["Deferred"])
+			continue
+		}
+
+		match := FirstValidMatch(matches)
+		if match == nil {
+			if newStatus != "Pending" {
+				issue("file has no match to mark %s", names[newStatus])
+			}
+			continue
+		}
+
+		if purls, ok := field(record, "PURL"); ok && purls != strings.Join(match.Purl, "; ") {
+			issue("PURL changed since the export (now %s)", strings.Join(match.Purl, "; "))
+			continue
+		}
+
+		oldStatus := CSVStatus(match)
+		oldComment := ""
+		if latest := match.LatestDecision(); latest != nil {
+			oldComment = latest.Assessment
+		}
+		newComment, hasComment := field(record, "Comment")
+		if !hasComment {
+			newComment = oldComment
+		}
+
+		if newStatus == oldStatus && newComment == oldComment {
+			continue
+		}
+		if newStatus == "Pending" && oldStatus == "Pending" {
+			issue("comments can only be imported with status %s, %s or %s", names["Accepted"], names["Ignored"], names["Deferred"])
+			continue
+		}
+		if newStatus == "Pending" {
+			issue("decisions can't be reset to %s by import; roll back to a checkpoint instead", names["Pending"])
+			continue
+		}
+		changes = append(changes, CSVChange{
+			Line:       line,
+			Path:       filePath,
+			OldStatus:  oldStatus,
+			NewStatus:  newStatus,
+			OldComment: oldComment,
+			NewComment: newComment,
+		})
+	}
+	return changes, issues, nil
+}
+
+// ApplyCSVImport records the planned decisions as made by auditor.
+// Comment-only changes are recorded as a new decision with the same status,
+// keeping the history.
+func ApplyCSVImport(scan *ScanResult, changes []CSVChange, auditor string) {
+	for _, change := range changes {
+		match := FirstValidMatch(scan.Files[change.Path])
+		if match == nil {
+			continue
+		}
+		decision := DecisionIdentified
+		switch change.NewStatus {
+		case "Ignored":
+			decision = DecisionIgnored
+		case "Deferred":
+			decision = DecisionDeferred
+		}
+		match.AddDecision(decision, change.NewComment)
+		match.AuditCmd[len(match.AuditCmd)-1].Auditor = auditor
+	}
+}
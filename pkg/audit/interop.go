@@ -0,0 +1,96 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"strings"
+	"time"
+)
+
+// statusDecisions maps the values other tools, such as SCANOSS Workbench,
+// write to a match's "status" field to the decision they stand for.
+// "include" and "remove" are the BOM terms of scanoss.json settings.
+var statusDecisions = map[string]string{
+	"identified": DecisionIdentified,
+	"include":    DecisionIdentified,
+	"ignored":    DecisionIgnored,
+	"remove":     DecisionIgnored,
+}
+
+// StatusAssessment is the assessment of decisions adopted from the status
+// field of a match
+const StatusAssessment = "Recorded by another tool in the match status"
+
+// adoptStatusDecision records the decision another tool left in the status
+// field of a match without an audit array. When it was made is unknown, so
+// it is timestamped with when it was adopted.
+func adoptStatusDecision(match *FileMatch) {
+	if match.IsAudited() || !IsValidMatch(*match) {
+		return
+	}
+	if decision, found := statusDecisions[strings.ToLower(strings.TrimSpace(match.Status))]; found {
+		match.AuditCmd = append(match.AuditCmd, AuditDecision{Decision: decision, Assessment: StatusAssessment, Timestamp: time.Now().UTC()})
+	}
+}
+
+// StatusValue is the status field of a match in the audit state status,
+// given the value it has now. Values other tools understand are written:
+// deferred files are pending, and the BOM terms "include" and "remove" set
+// by the SCANOSS platform are kept, or swapped when the decision changed.
+// Values auditcmd doesn't know are kept as they are.
+func StatusValue(current, status string) string {
+	if status == StatusDeferred {
+		status = StatusPending
+	}
+	known := strings.ToLower(strings.TrimSpace(current))
+	switch known {
+	case "", StatusIdentified, StatusIgnored, StatusPending, StatusDeferred:
+		return status
+	case "include", "remove":
+		switch status {
+		case StatusIdentified:
+			return "include"
+		case StatusIgnored:
+			return "remove"
+		}
+		return status
+	}
+	return current
+}
+
+// StatusAgrees reports whether the status field of a match says what its
+// audit array does
+func StatusAgrees(match *FileMatch) bool {
+	status := strings.TrimSpace(match.Status)
+	return strings.EqualFold(StatusValue(status, MatchStatus(match)), status)
+}
+
+// AddStatus gives every auditable match without a status field one, set
+// from its audit state, so tools that only read the status, such as the
+// SCANOSS platform, see all decisions. SyncStatus keeps it up to date.
+func (s *ScanResult) AddStatus() {
+	for path := range s.Files {
+		for i := range s.Files[path] {
+			match := &s.Files[path][i]
+			if match.Status == "" && IsValidMatch(*match) {
+				match.Status = StatusValue("", MatchStatus(match))
+			}
+		}
+	}
+}
+
+// SyncStatus writes the audit state of each match that has a status field
+// back to it, so tools reading the status see the decisions recorded in
+// the audit array
+func (s *ScanResult) SyncStatus() {
+	for path := range s.Files {
+		for i := range s.Files[path] {
+			match := &s.Files[path][i]
+			if match.Status == "" || !IsValidMatch(*match) {
+				continue
+			}
+			match.Status = StatusValue(match.Status, MatchStatus(match))
+		}
+	}
+}
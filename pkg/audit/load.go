@@ -0,0 +1,59 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Parse decodes a SCANOSS result document. Variants written by other
+// versions of the format are adapted; unsupported ones return a
+// *SchemaError, which tells what the document looks like instead when it
+// is a common mistake such as an SBOM.
+func Parse(data []byte) (*ScanResult, error) {
+	files, err := parseResults(data)
+	if err == nil {
+		err = checkResults(files)
+	}
+	var schemaError *SchemaError
+	if errors.As(err, &schemaError) {
+		if name := lookalike(data); name != "" {
+			return nil, &SchemaError{Message: fmt.Sprintf("this looks like %s, not a SCANOSS result", name)}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ScanResult{Files: files}, nil
+}
+
+// Load reads and decodes a SCANOSS result file
+func Load(path string) (*ScanResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Marshal encodes the results, including audit decisions, as indented JSON.
+// Status fields are updated to match the decisions, see SyncStatus, and
+// timestamps are written in UTC.
+func (s *ScanResult) Marshal() ([]byte, error) {
+	s.SyncStatus()
+	s.NormalizeTimestamps()
+	return json.MarshalIndent(s.Files, "", "  ")
+}
+
+// Save writes the results, including audit decisions, back to path
+func (s *ScanResult) Save(path string) error {
+	data, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+// Merge replaces the matches of every file in fresh, typically the output of
+// re-scanning part of the tree, and returns the paths that were updated.
+// Decisions carry over when the file still matches the same component;
+// otherwise the file becomes pending again. Audit notes always carry over.
+func (s *ScanResult) Merge(fresh *ScanResult) []string {
+	if s.Files == nil {
+		s.Files = make(map[string][]FileMatch)
+	}
+
+	updated := make([]string, 0, len(fresh.Files))
+	for filePath, matches := range fresh.Files {
+		oldMatch := FirstValidMatch(s.Files[filePath])
+		newMatches := append([]FileMatch(nil), matches...)
+
+		if oldMatch != nil && len(newMatches) > 0 {
+			target := FirstValidMatch(newMatches)
+			if target != nil && samePURL(oldMatch, target) {
+				target.AuditCmd = append([]AuditDecision(nil), oldMatch.AuditCmd...)
+			}
+			if target == nil {
+				target = &newMatches[0]
+			}
+			target.AuditNotes = append(target.AuditNotes, oldMatch.AuditNotes...)
+		}
+
+		s.Files[filePath] = newMatches
+		updated = append(updated, filePath)
+	}
+	return updated
+}
+
+func samePURL(a, b *FileMatch) bool {
+	if len(a.Purl) == 0 || len(b.Purl) == 0 {
+		return false
+	}
+	return a.Purl[0] == b.Purl[0]
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+// Package audit contains the SCANOSS result model and the audit logic shared
+// by the auditcmd TUI: loading and saving results, recording decisions,
+// filtering files and exporting reports.
+package audit
+
+import (
+	"time"
+)
+
+type ScanResult struct {
+	Files map[string][]FileMatch `json:",inline"`
+}
+
+type FileMatch struct {
+	Component    string          `json:"component"`
+	Copyrights   []Copyright     `json:"copyrights"`
+	Cryptography []interface{}   `json:"cryptography"`
+	Dependencies []Dependency    `json:"dependencies,omitempty"`
+	File         string          `json:"file"`
+	FileHash     string          `json:"file_hash"`
+	FileURL      string          `json:"file_url"`
+	Health       Health          `json:"health"`
+	ID           string          `json:"id"`
+	Latest       string          `json:"latest"`
+	Licenses     []License       `json:"licenses"`
+	Lines        interface{}     `json:"lines"`
+	OSSLines     interface{}     `json:"oss_lines"`
+	Purl         []string        `json:"purl"`
+	Quality      []Quality       `json:"quality"`
+	ReleaseDate  string          `json:"release_date"`
+	Server       Server          `json:"server"`
+	SourceHash   string          `json:"source_hash"`
+	Status       string          `json:"status"`
+	URL          string          `json:"url"`
+	URLHash      string          `json:"url_hash"`
+	URLStats     URLStats        `json:"url_stats"`
+	Version      string          `json:"version"`
+	AuditCmd     []AuditDecision `json:"audit,omitempty"`
+	AuditNotes   []AuditNote     `json:"audit_notes,omitempty"`
+}
+
+type Copyright struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+type Health struct {
+	CreationDate string `json:"creation_date"`
+	Forks        int    `json:"forks"`
+	Issues       int    `json:"issues"`
+	LastPush     string `json:"last_push"`
+	LastUpdate   string `json:"last_update"`
+	Stars        int    `json:"stars"`
+}
+
+type License struct {
+	ChecklistURL string `json:"checklist_url,omitempty"`
+	Copyleft     string `json:"copyleft,omitempty"`
+	Name         string `json:"name"`
+	OSADLUpdated string `json:"osadl_updated,omitempty"`
+	PatentHints  string `json:"patent_hints,omitempty"`
+	Source       string `json:"source"`
+	URL          string `json:"url,omitempty"`
+}
+
+type Quality struct {
+	Score  string `json:"score"`
+	Source string `json:"source"`
+}
+
+type Server struct {
+	Elapsed   string            `json:"elapsed"`
+	Flags     string            `json:"flags"`
+	Hostname  string            `json:"hostname"`
+	KBVersion map[string]string `json:"kb_version"`
+	Version   string            `json:"version"`
+}
+
+type URLStats struct {
+	IgnoredFiles int `json:"ignored_files"`
+	IndexedFiles int `json:"indexed_files"`
+	PackageSize  int `json:"package_size"`
+	SourceFiles  int `json:"source_files"`
+}
+
+type AuditDecision struct {
+	Decision   string          `json:"decision"`
+	Assessment string          `json:"assessment,omitempty"`
+	Reviewer   string          `json:"reviewer,omitempty"`         // Set for second opinions, see AddReview
+	Auditor    string          `json:"auditor,omitempty"`          // Who made the decision
+	Duration   int             `json:"duration_seconds,omitempty"` // Seconds the file was open before the decision, when recorded
+	Source     string          `json:"source,omitempty"`           // Set for automatic decisions, e.g. SourceIgnoreList
+	Checklist  []ChecklistItem `json:"checklist,omitempty"`        // The review checklist as ticked in the accept dialog
+	Timestamp  time.Time       `json:"timestamp"`
+}
+
+// ChecklistItem is a point of the review checklist and whether the auditor
+// ticked it
+type ChecklistItem struct {
+	Item    string `json:"item"`
+	Checked bool   `json:"checked"`
+}
+
+// AuditNote records follow-up information that doesn't change the decision,
+// such as the issue opened for a finding
+type AuditNote struct {
+	Note      string    `json:"note,omitempty"`
+	Ticket    string    `json:"ticket,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type PURLRankEntry struct {
+	PURL     string
+	Files    []string
+	Count    int
+	Stats    URLStats // Size of the component, see ComponentStats
+	Versions []PURLVersion
+}
+
+// PURLVersion is one version of a ranked component and the files matching it
+type PURLVersion struct {
+	Version string
+	Files   []string
+}
+
+// UpstreamGroup is a set of local files matching the same open source file
+type UpstreamGroup struct {
+	PURL  string
+	File  string // Path of the file in the component
+	Files []string
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LicenseObligations is what distributing the components under one license
+// requires, from the license metadata and its OSADL checklist
+type LicenseObligations struct {
+	License          string
+	ChecklistURL     string
+	Copyleft         string
+	Attribution      bool
+	SourceDisclosure bool
+	Patent           bool
+	Obligations      []string // "YOU MUST" statements from the checklist
+	Components       []string // PURLs of the identified components
+	Files            int
+	ChecklistError   string            // why the checklist couldn't be used, if it couldn't
+	Conflict         string            // why the license can't be used in the project, see MarkConflicts
+	Stale            map[string]string // why components look abandoned, by PURL, see MarkStale
+}
+
+// CollectObligations groups the licenses of all identified (accepted)
+// matches, ordered by license name. Checklists aren't fetched; see
+// ApplyChecklist.
+func CollectObligations(scan *ScanResult) []*LicenseObligations {
+	byLicense := make(map[string]*LicenseObligations)
+	components := make(map[string]map[string]bool)
+
+	for _, matches := range scan.Files {
+		match := FirstValidMatch(matches)
+		if match == nil || MatchStatus(match) != StatusIdentified {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, license := range match.Licenses {
+			if license.Name == "" || seen[license.Name] {
+				continue
+			}
+			seen[license.Name] = true
+
+			entry := byLicense[license.Name]
+			if entry == nil {
+				entry = &LicenseObligations{License: license.Name}
+				byLicense[license.Name] = entry
+				components[license.Name] = make(map[string]bool)
+			}
+			if entry.ChecklistURL == "" {
+				entry.ChecklistURL = license.ChecklistURL
+			}
+			if entry.Copyleft == "" {
+				entry.Copyleft = license.Copyleft
+			}
+			if strings.EqualFold(license.PatentHints, "yes") {
+				entry.Patent = true
+			}
+			if isCopyleft(license.Copyleft) {
+				entry.SourceDisclosure = true
+			}
+			entry.Files++
+			for _, purl := range match.Purl {
+				components[license.Name][purl] = true
+			}
+		}
+	}
+
+	result := make([]*LicenseObligations, 0, len(byLicense))
+	for name, entry := range byLicense {
+		for purl := range components[name] {
+			entry.Components = append(entry.Components, purl)
+		}
+		sort.Strings(entry.Components)
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].License < result[j].License
+	})
+	return result
+}
+
+// MarkConflicts records which licenses are incompatible with the project license
+func MarkConflicts(obligations []*LicenseObligations, project string) {
+	for _, o := range obligations {
+		o.Conflict = LicenseConflict(project, o.License)
+	}
+}
+
+// MarkStale records which components of the obligations look abandoned as
+// of now, see StaleReasons
+func MarkStale(obligations []*LicenseObligations, scan *ScanResult, thresholds StaleThresholds, now time.Time) {
+	stale := make(map[string]string)
+	for _, matches := range scan.Files {
+		match := FirstValidMatch(matches)
+		if match == nil || MatchStatus(match) != StatusIdentified {
+			continue
+		}
+		if reasons := StaleReasons(match, thresholds, now); len(reasons) > 0 {
+			for _, purl := range match.Purl {
+				stale[purl] = strings.Join(reasons, ", ")
+			}
+		}
+	}
+	for _, o := range obligations {
+		o.Stale = nil
+		for _, purl := range o.Components {
+			if reasons, ok := stale[purl]; ok {
+				if o.Stale == nil {
+					o.Stale = make(map[string]string)
+				}
+				o.Stale[purl] = reasons
+			}
+		}
+	}
+}
+
+func isCopyleft(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return value != "" && value != "no" && value != "false"
+}
+
+// ApplyChecklist adds the statements of an OSADL checklist, e.g.
+//
+//	USE CASE Source code delivery OR Binary delivery
+//	  YOU MUST Forward License text
+//	  YOU MUST Forward Copyright notices
+//	PATENT HINTS Yes
+//
+// and derives the attribution, source disclosure and patent flags from them
+func ApplyChecklist(o *LicenseObligations, checklist string) {
+	seen := make(map[string]bool, len(o.Obligations))
+	for _, statement := range o.Obligations {
+		seen[statement] = true
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(checklist))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		upper := strings.ToUpper(line)
+
+		if strings.HasPrefix(upper, "PATENT HINTS") && strings.Contains(upper, "YES") {
+			o.Patent = true
+			continue
+		}
+		if !strings.HasPrefix(upper, "YOU MUST") {
+			continue
+		}
+		if !seen[line] {
+			seen[line] = true
+			o.Obligations = append(o.Obligations, line)
+		}
+		if strings.HasPrefix(upper, "YOU MUST NOT") {
+			continue
+		}
+		switch {
+		case strings.Contains(upper, "COPYRIGHT NOTICES"), strings.Contains(upper, "LICENSE TEXT"), strings.Contains(upper, "CREDIT"):
+			o.Attribution = true
+		case strings.Contains(upper, "SOURCE CODE"):
+			o.SourceDisclosure = true
+		}
+		if strings.Contains(upper, "PATENT") {
+			o.Patent = true
+		}
+	}
+}
+
+// Checklists rarely change, so they are fetched once per run
+var (
+	checklistCache = make(map[string]string)
+	checklistMu    sync.Mutex
+)
+
+// FetchChecklist downloads an OSADL checklist
+func FetchChecklist(url string) (string, error) {
+	checklistMu.Lock()
+	text, ok := checklistCache[url]
+	checklistMu.Unlock()
+	if ok {
+		return text, nil
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	if err != nil {
+		return "", err
+	}
+
+	checklistMu.Lock()
+	checklistCache[url] = string(data)
+	checklistMu.Unlock()
+	return string(data), nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+// WriteObligationsCSV writes one row per license
+func WriteObligationsCSV(w io.Writer, obligations []*LicenseObligations) error {
+	writer := csv.NewWriter(w)
+	header := []string{"License", "Attribution Required", "Source Disclosure", "Patent Clauses", "Copyleft", "Files", "Components", "Obligations", "Checklist", "Conflict", "Stale Components"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %v", err)
+	}
+	for _, o := range obligations {
+		checklist := o.ChecklistURL
+		if o.ChecklistError != "" {
+			checklist = strings.TrimSpace(checklist + " (" + o.ChecklistError + ")")
+		}
+		stale := make([]string, 0, len(o.Stale))
+		for _, purl := range o.Components {
+			if reasons, ok := o.Stale[purl]; ok {
+				stale = append(stale, fmt.Sprintf("%s (%s)", purl, reasons))
+			}
+		}
+		record := []string{
+			o.License,
+			yesNo(o.Attribution),
+			yesNo(o.SourceDisclosure),
+			yesNo(o.Patent),
+			o.Copyleft,
+			fmt.Sprintf("%d", o.Files),
+			strings.Join(o.Components, "; "),
+			strings.Join(o.Obligations, "; "),
+			checklist,
+			o.Conflict,
+			strings.Join(stale, "; "),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write record: %v", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteObligationsMarkdown writes a summary table followed by one section
+// per license
+func WriteObligationsMarkdown(w io.Writer, obligations []*LicenseObligations) error {
+	fmt.Fprintf(w, "# License Obligations\n\n")
+	if len(obligations) == 0 {
+		fmt.Fprintf(w, "No identified components.\n")
+		return nil
+	}
+
+	fmt.Fprintf(w, "| License | Attribution | Source disclosure | Patent clauses | Files |\n")
+	fmt.Fprintf(w, "|---|---|---|---|---|\n")
+	for _, o := range obligations {
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %d |\n", o.License, yesNo(o.Attribution), yesNo(o.SourceDisclosure), yesNo(o.Patent), o.Files)
+	}
+
+	conflicts := false
+	for _, o := range obligations {
+		if o.Conflict == "" {
+			continue
+		}
+		if !conflicts {
+			fmt.Fprintf(w, "\n## Incompatible Licenses\n\n")
+			conflicts = true
+		}
+		fmt.Fprintf(w, "- **%s**: %s (%d files)\n", o.License, o.Conflict, o.Files)
+	}
+
+	for _, o := range obligations {
+		fmt.Fprintf(w, "\n## %s\n\n", o.License)
+		if o.Copyleft != "" {
+			fmt.Fprintf(w, "Copyleft: %s\n\n", o.Copyleft)
+		}
+		if len(o.Obligations) > 0 {
+			for _, statement := range o.Obligations {
+				fmt.Fprintf(w, "- %s\n", statement)
+			}
+			fmt.Fprintf(w, "\n")
+		}
+		if o.ChecklistURL != "" {
+			fmt.Fprintf(w, "Checklist: %s\n\n", o.ChecklistURL)
+		}
+		if o.ChecklistError != "" {
+			fmt.Fprintf(w, "Checklist unavailable (%s); flags are based on the license metadata only.\n\n", o.ChecklistError)
+		}
+		fmt.Fprintf(w, "Components:\n")
+		for _, purl := range o.Components {
+			if reasons, ok := o.Stale[purl]; ok {
+				fmt.Fprintf(w, "- `%s` (stale component: %s)\n", purl, reasons)
+			} else {
+				fmt.Fprintf(w, "- `%s`\n", purl)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CountryCount is how many contributors of a component are located in a
+// country
+type CountryCount struct {
+	Country string `json:"country"`
+	Count   int    `json:"count"`
+}
+
+// DeclaredLocation is a location the component's owner or contributors
+// state in their profiles
+type DeclaredLocation struct {
+	Type     string `json:"type"`
+	Location string `json:"location"`
+}
+
+// Provenance is the geographic origin of a component's contributors, as
+// reported by the SCANOSS geoprovenance API
+type Provenance struct {
+	PURL     string             `json:"purl"`
+	Declared []DeclaredLocation `json:"declared_locations"`
+	Curated  []CountryCount     `json:"curated_locations"`
+}
+
+// ProvenancePURL is the PURL provenance is looked up by: without version,
+// as provenance describes the project rather than a release
+func ProvenancePURL(purl string) string {
+	return ComponentPURL(purl)
+}
+
+// Countries summarizes the provenance, e.g. "Germany (12), Spain (3)", with
+// the most contributors first. The declared locations are used when no
+// curated countries are known, and "" when neither are.
+func (p *Provenance) Countries() string {
+	if p == nil {
+		return ""
+	}
+	curated := make([]CountryCount, 0, len(p.Curated))
+	for _, country := range p.Curated {
+		if country.Country != "" {
+			curated = append(curated, country)
+		}
+	}
+	sort.SliceStable(curated, func(i, j int) bool {
+		if curated[i].Count != curated[j].Count {
+			return curated[i].Count > curated[j].Count
+		}
+		return curated[i].Country < curated[j].Country
+	})
+	parts := make([]string, 0, len(curated))
+	for _, country := range curated {
+		parts = append(parts, fmt.Sprintf("%s (%d)", country.Country, country.Count))
+	}
+	if len(parts) > 0 {
+		return strings.Join(parts, ", ")
+	}
+
+	seen := make(map[string]bool)
+	for _, declared := range p.Declared {
+		if declared.Location != "" && !seen[declared.Location] {
+			seen[declared.Location] = true
+			parts = append(parts, declared.Location)
+		}
+	}
+	if len(parts) > 0 {
+		return "declared: " + strings.Join(parts, ", ")
+	}
+	return ""
+}
+
+// FetchProvenance asks the geoprovenance endpoint at url about purls in one
+// request. The results are keyed by ProvenancePURL; PURLs the API doesn't
+// know are missing from them.
+func FetchProvenance(url, apiKey string, purls []string) (map[string]*Provenance, error) {
+	return FetchProvenanceContext(context.Background(), url, apiKey, purls)
+}
+
+// FetchProvenanceContext is FetchProvenance with a context that cancels the
+// request
+func FetchProvenanceContext(ctx context.Context, url, apiKey string, purls []string) (map[string]*Provenance, error) {
+	type purlRequest struct {
+		PURL string `json:"purl"`
+	}
+	request := struct {
+		PURLs []purlRequest `json:"purls"`
+	}{}
+	seen := make(map[string]bool)
+	for _, purl := range purls {
+		if purl = ProvenancePURL(purl); purl != "" && !seen[purl] {
+			seen[purl] = true
+			request.PURLs = append(request.PURLs, purlRequest{PURL: purl})
+		}
+	}
+	results := make(map[string]*Provenance)
+	if len(request.PURLs) == 0 {
+		return results, nil
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var response struct {
+		PURLs []*Provenance `json:"purls"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("invalid provenance response: %v", err)
+	}
+	for _, provenance := range response.PURLs {
+		if provenance != nil && provenance.PURL != "" {
+			results[ProvenancePURL(provenance.PURL)] = provenance
+		}
+	}
+	return results, nil
+}
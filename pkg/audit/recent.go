@@ -0,0 +1,62 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// RecentFile is a results file that was opened for an audit
+type RecentFile struct {
+	Path   string    `json:"path"`
+	Opened time.Time `json:"opened"`
+}
+
+// UserState is what is remembered across the audits of a user, as opposed
+// to the ProjectState of one results file
+type UserState struct {
+	// Recent lists the results files opened last first
+	Recent []RecentFile `json:"recent,omitempty"`
+}
+
+// AddRecent moves path to the top of the recent files, keeping at most limit
+func (s *UserState) AddRecent(path string, opened time.Time, limit int) {
+	recent := []RecentFile{{Path: path, Opened: opened}}
+	for _, file := range s.Recent {
+		if file.Path != path && len(recent) < limit {
+			recent = append(recent, file)
+		}
+	}
+	s.Recent = recent
+}
+
+// LoadUserState reads a state file written by SaveUserState. A missing file
+// is an empty state.
+func LoadUserState(path string) (*UserState, error) {
+	state := &UserState{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// SaveUserState writes the state as JSON
+func SaveUserState(path string, state *UserState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
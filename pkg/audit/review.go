@@ -0,0 +1,34 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+// AddReview appends a second opinion on the match: the decision of a
+// reviewer confirming or overriding the earlier one
+func (m *FileMatch) AddReview(decision, assessment, reviewer string) AuditDecision {
+	entry := m.AddDecision(decision, assessment)
+	entry.Reviewer = reviewer
+	m.AuditCmd[len(m.AuditCmd)-1] = entry
+	return entry
+}
+
+// NeedsReview reports whether a file is in reviewer's re-audit queue: it was
+// identified or ignored, and the latest decision isn't reviewer's own
+// second opinion. A later decision by the first auditor puts it back.
+func NeedsReview(matches []FileMatch, reviewer string) bool {
+	match := FirstValidMatch(matches)
+	switch MatchStatus(match) {
+	case StatusIdentified, StatusIgnored:
+		return match.LatestDecision().Reviewer != reviewer
+	}
+	return false
+}
+
+// ReviewedDecision returns the decision a reviewer's latest entry confirmed
+// or overrode, or nil when the latest entry isn't a second opinion
+func ReviewedDecision(match *FileMatch) *AuditDecision {
+	if match == nil || len(match.AuditCmd) < 2 || match.LatestDecision().Reviewer == "" {
+		return nil
+	}
+	return &match.AuditCmd[len(match.AuditCmd)-2]
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SARIF 2.1.0 types, limited to what code scanning dashboards read
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string            `json:"id"`
+	ShortDescription sarifMessage      `json:"shortDescription"`
+	HelpURI          string            `json:"helpUri,omitempty"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID           string            `json:"ruleId"`
+	Level            string            `json:"level"`
+	Message          sarifMessage      `json:"message"`
+	Locations        []sarifLocation   `json:"locations"`
+	RelatedLocations []sarifLocation   `json:"relatedLocations,omitempty"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               int                   `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           *sarifRegion  `json:"region,omitempty"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// sarifRegions turns "12-40,55" into one region per range
+func sarifRegions(ranges string) []*sarifRegion {
+	regions := make([]*sarifRegion, 0)
+	for _, part := range splitRanges(ranges) {
+		startText, endText, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(startText))
+		if err != nil || start < 1 {
+			continue
+		}
+		region := &sarifRegion{StartLine: start}
+		if isRange {
+			if end, err := strconv.Atoi(strings.TrimSpace(endText)); err == nil && end >= start {
+				region.EndLine = end
+			}
+		}
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+// ExportSARIF writes one result per pending or deferred match so open findings show up
+// in code scanning dashboards. Each matched PURL is a rule described by its
+// licenses. Results point at the matched lines of the scanned file, with the
+// oss_lines of the open source file as related locations.
+func ExportSARIF(w io.Writer, scan *ScanResult) error {
+	return ExportSARIFStale(w, scan, StaleThresholds{})
+}
+
+// ExportSARIFStale is ExportSARIF with matches to components that look
+// abandoned flagged, see StaleReasons: their message says why and the
+// result has a "staleComponent" property.
+func ExportSARIFStale(w io.Writer, scan *ScanResult, stale StaleThresholds) error {
+	now := time.Now()
+	rules := make(map[string]sarifRule)
+	results := make([]sarifResult, 0)
+
+	paths := make([]string, 0, len(scan.Files))
+	for filePath := range scan.Files {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+
+	for _, filePath := range paths {
+		match := FirstValidMatch(scan.Files[filePath])
+		status := MatchStatus(match)
+		if match == nil || (status != StatusPending && status != StatusDeferred) {
+			continue
+		}
+		purl := "unknown"
+		if len(match.Purl) > 0 {
+			purl = match.Purl[0]
+		}
+		licenses := make([]string, 0, len(match.Licenses))
+		for _, license := range match.Licenses {
+			licenses = append(licenses, license.Name)
+		}
+		licenseText := strings.Join(licenses, ", ")
+		if licenseText == "" {
+			licenseText = "unknown license"
+		}
+
+		if _, exists := rules[purl]; !exists {
+			rules[purl] = sarifRule{
+				ID:               purl,
+				ShortDescription: sarifMessage{Text: fmt.Sprintf("Code matches open source component %s (%s)", purl, licenseText)},
+				HelpURI:          match.URL,
+				Properties:       map[string]string{"license": licenseText},
+			}
+		}
+
+		uri := NormalizePath(filePath)
+		state := "pending audit"
+		if status == StatusDeferred {
+			state = "audit deferred"
+		}
+		result := sarifResult{
+			RuleID:  purl,
+			Level:   "warning",
+			Message: sarifMessage{Text: fmt.Sprintf("%s match with %s (%s), %s", match.ID, purl, licenseText, state)},
+		}
+
+		if match.ID == "snippet" {
+			for _, region := range sarifRegions(ExtractMatchedLines(match)) {
+				result.Locations = append(result.Locations, sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: uri}, Region: region}})
+			}
+			ossURI := match.FileURL
+			if ossURI == "" {
+				ossURI = match.File
+			}
+			for i, region := range sarifRegions(ExtractLineRanges(match)) {
+				result.RelatedLocations = append(result.RelatedLocations, sarifLocation{ID: i + 1, PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: ossURI}, Region: region}})
+			}
+			if ossLines := ExtractLineRanges(match); ossLines != "" {
+				result.Message.Text += fmt.Sprintf(", OSS lines %s of %s", ossLines, match.File)
+			}
+		}
+		if reasons := StaleReasons(match, stale, now); len(reasons) > 0 {
+			result.Message.Text += ", stale component: " + strings.Join(reasons, ", ")
+			result.Properties = map[string]string{"staleComponent": strings.Join(reasons, ", ")}
+		}
+		if len(result.Locations) == 0 {
+			result.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: uri}}}}
+		}
+		results = append(results, result)
+	}
+
+	driver := sarifDriver{Name: "auditcmd", InformationURI: "https://github.com/scanoss/auditcmd", Rules: make([]sarifRule, 0, len(rules))}
+	for _, rule := range rules {
+		driver.Rules = append(driver.Rules, rule)
+	}
+	sort.Slice(driver.Rules, func(i, j int) bool {
+		return driver.Rules[i].ID < driver.Rules[j].ID
+	})
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
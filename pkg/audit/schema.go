@@ -0,0 +1,236 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SchemaError reports a result document that doesn't follow any supported
+// variant of the SCANOSS result format
+type SchemaError struct {
+	Path    string // Scanned file whose entry is unsupported, "" for the document
+	Field   string // Unsupported field, "" for the entry as a whole
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	switch {
+	case e.Path == "":
+		return "unsupported result format: " + e.Message
+	case e.Field == "":
+		return fmt.Sprintf("unsupported result format for %s: %s", e.Path, e.Message)
+	}
+	return fmt.Sprintf("unsupported result format for %s: field %q %s", e.Path, e.Field, e.Message)
+}
+
+// lookalikes are JSON documents passed for a result by mistake, recognised
+// by the keys they have at the top, so the error can say what was passed
+var lookalikes = []struct {
+	name string
+	keys []string
+}{
+	{"a CycloneDX SBOM", []string{"bomFormat"}},
+	{"a CycloneDX SBOM", []string{"specVersion", "components"}},
+	{"an SPDX document", []string{"spdxVersion"}},
+	{"a SARIF log", []string{"runs", "version"}},
+	{"a SCANOSS settings file (scanoss.json)", []string{"bom"}},
+	{"an auditcmd state file", []string{"sessions"}},
+	{"an auditcmd state file", []string{"recent"}},
+}
+
+// lookalike names the kind of document data is when it is one of the
+// lookalikes, or returns ""
+func lookalike(data []byte) string {
+	var document map[string]json.RawMessage
+	if json.Unmarshal(data, &document) != nil {
+		return ""
+	}
+	for _, candidate := range lookalikes {
+		found := true
+		for _, key := range candidate.keys {
+			if _, ok := document[key]; !ok {
+				found = false
+			}
+		}
+		if found {
+			return candidate.name
+		}
+	}
+	return ""
+}
+
+// checkResults rejects documents that decoded without error but hold no
+// SCANOSS matches: every match of a result has an id, such as file,
+// snippet or none. Documents without any match, e.g. of an empty scan, pass.
+func checkResults(files map[string][]FileMatch) error {
+	matches := 0
+	for _, fileMatches := range files {
+		for _, match := range fileMatches {
+			if match.ID != "" {
+				return nil
+			}
+			matches++
+		}
+	}
+	if matches == 0 {
+		return nil
+	}
+	return &SchemaError{Message: fmt.Sprintf("none of its %d matches has an id (file, snippet or none), is this a SCANOSS result?", matches)}
+}
+
+// resultWrappers are keys under which some tools nest the per-file results
+var resultWrappers = []string{"files", "results"}
+
+// renamedFields maps field names of other format versions to the current ones
+var renamedFields = map[string]string{
+	"purls":     "purl",
+	"license":   "licenses",
+	"copyright": "copyrights",
+}
+
+// jsonKind returns the first character of a JSON value: '{', '[', '"' etc.
+func jsonKind(raw json.RawMessage) byte {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return 0
+	}
+	return trimmed[0]
+}
+
+// parseResults decodes a result document, adapting the variants of the
+// format that older and newer scanners write: results nested under
+// "files" or "results", a single match object instead of a list, and PURLs,
+// licenses or copyrights given as plain strings or under older names.
+// Anything else that doesn't fit is reported as a SchemaError naming the
+// field, instead of being decoded into zero values.
+func parseResults(data []byte) (map[string][]FileMatch, error) {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(data, &document); err != nil {
+		var typeError *json.UnmarshalTypeError
+		if errors.As(err, &typeError) {
+			return nil, &SchemaError{Message: "expected an object of scanned file paths, found " + typeError.Value}
+		}
+		return nil, err
+	}
+	if len(document) == 1 {
+		for _, key := range resultWrappers {
+			if nested, found := document[key]; found && jsonKind(nested) == '{' {
+				if err := json.Unmarshal(nested, &document); err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+	}
+
+	files := make(map[string][]FileMatch, len(document))
+	for path, raw := range document {
+		var rawMatches []json.RawMessage
+		switch jsonKind(raw) {
+		case '[':
+			if err := json.Unmarshal(raw, &rawMatches); err != nil {
+				return nil, err
+			}
+		case '{':
+			rawMatches = []json.RawMessage{raw}
+		case 'n':
+			// null: a file without results
+		default:
+			return nil, &SchemaError{Path: path, Message: "expected a list of matches"}
+		}
+
+		matches := make([]FileMatch, 0, len(rawMatches))
+		for _, rawMatch := range rawMatches {
+			match, err := parseMatch(path, rawMatch)
+			if err != nil {
+				return nil, err
+			}
+			matches = append(matches, match)
+		}
+		files[path] = matches
+	}
+	return files, nil
+}
+
+// parseMatch decodes one match of path, see parseResults
+func parseMatch(path string, raw json.RawMessage) (FileMatch, error) {
+	var match FileMatch
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return match, &SchemaError{Path: path, Message: "expected each match to be an object"}
+	}
+
+	for oldName, name := range renamedFields {
+		if value, found := fields[oldName]; found {
+			if _, current := fields[name]; !current {
+				fields[name] = value
+			}
+			delete(fields, oldName)
+		}
+	}
+	if value, found := fields["purl"]; found && jsonKind(value) == '"' {
+		fields["purl"] = wrapJSON(value)
+	}
+	for _, name := range []string{"licenses", "copyrights"} {
+		if value, found := fields[name]; found {
+			fields[name] = namedObjects(value)
+		}
+	}
+	if value, found := fields["quality"]; found && jsonKind(value) == '{' {
+		fields["quality"] = wrapJSON(value)
+	}
+
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return match, err
+	}
+	if err := json.Unmarshal(normalized, &match); err != nil {
+		var typeError *json.UnmarshalTypeError
+		if errors.As(err, &typeError) {
+			return match, &SchemaError{Path: path, Field: typeError.Field, Message: fmt.Sprintf("holds a JSON %s where %s was expected", typeError.Value, typeError.Type)}
+		}
+		return match, err
+	}
+	adoptStatusDecision(&match)
+	return match, nil
+}
+
+// wrapJSON puts a single JSON value in a list
+func wrapJSON(value json.RawMessage) json.RawMessage {
+	return json.RawMessage("[" + string(value) + "]")
+}
+
+// namedObjects turns "MIT" or ["MIT", "BSD"] into [{"name": "MIT"}, ...], the
+// form licenses and copyrights take in the current format
+func namedObjects(value json.RawMessage) json.RawMessage {
+	var names []string
+	switch jsonKind(value) {
+	case '"':
+		var name string
+		if json.Unmarshal(value, &name) != nil {
+			return value
+		}
+		names = []string{name}
+	case '[':
+		if json.Unmarshal(value, &names) != nil {
+			return value // Already objects, or unsupported and reported later
+		}
+	default:
+		return value
+	}
+
+	objects := make([]map[string]string, len(names))
+	for i, name := range names {
+		objects[i] = map[string]string{"name": name}
+	}
+	converted, err := json.Marshal(objects)
+	if err != nil {
+		return value
+	}
+	return converted
+}
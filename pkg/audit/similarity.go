@@ -0,0 +1,44 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import "strings"
+
+// CommonPathSuffix returns the trailing path components two paths share,
+// e.g. "lib/util.c" for "src/lib/util.c" and "project/lib/util.c"
+func CommonPathSuffix(path1, path2 string) string {
+	parts1 := strings.Split(NormalizePath(path1), "/")
+	parts2 := strings.Split(NormalizePath(path2), "/")
+
+	i := len(parts1) - 1
+	j := len(parts2) - 1
+	for i >= 0 && j >= 0 && parts1[i] == parts2[j] {
+		i--
+		j--
+	}
+	return strings.Join(parts1[i+1:], "/")
+}
+
+// PathSimilarity returns how much of the local path matches the path of
+// the open source file, as the percentage of the local path's components
+// found at the end of the OSS path. A match whose file name differs scores
+// 0 and is often a false positive.
+func PathSimilarity(localPath, ossPath string) int {
+	local := strings.Split(strings.Trim(NormalizePath(localPath), "/"), "/")
+	common := CommonPathSuffix(strings.Trim(NormalizePath(localPath), "/"), ossPath)
+	if common == "" {
+		return 0
+	}
+	return 100 * len(strings.Split(common, "/")) / len(local)
+}
+
+// MatchSimilarity returns the path similarity of a file's match, or -1 when
+// the file has no match or the match doesn't name an OSS file
+func MatchSimilarity(localPath string, matches []FileMatch) int {
+	match := FirstValidMatch(matches)
+	if match == nil || match.ID == MatchDependency || match.File == "" {
+		return -1
+	}
+	return PathSimilarity(localPath, match.File)
+}
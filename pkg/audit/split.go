@@ -0,0 +1,68 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"strings"
+)
+
+// SplitByDirectory slices the results by the directories right below root,
+// "" for the whole scan, keyed by their name. Files in root itself are
+// keyed "".
+func (s *ScanResult) SplitByDirectory(root string) map[string]*ScanResult {
+	slices := make(map[string]*ScanResult)
+	for path, matches := range s.Files {
+		relative := NormalizePath(path)
+		if root != "" {
+			relative = strings.TrimPrefix(relative, root+"/")
+		}
+		key := ""
+		if slash := strings.Index(relative, "/"); slash >= 0 {
+			key = relative[:slash]
+		}
+		addToSlice(slices, key, path, matches)
+	}
+	return slices
+}
+
+// SplitByPURL slices the results by the component of each file's match,
+// keyed by its PURL without version. Files without a match are left out.
+func (s *ScanResult) SplitByPURL() map[string]*ScanResult {
+	slices := make(map[string]*ScanResult)
+	for path, matches := range s.Files {
+		match := FirstValidMatch(matches)
+		if match == nil || len(match.Purl) == 0 {
+			continue
+		}
+		addToSlice(slices, ComponentPURL(match.Purl[0]), path, matches)
+	}
+	return slices
+}
+
+func addToSlice(slices map[string]*ScanResult, key, path string, matches []FileMatch) {
+	slice, ok := slices[key]
+	if !ok {
+		slice = &ScanResult{Files: make(map[string][]FileMatch)}
+		slices[key] = slice
+	}
+	slice.Files[path] = matches
+}
+
+// SliceFilename turns a slice key into a file name, e.g. "github_acme_lib"
+// for pkg:github/acme/lib: anything but letters, digits, dots, dashes and
+// underscores becomes an underscore
+func SliceFilename(key string) string {
+	key = strings.TrimPrefix(key, "pkg:")
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, key)
+	if strings.Trim(name, ".") == "" {
+		return "_"
+	}
+	return name
+}
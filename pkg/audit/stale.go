@@ -0,0 +1,59 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"fmt"
+	"time"
+)
+
+// Default ages after which a component is flagged as stale
+const (
+	DefaultStaleReleaseYears = 5
+	DefaultStalePushYears    = 2
+)
+
+// StaleThresholds says when the component of a match looks abandoned:
+// released, or last pushed to, more than this many years ago. A threshold
+// of 0 isn't checked.
+type StaleThresholds struct {
+	ReleaseYears int
+	PushYears    int
+}
+
+// Enabled reports whether any threshold is checked
+func (t StaleThresholds) Enabled() bool {
+	return t.ReleaseYears > 0 || t.PushYears > 0
+}
+
+// parseScanDate reads the dates of the scan results, which come as plain
+// dates or RFC 3339 timestamps
+func parseScanDate(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	if len(value) > 10 {
+		return parseScanDate(value[:10])
+	}
+	return time.Time{}, false
+}
+
+// StaleReasons returns why the component of a match looks abandoned as of
+// now, e.g. ["released 2014-03-01", "last push 2019-06-30"], or nil when it
+// doesn't or the scan doesn't report the dates
+func StaleReasons(match *FileMatch, thresholds StaleThresholds, now time.Time) []string {
+	if match == nil || !thresholds.Enabled() {
+		return nil
+	}
+	var reasons []string
+	if released, ok := parseScanDate(match.ReleaseDate); ok && thresholds.ReleaseYears > 0 && released.Before(now.AddDate(-thresholds.ReleaseYears, 0, 0)) {
+		reasons = append(reasons, fmt.Sprintf("released %s", released.Format("2006-01-02")))
+	}
+	if pushed, ok := parseScanDate(match.Health.LastPush); ok && thresholds.PushYears > 0 && pushed.Before(now.AddDate(-thresholds.PushYears, 0, 0)) {
+		reasons = append(reasons, fmt.Sprintf("last push %s", pushed.Format("2006-01-02")))
+	}
+	return reasons
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import "sort"
+
+// Summary counts files by match type and audit state
+type Summary struct {
+	TotalFiles        int
+	MatchingFiles     int
+	FileMatches       int
+	SnippetMatches    int
+	DependencyMatches int
+	NoMatchFiles      int
+	Pending           int
+	Identified        int
+	Ignored           int
+	Deferred          int
+}
+
+// Summarize counts every file in the results
+func Summarize(scan *ScanResult) Summary {
+	summary := Summary{TotalFiles: len(scan.Files)}
+	for _, matches := range scan.Files {
+		summary.add(matches)
+	}
+	summary.NoMatchFiles = summary.TotalFiles - summary.MatchingFiles
+	return summary
+}
+
+// SummarizeFiles counts the given files only, e.g. those of one directory
+func SummarizeFiles(scan *ScanResult, files []string) Summary {
+	summary := Summary{TotalFiles: len(files)}
+	for _, filePath := range files {
+		summary.add(scan.Files[filePath])
+	}
+	summary.NoMatchFiles = summary.TotalFiles - summary.MatchingFiles
+	return summary
+}
+
+func (summary *Summary) add(matches []FileMatch) {
+	match := FirstValidMatch(matches)
+	if match == nil {
+		return
+	}
+
+	summary.MatchingFiles++
+	if match.ID == "file" {
+		summary.FileMatches++
+	} else if match.ID == MatchDependency {
+		summary.DependencyMatches++
+	} else {
+		summary.SnippetMatches++
+	}
+
+	switch MatchStatus(match) {
+	case StatusIdentified:
+		summary.Identified++
+	case StatusIgnored:
+		summary.Ignored++
+	case StatusDeferred:
+		summary.Deferred++
+	default:
+		summary.Pending++
+	}
+}
+
+// Tally counts the matched files of one PURL or license
+type Tally struct {
+	Name    string
+	Files   int
+	Pending int
+}
+
+// TopPURLs and TopLicenses rank the PURLs or licenses of the given files by
+// pending files, then by matched files. At most limit entries are returned.
+func TopPURLs(scan *ScanResult, files []string, limit int) []Tally {
+	return topTallies(scan, files, limit, func(match *FileMatch) []string {
+		return match.Purl
+	})
+}
+
+func TopLicenses(scan *ScanResult, files []string, limit int) []Tally {
+	return topTallies(scan, files, limit, func(match *FileMatch) []string {
+		names := make([]string, 0, len(match.Licenses))
+		for _, license := range match.Licenses {
+			names = append(names, license.Name)
+		}
+		return names
+	})
+}
+
+func topTallies(scan *ScanResult, files []string, limit int, keys func(*FileMatch) []string) []Tally {
+	byName := make(map[string]*Tally)
+	for _, filePath := range files {
+		match := FirstValidMatch(scan.Files[filePath])
+		if match == nil {
+			continue
+		}
+		pending := MatchStatus(match) == StatusPending
+		seen := make(map[string]bool)
+		for _, name := range keys(match) {
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			tally := byName[name]
+			if tally == nil {
+				tally = &Tally{Name: name}
+				byName[name] = tally
+			}
+			tally.Files++
+			if pending {
+				tally.Pending++
+			}
+		}
+	}
+
+	tallies := make([]Tally, 0, len(byName))
+	for _, tally := range byName {
+		tallies = append(tallies, *tally)
+	}
+	sort.Slice(tallies, func(i, j int) bool {
+		if tallies[i].Pending != tallies[j].Pending {
+			return tallies[i].Pending > tallies[j].Pending
+		}
+		if tallies[i].Files != tallies[j].Files {
+			return tallies[i].Files > tallies[j].Files
+		}
+		return tallies[i].Name < tallies[j].Name
+	})
+	if limit > 0 && len(tallies) > limit {
+		tallies = tallies[:limit]
+	}
+	return tallies
+}
+
+// Progress returns the number of audited files, the number of auditable files
+// and the completion percentage. Deferred files aren't audited yet.
+func Progress(scan *ScanResult) (int, int, int) {
+	audited := 0
+	total := 0
+
+	for _, matches := range scan.Files {
+		match := FirstValidMatch(matches)
+		if match == nil {
+			continue
+		}
+		total++
+		if status := MatchStatus(match); status == StatusIdentified || status == StatusIgnored {
+			audited++
+		}
+	}
+
+	percentage := 0
+	if total > 0 {
+		percentage = (audited * 100) / total
+	}
+	return audited, total, percentage
+}
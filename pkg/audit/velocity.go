@@ -0,0 +1,96 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// AuditSession is one run of the auditor in which decisions were recorded
+type AuditSession struct {
+	Started   time.Time `json:"started"`
+	Ended     time.Time `json:"ended"` // Time of the last decision
+	Decisions int       `json:"decisions"`
+}
+
+// Duration returns how long the session took up to its last decision
+func (s AuditSession) Duration() time.Duration {
+	return s.Ended.Sub(s.Started)
+}
+
+// Rate returns the decisions per hour of the session, 0 when it has no
+// measurable duration
+func (s AuditSession) Rate() float64 {
+	hours := s.Duration().Hours()
+	if hours <= 0 {
+		return 0
+	}
+	return float64(s.Decisions) / hours
+}
+
+// ProjectState is what auditcmd keeps about an audit besides the decisions
+// in the results file
+type ProjectState struct {
+	Sessions []AuditSession `json:"sessions"`
+}
+
+// Velocity returns the decisions per hour over the last n sessions, weighted
+// by their duration, or 0 without history
+func (p *ProjectState) Velocity(n int) float64 {
+	sessions := p.Sessions
+	if len(sessions) > n {
+		sessions = sessions[len(sessions)-n:]
+	}
+	decisions, hours := 0, 0.0
+	for _, session := range sessions {
+		if session.Duration() > 0 {
+			decisions += session.Decisions
+			hours += session.Duration().Hours()
+		}
+	}
+	if hours == 0 {
+		return 0
+	}
+	return float64(decisions) / hours
+}
+
+// EstimateRemaining returns the time pending decisions take at the velocity
+// of the last n sessions, 0 when there is no history to estimate from
+func (p *ProjectState) EstimateRemaining(pending, n int) time.Duration {
+	rate := p.Velocity(n)
+	if rate == 0 {
+		return 0
+	}
+	return time.Duration(float64(pending) / rate * float64(time.Hour))
+}
+
+// LoadProjectState reads a state file written by SaveProjectState. A
+// missing file is an empty state.
+func LoadProjectState(path string) (*ProjectState, error) {
+	state := &ProjectState{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// SaveProjectState writes the state as JSON
+func SaveProjectState(path string, state *ProjectState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
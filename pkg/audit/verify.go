@@ -0,0 +1,64 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Results of comparing a local file with the scan
+const (
+	DriftModified = "modified" // contents differ from the scanned file
+	DriftMissing  = "missing"  // file no longer exists
+)
+
+// LocalHash returns the MD5 of a file, the hash SCANOSS reports as source_hash
+func LocalHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifySource hashes every scanned file below sourceDir and returns the
+// files that no longer match, with DriftModified or DriftMissing. Files
+// without a source_hash in the results can't be checked and are skipped.
+func VerifySource(scan *ScanResult, sourceDir string) map[string]string {
+	drift := make(map[string]string)
+	for filePath, matches := range scan.Files {
+		expected := ""
+		for i := range matches {
+			if matches[i].SourceHash != "" {
+				expected = matches[i].SourceHash
+				break
+			}
+		}
+		if expected == "" {
+			continue
+		}
+
+		local := filepath.Join(sourceDir, filepath.FromSlash(NormalizePath(filePath)))
+		actual, err := LocalHash(local)
+		if err != nil {
+			drift[filePath] = DriftMissing
+			continue
+		}
+		if !strings.EqualFold(actual, expected) {
+			drift[filePath] = DriftModified
+		}
+	}
+	return drift
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WFPFile is the fingerprint of one scanned file from a .wfp file
+type WFPFile struct {
+	Path         string
+	MD5          string
+	Size         int64
+	SnippetLines []int // local lines that carry snippet hashes, ascending
+}
+
+// HasSnippet reports whether a snippet hash was computed at line
+func (f *WFPFile) HasSnippet(line int) bool {
+	i := sort.SearchInts(f.SnippetLines, line)
+	return i < len(f.SnippetLines) && f.SnippetLines[i] == line
+}
+
+// ParseWFP reads the winnowing fingerprints produced by the SCANOSS scanners:
+//
+//	file=<md5>,<size>,<path>
+//	<line>=<hash>,<hash>...
+//
+// Other entries (hpsm=, fh2=, ...) are ignored. Files are keyed by their
+// normalized path.
+func ParseWFP(r io.Reader) (map[string]*WFPFile, error) {
+	files := make(map[string]*WFPFile)
+	var current *WFPFile
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !found {
+			continue
+		}
+
+		if key == "file" {
+			parts := strings.SplitN(value, ",", 3)
+			if len(parts) != 3 {
+				return nil, fmt.Errorf("line %d: malformed file entry", lineNum)
+			}
+			size, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid file size %q", lineNum, parts[1])
+			}
+			current = &WFPFile{Path: parts[2], MD5: parts[0], Size: size}
+			files[NormalizePath(parts[2])] = current
+			continue
+		}
+
+		line, err := strconv.Atoi(key)
+		if err != nil || current == nil {
+			continue
+		}
+		current.SnippetLines = append(current.SnippetLines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		sort.Ints(file.SnippetLines)
+	}
+	return files, nil
+}
+
+// LoadWFP reads a .wfp file
+func LoadWFP(path string) (map[string]*WFPFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseWFP(f)
+}
+
+// RangePair links a matched range in the scanned file to the corresponding
+// range in the open source file
+type RangePair struct {
+	Local string
+	OSS   string
+}
+
+// PairLineRanges pairs the lines and oss_lines ranges of a snippet match.
+// SCANOSS lists both in the same order, one entry per matched block.
+func PairLineRanges(match *FileMatch) []RangePair {
+	local := splitRanges(ExtractMatchedLines(match))
+	oss := splitRanges(ExtractLineRanges(match))
+
+	count := len(local)
+	if len(oss) > count {
+		count = len(oss)
+	}
+	pairs := make([]RangePair, 0, count)
+	for i := 0; i < count; i++ {
+		pair := RangePair{}
+		if i < len(local) {
+			pair.Local = local[i]
+		}
+		if i < len(oss) {
+			pair.OSS = oss[i]
+		}
+		pairs = append(pairs, pair)
+	}
+	return pairs
+}
+
+func splitRanges(value string) []string {
+	ranges := make([]string, 0)
+	if value == "" || value == "all" {
+		return ranges
+	}
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			ranges = append(ranges, part)
+		}
+	}
+	return ranges
+}
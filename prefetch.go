@@ -0,0 +1,225 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// batchContentSize is the most files requested in one batch
+const batchContentSize = 50
+
+// errBatchUnsupported means the API has no batched content endpoint, and
+// files have to be fetched one at a time
+var errBatchUnsupported = errors.New("batched content retrieval not supported")
+
+// batchContentFile is one file of a batched content response
+type batchContentFile struct {
+	URL     string `json:"url"`
+	Content string `json:"content"`
+	ETag    string `json:"etag"`
+}
+
+// fetchFileContentBatch downloads several matched files in one request to
+// the batch endpoint. Files missing from the response aren't included in
+// the results.
+func fetchFileContentBatch(ctx context.Context, batchURL string, apiKey string, urls []string) (map[string]contentResponse, error) {
+	client := &http.Client{Timeout: 60 * time.Second}
+
+	body, err := json.Marshal(map[string][]string{"urls": urls})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", batchURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("X-API-Key", apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := network.do(client, req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("HTTP request failed: %v (%w)", err, errAPIUnreachable)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return nil, errBatchUnsupported
+	default:
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(message))
+	}
+
+	var response struct {
+		Files []batchContentFile `json:"files"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("invalid batch response: %v", err)
+	}
+	quota := quotaFromHeaders(resp.Header)
+	results := make(map[string]contentResponse, len(response.Files))
+	for _, file := range response.Files {
+		results[file.URL] = contentResponse{Content: file.Content, ETag: file.ETag, Quota: quota}
+	}
+	return results, nil
+}
+
+// fetchContents downloads several matched files, each with the key apiKeys
+// returns for its URL, in batches when batchURL is set. Only files on the
+// batch endpoint's host are batched. Files a batch doesn't return, and all
+// files once the endpoint turns out to be unsupported, are fetched one at
+// a time. The returned flag
+// reports an unsupported endpoint, so later calls can skip it. Fetching
+// stops at the first sign that the API is unreachable, or when ctx is
+// cancelled; the files fetched until then are returned with the error.
+// progress, if set, is called with the number of files fetched so far
+// after each request.
+func fetchContents(ctx context.Context, batchURL string, apiKeys func(string) string, urls []string, progress func(fetched int)) (map[string]contentResponse, bool, error) {
+	results := make(map[string]contentResponse, len(urls))
+	unsupported := false
+	for start := 0; start < len(urls); start += batchContentSize {
+		chunk := urls[start:min(start+batchContentSize, len(urls))]
+		batched := make([]string, 0, len(chunk))
+		for _, url := range chunk {
+			if urlHost(url) == urlHost(batchURL) {
+				batched = append(batched, url)
+			}
+		}
+		if batchURL != "" && !unsupported && len(batched) > 0 {
+			batch, err := fetchFileContentBatch(ctx, batchURL, apiKeys(batchURL), batched)
+			if ctx.Err() != nil {
+				return results, unsupported, ctx.Err()
+			}
+			if errors.Is(err, errAPIUnreachable) {
+				return results, unsupported, err
+			}
+			unsupported = err == errBatchUnsupported
+			for url, response := range batch {
+				results[url] = response
+			}
+			if progress != nil {
+				progress(len(results))
+			}
+		}
+
+		for _, url := range chunk {
+			if _, done := results[url]; done {
+				continue
+			}
+			response, err := fetchFileContent(ctx, url, apiKeys(url), "")
+			if err == nil {
+				results[url] = response
+			}
+			if progress != nil {
+				progress(len(results))
+			}
+			if ctx.Err() != nil {
+				return results, unsupported, ctx.Err()
+			}
+			if errors.Is(err, errAPIUnreachable) {
+				return results, unsupported, err
+			}
+		}
+	}
+	return results, unsupported, nil
+}
+
+// prefetchURLs returns the content URLs of up to app.Prefetch files that
+// follow filePath in the file list and aren't cached yet
+func prefetchURLs(app *AppState, filePath string) []string {
+	start := 0
+	for i, listed := range app.CurrentFileList {
+		if listed == filePath {
+			start = i + 1
+			break
+		}
+	}
+
+	urls := make([]string, 0, app.Prefetch)
+	seen := make(map[string]bool)
+	for _, listed := range app.CurrentFileList[start:] {
+		if len(urls) == app.Prefetch {
+			break
+		}
+		match := audit.FirstValidMatch(app.ScanData.Files[listed])
+		if match == nil || match.ID == audit.MatchDependency || match.FileURL == "" || seen[match.FileURL] {
+			continue
+		}
+		seen[match.FileURL] = true
+		if _, cached := lookupContent(app, match.FileURL); !cached && apiKeyFor(app, match.FileURL) != "" {
+			urls = append(urls, match.FileURL)
+		}
+	}
+	return urls
+}
+
+// startPrefetch fetches the contents of the next files in the list in the
+// background, so moving through them doesn't wait on the API. Leaving the
+// content view with Esc stops it; the files fetched until then are cached.
+func startPrefetch(g *gocui.Gui, app *AppState, filePath string) {
+	if app.Prefetch <= 0 || app.StopPrefetch != nil || app.Offline || !hasAPIKey(app) {
+		return
+	}
+	urls := prefetchURLs(app, filePath)
+	if len(urls) == 0 {
+		return
+	}
+	// Prefetching is a guess: the quota left is kept for the files opened.
+	// The Tasks popup says why, once per session.
+	if warning := quotaWarning(app, len(urls)); warning != "" {
+		if !app.PrefetchHeld {
+			app.PrefetchHeld = true
+			task := startBackgroundTask(g, app, fmt.Sprintf("Prefetch of %d files", len(urls)))
+			finishTask(g, app, task, fmt.Errorf("skipped to save the API quota: %s", strings.TrimPrefix(warning, "WARNING: ")))
+		}
+		return
+	}
+
+	task := startBackgroundTask(g, app, fmt.Sprintf("Prefetch of %d files", len(urls)))
+	app.StopPrefetch = task.cancel
+	batchURL := app.BatchContentURL
+	if app.BatchUnsupported {
+		batchURL = ""
+	}
+	apiKeys := apiKeysFor(app)
+	go func() {
+		results, unsupported, err := fetchContents(task.ctx, batchURL, apiKeys, urls, func(fetched int) {
+			reportTask(g, app, task, fmt.Sprintf("%d of %d files", fetched, len(urls)), fetched, len(urls))
+		})
+		g.Update(func(g *gocui.Gui) error {
+			finishTask(g, app, task, err)
+			app.StopPrefetch = nil
+			if unsupported {
+				app.BatchUnsupported = true
+			}
+			for url, response := range results {
+				if response.Quota != nil {
+					app.Quota = response.Quota
+				}
+				storeContent(app, cachedContent{URL: url, ETag: response.ETag, Content: response.Content}, true)
+			}
+			if errors.Is(err, errAPIUnreachable) {
+				goOffline(g, app, urls[0])
+			}
+			return nil
+		})
+	}()
+}
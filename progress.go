@@ -7,37 +7,14 @@ import (
 	"fmt"
 	"strings"
 
+	"auditcmd/pkg/audit"
+
 	"github.com/awesome-gocui/gocui"
 )
 
 func calculateProgress(app *AppState) (int, int, int) {
-	totalFiles := 0
-	auditedFiles := 0
-
-	// Only count files with valid matches (file or snippet)
-	for _, matches := range app.ScanData.Files {
-		for _, match := range matches {
-			// Only count files with id = "file" or "snippet"
-			if match.ID != "file" && match.ID != "snippet" {
-				continue
-			}
-			
-			totalFiles++
-			
-			// Check if file has been audited (any decision made)
-			if len(match.AuditCmd) > 0 {
-				auditedFiles++
-			}
-			break // Only count first valid match per file
-		}
-	}
-
-	percentage := 0
-	if totalFiles > 0 {
-		percentage = (auditedFiles * 100) / totalFiles
-	}
-
-	return auditedFiles, totalFiles, percentage
+	// Only files with valid matches (file or snippet) count towards progress
+	return audit.Progress(&app.ScanData)
 }
 
 func displayProgressBar(g *gocui.Gui, app *AppState) error {
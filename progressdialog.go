@@ -0,0 +1,125 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// progressState is what the progress dialog shows: the progress of a task
+type progressState struct {
+	Title  string
+	Detail string // Fixed first line, e.g. the file being written
+	task   *backgroundTask
+}
+
+// showProgressDialog shows the progress of task until closeProgressDialog.
+// For the task the user waits for, see startTask, ESC cancels it and B lets
+// it continue in the background; other tasks can't be interrupted here.
+func showProgressDialog(g *gocui.Gui, app *AppState, title, detail string, task *backgroundTask) error {
+	app.Progress = &progressState{Title: title, Detail: detail, task: task}
+	if _, err := setDialogView(g, "progress_dialog"); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	if _, err := g.SetCurrentView("progress_dialog"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("progress_dialog")
+	g.SetKeybinding("progress_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if app.Task == task {
+			cancelTask(app)
+			return drawProgressDialog(g, app)
+		}
+		return nil
+	})
+	for _, key := range []rune{'b', 'B'} {
+		g.SetKeybinding("progress_dialog", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			if app.Task != task || task.ctx.Err() != nil {
+				return nil
+			}
+			// Still listed in the Tasks popup, where it can be cancelled
+			app.Task = nil
+			announce(app, "%s continues in the background", task.Name)
+			return closeProgressDialog(g, app, task)
+		})
+	}
+
+	announce(app, "%s: %s", title, task.Message)
+	return drawProgressDialog(g, app)
+}
+
+func drawProgressDialog(g *gocui.Gui, app *AppState) error {
+	v, err := g.View("progress_dialog")
+	if err != nil || app.Progress == nil {
+		return nil
+	}
+	state, task := app.Progress, app.Progress.task
+	v.Title = state.Title
+	v.Frame = true
+	v.Wrap = false
+	v.TitleColor = gocui.ColorYellow
+	v.BgColor = gocui.ColorBlack
+	v.FgColor = gocui.ColorYellow
+	v.Clear()
+
+	width, _ := v.Size()
+	fmt.Fprintf(v, " %s\n", state.Detail)
+	fmt.Fprintf(v, " %s\n", task.Message)
+	if task.Total > 0 {
+		fmt.Fprintf(v, " %s\n", progressBarLine(app, width-2, task.Done, task.Total))
+	} else {
+		fmt.Fprintf(v, "\n")
+	}
+	switch {
+	case app.Task != task:
+	case task.ctx.Err() != nil:
+		fmt.Fprintf(v, " Cancelling...")
+	default:
+		fmt.Fprintf(v, " ESC: Cancel  B: Continue in background")
+	}
+	return nil
+}
+
+// progressBarLine renders done out of total as a bar followed by the
+// percentage and counts, fitting width columns
+func progressBarLine(app *AppState, width, done, total int) string {
+	percentage := done * 100 / total
+	text := fmt.Sprintf(" %3d%% (%d/%d)", percentage, done, total)
+	if app.Accessible {
+		return strings.TrimSpace(text)
+	}
+	barWidth := width - len(text)
+	if barWidth < 10 {
+		barWidth = 10
+	}
+	filled := barWidth * done / total
+	return strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled) + text
+}
+
+// closeProgressDialog closes the progress dialog of task, if it is still
+// showing, and returns to the pane that was active
+func closeProgressDialog(g *gocui.Gui, app *AppState, task *backgroundTask) error {
+	if app.Progress == nil || app.Progress.task != task {
+		return nil
+	}
+	app.Progress = nil
+	g.DeleteKeybindings("progress_dialog")
+	g.DeleteView("progress_dialog")
+
+	// An error reported meanwhile keeps the focus
+	if _, err := g.View("error_dialog"); err == nil {
+		g.SetCurrentView("error_dialog")
+		return nil
+	}
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// provenanceCountries returns the contributor countries of a PURL fetched
+// from provenance_url this session, or "" while they aren't known
+func provenanceCountries(app *AppState, purl string) string {
+	if app.ProvenanceURL == "" || purl == "" {
+		return ""
+	}
+	return app.Provenance[audit.ProvenancePURL(purl)]
+}
+
+// statusPURL is the PURL described by the status pane, if any
+func statusPURL(app *AppState) string {
+	match := app.CurrentMatch
+	if match == nil {
+		match = highlightedMatch(app)
+	}
+	if match != nil {
+		if len(match.Purl) > 0 {
+			return match.Purl[0]
+		}
+		return ""
+	}
+	if groupedView(app) && app.TreeState != nil && app.TreeState.selectedNode != nil {
+		return app.TreeState.selectedNode.Name
+	}
+	return ""
+}
+
+// requestProvenance looks up the contributor countries of the PURL the
+// status pane describes in the background, once per PURL and session, and
+// redraws the status pane when they arrive
+func requestProvenance(g *gocui.Gui, app *AppState) {
+	purl := audit.ProvenancePURL(statusPURL(app))
+	if app.ProvenanceURL == "" || purl == "" || app.Offline {
+		return
+	}
+	if _, known := app.Provenance[purl]; known {
+		return
+	}
+	if app.Provenance == nil {
+		app.Provenance = make(map[string]string)
+	}
+	// Recorded before the lookup, so a failed one isn't repeated
+	app.Provenance[purl] = ""
+
+	task := startBackgroundTask(g, app, "Contributor countries of "+displayPURL(app, purl))
+	url, apiKey := app.ProvenanceURL, apiKeyFor(app, app.ProvenanceURL)
+	go func() {
+		results, err := audit.FetchProvenanceContext(task.ctx, url, apiKey, []string{purl})
+		g.Update(func(g *gocui.Gui) error {
+			finishTask(g, app, task, err)
+			if task.Err == context.Canceled {
+				return nil
+			}
+			if err != nil {
+				announce(app, "Provenance lookup for %s failed: %v", displayPURL(app, purl), err)
+				return nil
+			}
+			app.Provenance[purl] = results[purl].Countries()
+			// The status pane may be gone by now, e.g. on quit
+			updateStatus(g, app)
+			return nil
+		})
+	}()
+}
+
+// fetchExportProvenance looks up the contributor countries of every
+// matched PURL whose countries aren't known yet, for the Contributor
+// Countries column of the CSV export. PURLs whose lookup fails are exported
+// without countries, as are all once ctx is cancelled.
+func fetchExportProvenance(ctx context.Context, app *AppState) map[string]string {
+	countries := make(map[string]string, len(app.Provenance))
+	for purl, summary := range app.Provenance {
+		countries[purl] = summary
+	}
+	if app.Offline {
+		return countries
+	}
+	missing := missingProvenance(app)
+	if len(missing) == 0 {
+		return countries
+	}
+	results, err := audit.FetchProvenanceContext(ctx, app.ProvenanceURL, apiKeyFor(app, app.ProvenanceURL), missing)
+	if err != nil {
+		return countries
+	}
+	for purl, provenance := range results {
+		countries[purl] = provenance.Countries()
+	}
+	return countries
+}
+
+// missingProvenance lists the matched PURLs, without versions, whose
+// contributor countries weren't found this session
+func missingProvenance(app *AppState) []string {
+	seen := make(map[string]bool)
+	missing := make([]string, 0)
+	for _, matches := range app.ScanData.Files {
+		match := audit.FirstValidMatch(matches)
+		if match == nil || len(match.Purl) == 0 {
+			continue
+		}
+		purl := audit.ProvenancePURL(match.Purl[0])
+		if app.Provenance[purl] == "" && !seen[purl] {
+			seen[purl] = true
+			missing = append(missing, purl)
+		}
+	}
+	return missing
+}
+
+// exportProvenanceWarning is the quota warning for the contributor countries
+// an export looks up, each PURL counting as a request, or ""
+func exportProvenanceWarning(app *AppState) string {
+	if app.ProvenanceURL == "" || app.Offline {
+		return ""
+	}
+	return quotaWarning(app, len(missingProvenance(app)))
+}
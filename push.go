@@ -0,0 +1,285 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
+)
+
+// PushConfig holds the compliance servers identified components can be
+// pushed to with "auditcmd push"
+type PushConfig struct {
+	SW360URL        string // e.g. https://sw360.example.com
+	SW360Token      string
+	SW360Project    string // project id the releases are linked to (optional)
+	FossologyURL    string // e.g. https://fossology.example.com/repo
+	FossologyToken  string
+	FossologyUpload string // id of the upload the report is imported into
+}
+
+// runPush implements "auditcmd push results.json --to sw360|fossology" and
+// returns the exit code
+func runPush(opts *Options) int {
+	scan, err := loadReportScan(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return loadExitCode(err)
+	}
+	config, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+
+	components := audit.CollectComponents(scan)
+	if len(components) == 0 {
+		fmt.Println("No identified components to push.")
+		return exitClean
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	if opts.Format == "fossology" {
+		err = pushFossology(client, config.Push, components)
+	} else {
+		err = pushSW360(client, config.Push, components)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	return exitClean
+}
+
+// sw360Client talks to the SW360 REST API
+type sw360Client struct {
+	http  *http.Client
+	base  string
+	token string
+}
+
+// request sends an API request and decodes the response into result when
+// given. The status code is returned so callers can handle 409 Conflict.
+func (c *sw360Client) request(method, endpoint string, payload, result interface{}) (int, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return 0, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	target := endpoint
+	if !strings.HasPrefix(endpoint, "http") {
+		target = c.base + "/resource/api" + endpoint
+	}
+	req, err := http.NewRequest(method, target, body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Token "+c.token)
+	req.Header.Set("Accept", "application/hal+json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return resp.StatusCode, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("API error %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if result != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, result); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to parse response: %v", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// sw360Resource is the part of a HAL resource auditcmd needs
+type sw360Resource struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Links   struct {
+		Self struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"_links"`
+	Embedded struct {
+		Components []sw360Resource `json:"sw360:components"`
+		Releases   []sw360Resource `json:"sw360:releases"`
+	} `json:"_embedded"`
+}
+
+// findOrCreateComponent returns the URL of the SW360 component with the
+// name of component, creating it if needed
+func (c *sw360Client) findOrCreateComponent(component *audit.Component) (string, error) {
+	var found sw360Resource
+	if _, err := c.request("GET", "/components?name="+url.QueryEscape(component.Name), nil, &found); err != nil {
+		return "", err
+	}
+	for _, existing := range found.Embedded.Components {
+		if existing.Name == component.Name {
+			return existing.Links.Self.Href, nil
+		}
+	}
+
+	payload := map[string]interface{}{
+		"name":          component.Name,
+		"componentType": "OSS",
+		"categories":    []string{"auditcmd"},
+		"description":   "Identified by auditcmd as " + component.PURL,
+		"externalIds":   map[string]string{"package-url": component.PURL},
+	}
+	var created sw360Resource
+	if _, err := c.request("POST", "/components", payload, &created); err != nil {
+		return "", err
+	}
+	if created.Links.Self.Href == "" {
+		return "", fmt.Errorf("component %s already exists but wasn't found by name", component.Name)
+	}
+	return created.Links.Self.Href, nil
+}
+
+// createRelease adds the component version, or finds it when it exists, and
+// returns its URL
+func (c *sw360Client) createRelease(componentURL string, component *audit.Component) (string, bool, error) {
+	version := component.Version
+	if version == "" {
+		version = "unknown"
+	}
+	payload := map[string]interface{}{
+		"name":           component.Name,
+		"version":        version,
+		"componentId":    path.Base(componentURL),
+		"mainLicenseIds": component.Licenses,
+		"externalIds":    map[string]string{"package-url": component.PURL},
+	}
+	var created sw360Resource
+	status, err := c.request("POST", "/releases", payload, &created)
+	if err != nil {
+		return "", false, err
+	}
+	if status != http.StatusConflict {
+		return created.Links.Self.Href, true, nil
+	}
+
+	var existing sw360Resource
+	if _, err := c.request("GET", componentURL, nil, &existing); err != nil {
+		return "", false, err
+	}
+	for _, release := range existing.Embedded.Releases {
+		if release.Version == version {
+			return release.Links.Self.Href, false, nil
+		}
+	}
+	return "", false, fmt.Errorf("release %s %s already exists but wasn't found", component.Name, version)
+}
+
+// pushSW360 creates a component and release for every identified component
+// and links the releases to sw360_project when set
+func pushSW360(httpClient *http.Client, config PushConfig, components []*audit.Component) error {
+	if config.SW360URL == "" || config.SW360Token == "" {
+		return fmt.Errorf("set sw360_url and sw360_token in %s", getConfigFilePath())
+	}
+	client := &sw360Client{http: httpClient, base: strings.TrimRight(config.SW360URL, "/"), token: config.SW360Token}
+
+	releases := make([]string, 0, len(components))
+	created := 0
+	for _, component := range components {
+		componentURL, err := client.findOrCreateComponent(component)
+		if err != nil {
+			return fmt.Errorf("%s: %v", component.PURL, err)
+		}
+		releaseURL, isNew, err := client.createRelease(componentURL, component)
+		if err != nil {
+			return fmt.Errorf("%s: %v", component.PURL, err)
+		}
+		if isNew {
+			created++
+			fmt.Printf("  created  %s %s\n", component.Name, component.Version)
+		} else {
+			fmt.Printf("  exists   %s %s\n", component.Name, component.Version)
+		}
+		if releaseURL != "" {
+			releases = append(releases, releaseURL)
+		}
+	}
+
+	if config.SW360Project != "" && len(releases) > 0 {
+		if _, err := client.request("POST", "/projects/"+url.PathEscape(config.SW360Project)+"/releases", releases, nil); err != nil {
+			return fmt.Errorf("linking releases to project %s: %v", config.SW360Project, err)
+		}
+		fmt.Printf("Linked %d releases to project %s\n", len(releases), config.SW360Project)
+	}
+	fmt.Printf("Pushed %d components to SW360 (%d new releases)\n", len(components), created)
+	return nil
+}
+
+// pushFossology imports the components as a CycloneDX report into an
+// existing FOSSology upload, which records their licenses as conclusions
+func pushFossology(client *http.Client, config PushConfig, components []*audit.Component) error {
+	if config.FossologyURL == "" || config.FossologyToken == "" || config.FossologyUpload == "" {
+		return fmt.Errorf("set fossology_url, fossology_token and fossology_upload in %s", getConfigFilePath())
+	}
+
+	var bom bytes.Buffer
+	if err := audit.WriteCycloneDX(&bom, components); err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("report", "auditcmd-bom.json")
+	if err != nil {
+		return err
+	}
+	part.Write(bom.Bytes())
+	form.Close()
+
+	endpoint := strings.TrimRight(config.FossologyURL, "/") + "/api/v1/report/import"
+	req, err := http.NewRequest("POST", endpoint, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+config.FossologyToken)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("upload", config.FossologyUpload)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("API error %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	fmt.Printf("Imported %d components into FOSSology upload %s\n", len(components), config.FossologyUpload)
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// defaultQualityThreshold is the quality score percentage below which a
+// match is highlighted and counts as low in the false positive review,
+// unless quality_threshold is set
+const defaultQualityThreshold = audit.DefaultQualityThreshold
+
+// lowQuality reports whether a file's match has a quality score below the
+// threshold
+func lowQuality(app *AppState, filePath string) bool {
+	_, percent := audit.BestQuality(audit.FirstValidMatch(app.ScanData.Files[filePath]))
+	return percent >= 0 && percent < app.QualityThreshold
+}
+
+// qualityMarker highlights matches whose quality score is below the
+// threshold, as they deserve extra scrutiny
+func qualityMarker(app *AppState, filePath string) string {
+	if !lowQuality(app, filePath) {
+		return ""
+	}
+	score, _ := audit.BestQuality(audit.FirstValidMatch(app.ScanData.Files[filePath]))
+	if app.Accessible {
+		return fmt.Sprintf(" [low quality %s]", score)
+	}
+	return fmt.Sprintf(" \033[31mq%s\033[0m", score)
+}
+
+// cycleQualityMode switches the file list between its order by path
+// similarity, lowest quality scores first, and only the matches below the
+// quality threshold
+func cycleQualityMode(g *gocui.Gui, app *AppState) error {
+	switch app.QualityMode {
+	case "":
+		app.QualityMode = "sort"
+	case "sort":
+		app.QualityMode = "low"
+	default:
+		app.QualityMode = ""
+	}
+
+	refreshScope(g, app)
+	switch app.QualityMode {
+	case "sort":
+		announce(app, "Files ordered by quality score, lowest first")
+	case "low":
+		announce(app, "Showing only matches with a quality score below %d%%, %d items", app.QualityThreshold, len(app.TreeList.Items))
+	default:
+		announce(app, "Files no longer ordered by quality score, %d items", len(app.TreeList.Items))
+	}
+	return nil
+}
+
+// sortByQuality orders files from the lowest to the highest quality score,
+// keeping files without a score last
+func sortByQuality(app *AppState, files []string) []string {
+	sorted := make([]string, len(files))
+	copy(sorted, files)
+	quality := make(map[string]int, len(files))
+	for _, filePath := range files {
+		_, quality[filePath] = audit.BestQuality(audit.FirstValidMatch(app.ScanData.Files[filePath]))
+		if quality[filePath] < 0 {
+			quality[filePath] = 101
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return quality[sorted[i]] < quality[sorted[j]]
+	})
+	return sorted
+}
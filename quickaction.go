@@ -0,0 +1,118 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Values of quick_actions: how decisions without the dialog are taken
+const (
+	quickInstant = "instant" // Default: the key decides straight away
+	quickConfirm = "confirm" // A second key confirms
+	quickOff     = "off"     // Only the accept and ignore dialogs decide
+)
+
+var quickActionModes = []string{quickInstant, quickConfirm, quickOff}
+
+// quickTarget names what a quick decision would apply to, or "" when the
+// key does nothing where the focus is. inContent is set for the keys that
+// also work while viewing a file.
+func quickTarget(app *AppState, inContent bool) string {
+	if app.ActivePane == "tree" {
+		if file := selectedTreeFile(app); file != "" {
+			return displayPath(app, file)
+		}
+		if files := selectedGroupFiles(app); len(files) > 0 {
+			if app.TreeViewType == "purls" {
+				return "the " + groupLabel(app, files)
+			}
+			return fmt.Sprintf("the %d files of this upstream group", len(files))
+		}
+		return ""
+	}
+	if app.ViewMode == "content" {
+		if inContent && app.CurrentFile != "" {
+			return displayPath(app, app.CurrentFile)
+		}
+		return ""
+	}
+	if app.SelectedFileIndex >= 0 && app.SelectedFileIndex < len(app.CurrentFileList) {
+		return displayPath(app, app.CurrentFileList[app.SelectedFileIndex])
+	}
+	return ""
+}
+
+// guardQuickAction runs a quick decision as quick_actions allows: at once,
+// after a confirming key, or not at all
+func guardQuickAction(g *gocui.Gui, app *AppState, decision string, inContent bool, action func() error) error {
+	if isAuditDialogOpen(g) {
+		return nil
+	}
+	target := quickTarget(app, inContent)
+	if target == "" || app.ProcessingQuickAction {
+		return action()
+	}
+
+	switch app.QuickActions {
+	case quickOff:
+		return showErrorDialog(g, app, "Quick Actions", fmt.Sprintf("Quick decisions are off (quick_actions=off in %s). Use [a] or [i] to decide with the dialog.", getConfigFilePath()))
+	case quickConfirm:
+		return showQuickConfirmDialog(g, app, decision, target, action)
+	}
+	return action()
+}
+
+// showQuickConfirmDialog asks for one key before a quick decision is taken
+func showQuickConfirmDialog(g *gocui.Gui, app *AppState, decision, target string, action func() error) error {
+	v, err := setDialogView(g, "quick_confirm")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "CONFIRM"
+	v.Frame = true
+	v.Editable = false
+	v.TitleColor = gocui.ColorYellow
+	v.BgColor = gocui.ColorBlack
+	v.FgColor = gocui.ColorYellow
+	v.Clear()
+	fmt.Fprintf(v, " Mark %s as %s?\n\n", target, decisionLabel(app, decision))
+	fmt.Fprintf(v, " Y or ENTER: Confirm  N or ESC: Cancel")
+
+	if _, err := g.SetCurrentView("quick_confirm"); err != nil {
+		return err
+	}
+	announce(app, "Mark %s as %s? Y confirms, N cancels", target, decisionLabel(app, decision))
+
+	confirm := func(g *gocui.Gui, v *gocui.View) error {
+		closeQuickConfirmDialog(g, app)
+		return action()
+	}
+	cancel := func(g *gocui.Gui, v *gocui.View) error {
+		announce(app, "Cancelled")
+		return closeQuickConfirmDialog(g, app)
+	}
+	g.DeleteKeybindings("quick_confirm")
+	for _, key := range []interface{}{'y', 'Y', gocui.KeyEnter} {
+		g.SetKeybinding("quick_confirm", key, gocui.ModNone, confirm)
+	}
+	for _, key := range []interface{}{'n', 'N', gocui.KeyEsc} {
+		g.SetKeybinding("quick_confirm", key, gocui.ModNone, cancel)
+	}
+	return nil
+}
+
+func closeQuickConfirmDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("quick_confirm")
+	g.DeleteView("quick_confirm")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// apiQuota is the remaining SCANOSS API allowance, as last reported by the
+// server
+type apiQuota struct {
+	Limit     int64 // -1 when not reported
+	Remaining int64
+	Reset     string // When the allowance renews, as reported
+}
+
+// quotaFromHeaders reads the rate limit headers of an API response, or
+// returns nil when the server doesn't send them
+func quotaFromHeaders(header http.Header) *apiQuota {
+	remaining, err := strconv.ParseInt(strings.TrimSpace(header.Get("X-RateLimit-Remaining")), 10, 64)
+	if err != nil {
+		return nil
+	}
+	quota := &apiQuota{Limit: -1, Remaining: remaining, Reset: header.Get("X-RateLimit-Reset")}
+	if limit, err := strconv.ParseInt(strings.TrimSpace(header.Get("X-RateLimit-Limit")), 10, 64); err == nil {
+		quota.Limit = limit
+	}
+	return quota
+}
+
+// quotaFromBody reads a JSON usage document such as
+// {"limit": 5000, "remaining": 4200, "reset": "2025-07-01"}
+func quotaFromBody(data []byte) *apiQuota {
+	var usage struct {
+		Limit     *int64 `json:"limit"`
+		Remaining *int64 `json:"remaining"`
+		Reset     string `json:"reset"`
+	}
+	if json.Unmarshal(data, &usage) != nil || usage.Remaining == nil {
+		return nil
+	}
+	quota := &apiQuota{Limit: -1, Remaining: *usage.Remaining, Reset: usage.Reset}
+	if usage.Limit != nil {
+		quota.Limit = *usage.Limit
+	}
+	return quota
+}
+
+// fetchQuota asks the configured quota_url for the remaining allowance
+func fetchQuota(ctx context.Context, quotaURL, apiKey string) (*apiQuota, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+	req, err := http.NewRequestWithContext(ctx, "GET", quotaURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("X-API-Key", apiKey)
+
+	resp, err := network.do(client, req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusTooManyRequests {
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if quota := quotaFromHeaders(resp.Header); quota != nil {
+		return quota, nil
+	}
+	if quota := quotaFromBody(data); quota != nil {
+		return quota, nil
+	}
+	return nil, fmt.Errorf("the response reports no remaining quota")
+}
+
+// startQuotaCheck queries quota_url in the background and shows the result
+// in the status pane. Later API responses keep it up to date.
+func startQuotaCheck(g *gocui.Gui, app *AppState) {
+	quotaURL, apiKey := app.QuotaURL, apiKeyFor(app, app.QuotaURL)
+	if quotaURL == "" || apiKey == "" {
+		return
+	}
+	task := startBackgroundTask(g, app, "API quota check")
+	go func() {
+		quota, err := fetchQuota(task.ctx, quotaURL, apiKey)
+		g.Update(func(g *gocui.Gui) error {
+			finishTask(g, app, task, err)
+			if err != nil {
+				return nil
+			}
+			app.Quota = quota
+			updateStatus(g, app)
+			if quotaLow(quota) {
+				announce(app, "Only %d API requests remain", quota.Remaining)
+			}
+			return nil
+		})
+	}()
+}
+
+// quotaLow reports whether less than a tenth of the allowance remains
+func quotaLow(quota *apiQuota) bool {
+	if quota == nil {
+		return false
+	}
+	if quota.Limit > 0 {
+		return quota.Remaining*10 < quota.Limit
+	}
+	return quota.Remaining < 100
+}
+
+// quotaStatus describes the remaining allowance for the status pane
+func quotaStatus(app *AppState) string {
+	quota := app.Quota
+	if quota == nil {
+		return ""
+	}
+	text := strconv.FormatInt(quota.Remaining, 10)
+	if quota.Limit >= 0 {
+		text += "/" + strconv.FormatInt(quota.Limit, 10)
+	}
+	if quotaLow(quota) {
+		return " | \033[1mAPI quota:\033[0m \033[31m" + text + " left\033[0m"
+	}
+	return " | \033[1mAPI quota:\033[0m \033[37m" + text + " left\033[0m"
+}
+
+// quotaWarning returns a warning when an operation needing about requests
+// API calls could exhaust the remaining allowance, or "" when it fits or the
+// quota is unknown
+func quotaWarning(app *AppState, requests int) string {
+	quota := app.Quota
+	if quota == nil || int64(requests) < quota.Remaining {
+		return ""
+	}
+	warning := fmt.Sprintf("WARNING: about %d API requests needed but only %d remain", requests, quota.Remaining)
+	if quota.Reset != "" {
+		warning += " (resets " + quota.Reset + ")"
+	}
+	return warning
+}
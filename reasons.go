@@ -0,0 +1,93 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Reasons are predefined comments for the accept and ignore dialogs, set
+// with accept_reason.<n> and ignore_reason.<n> and picked with keys 1-9
+type Reasons [9]string
+
+// addConfigReason records an accept_reason.<n> or ignore_reason.<n> entry
+func addConfigReason(config *Config, reasons *Reasons, key, name, text string) {
+	n, err := strconv.Atoi(name)
+	if err != nil || n < 1 || n > len(reasons) {
+		config.Warnings = append(config.Warnings, fmt.Sprintf("invalid %s (use a number from 1 to 9 after the dot)", key))
+		return
+	}
+	reasons[n-1] = text
+}
+
+// configReasonLines writes reasons back as config lines
+func configReasonLines(prefix string, reasons Reasons) string {
+	var lines strings.Builder
+	for i, text := range reasons {
+		if text != "" {
+			fmt.Fprintf(&lines, "%s.%d=%s\n", prefix, i+1, text)
+		}
+	}
+	return lines.String()
+}
+
+// pendingReasons returns the reasons for the decision the dialog is open for
+func pendingReasons(app *AppState) Reasons {
+	switch app.PendingDecision {
+	case audit.DecisionIgnored:
+		return app.IgnoreReasons
+	case audit.DecisionIdentified:
+		return app.AcceptReasons
+	}
+	return Reasons{}
+}
+
+// showReasonList lists the reasons under the accept or ignore dialog and
+// binds their number keys in the comment field. A number fills in its
+// reason when the comment is still empty and is typed as usual otherwise.
+func showReasonList(g *gocui.Gui, app *AppState) error {
+	reasons := pendingReasons(app)
+	if reasons == (Reasons{}) {
+		return nil
+	}
+
+	v, err := setDialogView(g, "audit_reasons")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "Reasons (press the number)"
+	v.Frame = true
+	v.Editable = false
+	v.BgColor = gocui.ColorBlack
+	v.FgColor = gocui.ColorYellow
+	v.Clear()
+	for i, text := range reasons {
+		if text != "" {
+			fmt.Fprintf(v, " %d: %s\n", i+1, text)
+		}
+	}
+
+	for i, text := range reasons {
+		key := rune('1' + i)
+		reason := text
+		g.SetKeybinding("audit_input", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			if reason == "" || strings.TrimSpace(v.Buffer()) != "" {
+				v.EditWrite(key)
+				return nil
+			}
+			for _, ch := range reason {
+				v.EditWrite(ch)
+			}
+			announce(app, "Comment: %s", reason)
+			return nil
+		})
+	}
+	return nil
+}
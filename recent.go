@@ -0,0 +1,191 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// recentLimit is how many results files are remembered
+const recentLimit = 10
+
+// userStatePath is where the state kept across audits, such as the recently
+// opened results files, is stored next to the config file
+func userStatePath() string {
+	return strings.TrimSuffix(getConfigFilePath(), ".ini") + ".state.json"
+}
+
+// rememberResultsFile puts the results file at the top of the recently
+// opened ones
+func rememberResultsFile(resultsPath string) error {
+	if abs, err := filepath.Abs(resultsPath); err == nil {
+		resultsPath = abs
+	}
+	state, err := audit.LoadUserState(userStatePath())
+	if err != nil {
+		return err
+	}
+	state.AddRecent(resultsPath, time.Now().UTC(), recentLimit)
+	return audit.SaveUserState(userStatePath(), state)
+}
+
+// recentResultsFiles lists the recently opened results files that are still
+// there, last opened first
+func recentResultsFiles() []audit.RecentFile {
+	state, err := audit.LoadUserState(userStatePath())
+	if err != nil {
+		return nil
+	}
+	var recent []audit.RecentFile
+	for _, file := range state.Recent {
+		if info, err := os.Stat(file.Path); err == nil && !info.IsDir() {
+			recent = append(recent, file)
+		}
+	}
+	return recent
+}
+
+// browseEntry ends the list of recent files, to look for another one
+const browseEntry = "Browse for another results file…"
+
+// recentPicker lists the recent results files to resume an audit from when
+// auditcmd is launched without one, and switches to the file browser when
+// none of them is wanted
+type recentPicker struct {
+	files      []audit.RecentFile
+	list       *ScrollableList
+	accessible bool
+	browser    *fileBrowser
+	chosen     string
+}
+
+func newRecentPicker(files []audit.RecentFile, accessible bool) *recentPicker {
+	// Timestamps follow timestamp_format and time_zone like everywhere else
+	settings := &AppState{}
+	config, _ := loadConfig()
+	applyConfig(settings, config)
+
+	items := make([]string, len(files))
+	for i, file := range files {
+		items[i] = fmt.Sprintf("%s  \033[90m%s\033[0m", cellSafe(file.Path), formatTimestamp(settings, file.Opened))
+	}
+	list := NewScrollableList(append(items, browseEntry))
+	list.Plain = accessible
+	return &recentPicker{files: files, list: list, accessible: accessible}
+}
+
+// chooseResultsFile offers the recently opened results files to pick one
+// from, or the file browser when there are none. It returns "" when none
+// was picked or the terminal can't show the list, and the usage is printed
+// instead.
+func chooseResultsFile(accessible bool) string {
+	files := recentResultsFiles()
+
+	// The picker reads the config, whose keys are decrypted later
+	secretPrompts = false
+	defer func() { secretPrompts = true }()
+	g, err := gocui.NewGui(gocui.OutputNormal, true)
+	if err != nil {
+		return ""
+	}
+	defer g.Close()
+	if accessible {
+		g.ASCII = true
+		g.Cursor = true
+	}
+
+	picker := newRecentPicker(files, accessible)
+	g.SetManagerFunc(picker.layout)
+	if err := picker.keybindings(g); err != nil {
+		return ""
+	}
+	if len(files) == 0 {
+		if err := picker.browse(g); err != nil {
+			return ""
+		}
+	}
+	if err := g.MainLoop(); err != nil && !errors.Is(err, gocui.ErrQuit) {
+		return ""
+	}
+	if picker.browser != nil {
+		return picker.browser.chosen
+	}
+	return picker.chosen
+}
+
+// browse replaces the recent files with the file browser, starting in the
+// current directory
+func (p *recentPicker) browse(g *gocui.Gui) error {
+	g.DeleteKeybindings("recent_files")
+	g.DeleteView("recent_files")
+	p.browser = newFileBrowser(".", p.accessible)
+	return p.browser.keybindings(g)
+}
+
+func (p *recentPicker) layout(g *gocui.Gui) error {
+	if p.browser != nil {
+		return p.browser.layout(g)
+	}
+	maxX, maxY := g.Size()
+	height := min(len(p.list.Items), maxY-4)
+	v, err := g.SetView("recent_files", maxX/8, maxY/2-height/2-1, 7*maxX/8, maxY/2+height/2+1, 0)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "Resume an audit (ENTER: Open, ESC: Quit)"
+	p.list.Render(v, true)
+	if _, err := g.SetCurrentView("recent_files"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// keybindings binds the keys of the recent files, and quitting for both
+// the recent files and the browser
+func (p *recentPicker) keybindings(g *gocui.Gui) error {
+	navigation := map[gocui.Key]func(){
+		gocui.KeyArrowUp:   func() { p.list.Navigate("up") },
+		gocui.KeyArrowDown: func() { p.list.Navigate("down") },
+		gocui.KeyHome:      func() { p.list.Navigate("first") },
+		gocui.KeyEnd:       func() { p.list.Navigate("last") },
+		gocui.KeyPgup:      func() { p.list.NavigatePage("up") },
+		gocui.KeyPgdn:      func() { p.list.NavigatePage("down") },
+	}
+	for key, navigate := range navigation {
+		navigate := navigate
+		if err := g.SetKeybinding("recent_files", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			navigate()
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	if err := g.SetKeybinding("recent_files", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		index := p.list.GetSelectedIndex()
+		if index >= len(p.files) {
+			return p.browse(g)
+		}
+		p.chosen = p.files[index].Path
+		return gocui.ErrQuit
+	}); err != nil {
+		return err
+	}
+	for _, key := range []any{gocui.KeyEsc, gocui.KeyCtrlC, 'q', 'Q'} {
+		if err := g.SetKeybinding("", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			return gocui.ErrQuit
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"strings"
+
+	"auditcmd/pkg/audit"
+)
+
+// redactSalt is generated once per run so redacted names are stable within a
+// session but can't be reversed by hashing well-known directory names
+var redactSalt = newRedactSalt()
+
+func newRedactSalt() []byte {
+	salt := make([]byte, 16)
+	rand.Read(salt)
+	return salt
+}
+
+// redactToken replaces a value with a short salted hash
+func redactToken(value string) string {
+	if value == "" {
+		return ""
+	}
+	h := sha256.New()
+	h.Write(redactSalt)
+	h.Write([]byte(value))
+	return hex.EncodeToString(h.Sum(nil))[:8]
+}
+
+// redactComponent hashes a single path element but keeps its extension so
+// redacted file lists still show which languages are involved
+func redactComponent(name string) string {
+	if name == "" || name == "." || name == ".." {
+		return name
+	}
+	ext := path.Ext(name)
+	if ext == name {
+		ext = ""
+	}
+	return redactToken(strings.TrimSuffix(name, ext)) + ext
+}
+
+// redactPath hashes every element of a path, preserving the directory structure
+func redactPath(filePath string) string {
+	parts := strings.Split(audit.NormalizePath(filePath), "/")
+	for i, part := range parts {
+		parts[i] = redactComponent(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// redactPURL keeps the package type and version but hashes the namespace and name
+func redactPURL(purl string) string {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return redactToken(purl)
+	}
+	rest := strings.TrimPrefix(purl, "pkg:")
+	slash := strings.Index(rest, "/")
+	if slash < 0 {
+		return "pkg:" + redactToken(rest)
+	}
+	purlType, name := rest[:slash], rest[slash+1:]
+
+	version := ""
+	if at := strings.Index(name, "@"); at >= 0 {
+		name, version = name[:at], name[at:]
+	}
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		parts[i] = redactToken(part)
+	}
+	return "pkg:" + purlType + "/" + strings.Join(parts, "/") + version
+}
+
+// displayPath returns the file path as it should be shown to the user, with
+// forward slashes, without a leading "./" and ready for the terminal grid
+func displayPath(app *AppState, filePath string) string {
+	if app.Redact {
+		return redactPath(filePath)
+	}
+	return cellSafe(audit.NormalizePath(filePath))
+}
+
+// displayPURL returns the PURL as it should be shown to the user
+func displayPURL(app *AppState, purl string) string {
+	if app.Redact {
+		return redactPURL(purl)
+	}
+	return cellSafe(purl)
+}
+
+// displayURL hides URLs that reveal the matched component when redacting
+func displayURL(app *AppState, url string) string {
+	if app.Redact && url != "" {
+		return "redacted:" + redactToken(url)
+	}
+	return url
+}
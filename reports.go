@@ -0,0 +1,205 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"auditcmd/pkg/audit"
+)
+
+// openReportOutput returns where a report subcommand writes: the --output
+// file, or stdout
+func openReportOutput(opts *Options) (io.Writer, func(), error) {
+	if opts.Output == "" {
+		return os.Stdout, func() {}, nil
+	}
+	file, err := os.Create(opts.Output)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create file: %v", err)
+	}
+	return file, func() { file.Close() }, nil
+}
+
+// loadReportScan loads the results a report subcommand runs on, without the
+// files excluded in the config or with --exclude, or outside --dir
+func loadReportScan(opts *Options) (*audit.ScanResult, error) {
+	scan, err := audit.Load(opts.CommandArgs[0])
+	if err != nil {
+		return nil, err
+	}
+	if audit.IsDependencyDocument(scan) {
+		scan, _ = audit.ExpandDependencies(scan)
+	}
+	globs := opts.Exclude
+	if config, err := loadConfig(); err == nil {
+		globs = append(config.Exclude, globs...)
+	}
+	scan.Exclude(audit.CompileExcludes(globs))
+	scan.KeepDirectory(opts.Dir)
+	return scan, nil
+}
+
+// runObligations implements "auditcmd obligations results.json" and returns
+// the exit code, see outcomeExitCode
+func runObligations(opts *Options) int {
+	scan, err := loadReportScan(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return loadExitCode(err)
+	}
+
+	obligations := audit.CollectObligations(scan)
+	// loadConfig falls back to the defaults when the file can't be read
+	config, _ := loadConfig()
+	if config.ProjectLicense != "" {
+		audit.MarkConflicts(obligations, config.ProjectLicense)
+	}
+	audit.MarkStale(obligations, scan, staleThresholds(config), time.Now())
+	for _, o := range obligations {
+		if o.ChecklistURL == "" {
+			o.ChecklistError = "no OSADL checklist for this license"
+			continue
+		}
+		checklist, err := audit.FetchChecklist(o.ChecklistURL)
+		if err != nil {
+			o.ChecklistError = err.Error()
+			fmt.Fprintf(os.Stderr, "Warning: checklist for %s unavailable: %v\n", o.License, err)
+			continue
+		}
+		audit.ApplyChecklist(o, checklist)
+	}
+
+	out, closeOut, err := openReportOutput(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	defer closeOut()
+
+	if opts.Format == "csv" {
+		err = audit.WriteObligationsCSV(out, obligations)
+	} else {
+		err = audit.WriteObligationsMarkdown(out, obligations)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	return outcomeExitCode(scan)
+}
+
+// runCopyrights implements "auditcmd copyrights results.json" and returns
+// the exit code, see outcomeExitCode
+func runCopyrights(opts *Options) int {
+	scan, err := loadReportScan(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return loadExitCode(err)
+	}
+	components := audit.CollectCopyrights(scan)
+
+	out, closeOut, err := openReportOutput(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	defer closeOut()
+
+	if opts.Format == "md" {
+		err = audit.WriteCopyrightsMarkdown(out, components)
+	} else {
+		err = audit.WriteCopyrightsText(out, components)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	return outcomeExitCode(scan)
+}
+
+// runCrypto implements "auditcmd crypto results.json" and returns the exit
+// code, see outcomeExitCode
+func runCrypto(opts *Options) int {
+	scan, err := loadReportScan(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return loadExitCode(err)
+	}
+	findings := audit.CollectCrypto(scan)
+
+	out, closeOut, err := openReportOutput(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	defer closeOut()
+
+	if opts.Format == "csv" {
+		err = audit.WriteCryptoCSV(out, findings)
+	} else {
+		err = audit.WriteCryptoMarkdown(out, findings)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	return outcomeExitCode(scan)
+}
+
+// runSARIF implements "auditcmd sarif results.json" and returns the exit
+// code, see outcomeExitCode
+func runSARIF(opts *Options) int {
+	scan, err := loadReportScan(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return loadExitCode(err)
+	}
+
+	out, closeOut, err := openReportOutput(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	defer closeOut()
+
+	config, _ := loadConfig()
+	if err := audit.ExportSARIFStale(out, scan, staleThresholds(config)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	return outcomeExitCode(scan)
+}
+
+// runBadge implements "auditcmd badge results.json". It exits with 0 once
+// the badge is written: a badge is published while work is still pending.
+func runBadge(opts *Options) int {
+	scan, err := loadReportScan(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return loadExitCode(err)
+	}
+
+	out, closeOut, err := openReportOutput(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	defer closeOut()
+
+	badge := audit.ProgressBadge(scan)
+	if opts.Format == "json" {
+		err = audit.WriteBadgeJSON(out, badge)
+	} else {
+		err = audit.WriteBadgeSVG(out, badge)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+	return exitClean
+}
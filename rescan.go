@@ -0,0 +1,288 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// defaultRescanCommand is used when rescan_command isn't configured. It runs
+// in the --source directory; {path} is relative to it.
+const defaultRescanCommand = "scanoss-py scan --output {output} {path}"
+
+// rescanKeyVariable passes the API key to the scanner. The environment keeps
+// it off the command line, where other users can see it.
+const rescanKeyVariable = "SCANOSS_API_KEY"
+
+// rescanTarget returns the file or directory to re-scan for the current
+// selection, as a path relative to the source directory
+func rescanTarget(app *AppState) (target string, isDir bool, ok bool) {
+	if app.ActivePane == "files" {
+		filePath := focusedFile(app)
+		return filePath, false, filePath != ""
+	}
+	node := app.TreeState.selectedNode
+	if file := treeFile(app, node); file != "" {
+		return file, false, true
+	}
+	if app.TreeViewType != "directories" || node == nil || !node.IsDir {
+		return "", false, false
+	}
+	if node.Path == "" {
+		return ".", true, true
+	}
+	return node.Path, true, true
+}
+
+// rescanCommandLine fills in the rescan command template
+func rescanCommandLine(app *AppState, target, output string) string {
+	template := app.RescanCommand
+	if template == "" {
+		template = defaultRescanCommand
+	}
+	fields := map[string]string{
+		"path":   target,
+		"output": output,
+	}
+	return expandTemplate(template, fields, shellQuote)
+}
+
+// runRescan scans target in the source directory and returns the results
+// keyed by the paths used in the loaded scan. Cancelling ctx stops the
+// scanner.
+func runRescan(ctx context.Context, app *AppState, target string, isDir bool) (*audit.ScanResult, error) {
+	output, err := os.CreateTemp("", "auditcmd-rescan-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %v", err)
+	}
+	output.Close()
+	defer os.Remove(output.Name())
+
+	cmd := shellCommand(ctx, rescanCommandLine(app, target, output.Name()))
+	cmd.Dir = app.SourceDir
+	if app.APIKey != "" {
+		cmd.Env = append(os.Environ(), rescanKeyVariable+"="+app.APIKey)
+	}
+	// Processes the scanner started may keep stderr open once it is stopped
+	cmd.WaitDelay = time.Second
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%v: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	fresh, err := audit.Load(output.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scan output: %v", err)
+	}
+	return remapRescanPaths(app, fresh, target, isDir), nil
+}
+
+// remapRescanPaths rewrites the keys of a re-scan so they match the loaded
+// results. Scanners report paths relative to the scanned directory, and the
+// results may use Windows separators.
+func remapRescanPaths(app *AppState, fresh *audit.ScanResult, target string, isDir bool) *audit.ScanResult {
+	existing := make(map[string]string, len(app.ScanData.Files))
+	for filePath := range app.ScanData.Files {
+		existing[audit.NormalizePath(filePath)] = filePath
+	}
+
+	normalizedTarget := strings.TrimPrefix(audit.NormalizePath(target), "./")
+	mapped := &audit.ScanResult{Files: make(map[string][]FileMatch)}
+	for key, matches := range fresh.Files {
+		relative := strings.TrimPrefix(audit.NormalizePath(key), "./")
+		switch {
+		case !isDir:
+			// A single file scan has one entry, whatever the scanner called it
+			relative = normalizedTarget
+		case normalizedTarget != "." && !strings.HasPrefix(relative, normalizedTarget+"/"):
+			relative = path.Join(normalizedTarget, relative)
+		}
+
+		if original, ok := existing[relative]; ok {
+			mapped.Files[original] = matches
+		} else {
+			mapped.Files[relative] = matches
+		}
+	}
+	return mapped
+}
+
+// rebuildTree rebuilds the directory tree and PURL ranking after files were
+// added or changed, keeping the current selection where possible
+func rebuildTree(app *AppState) {
+	selectedPath, selectedName := "", ""
+	if node := app.TreeState.selectedNode; node != nil {
+		selectedPath, selectedName = node.Path, node.Name
+		if treeFile(app, node) != "" {
+			selectedPath = node.Parent.Path
+		}
+	}
+
+	buildFileTree(app)
+	buildPURLRanking(app)
+
+	// In the PURL view the nodes are rebuilt with the view, which selects
+	// the new node with the selected node's path
+	if app.TreeViewType == "upstream" {
+		app.TreeState.selectedNode = nil
+		for i, group := range app.UpstreamGroups {
+			if group.PURL == selectedName {
+				app.TreeState.selectedNode = upstreamNode(app, i)
+				break
+			}
+		}
+		if app.TreeState.selectedNode == nil && len(app.UpstreamGroups) > 0 {
+			app.TreeState.selectedNode = upstreamNode(app, 0)
+		}
+	} else if app.TreeViewType != "purls" {
+		app.TreeState.selectedNode = findTreeNode(app.FileTree, selectedPath)
+	}
+	updateTreeDisplay(app)
+}
+
+// findTreeNode returns the directory node with the given path, or the first
+// top-level directory if it no longer exists
+func findTreeNode(root *TreeNode, dirPath string) *TreeNode {
+	var search func(node *TreeNode) *TreeNode
+	search = func(node *TreeNode) *TreeNode {
+		for _, child := range node.Children {
+			if child.Path == dirPath && child.Name != "." {
+				return child
+			}
+			if found := search(child); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	if found := search(root); found != nil {
+		return found
+	}
+	if len(root.Children) > 0 {
+		return root.Children[0]
+	}
+	return root
+}
+
+// showRescanDialog asks for confirmation, then re-scans the selected file or
+// directory and merges the new matches into the audit
+func showRescanDialog(g *gocui.Gui, app *AppState) error {
+	if app.SourceDir == "" {
+		return showErrorDialog(g, app, "Re-scan", "No source directory configured. Start auditcmd with --source <dir> pointing at the scanned checkout.")
+	}
+	if app.Dependencies != nil {
+		return showErrorDialog(g, app, "Re-scan", "Re-scanning isn't available when auditing dependency results.")
+	}
+	target, isDir, ok := rescanTarget(app)
+	if !ok {
+		return showErrorDialog(g, app, "Re-scan", "Select a file, or a directory in the Directories view, to re-scan.")
+	}
+
+	v, err := setDialogView(g, "rescan_dialog")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "RE-SCAN"
+	v.Frame = true
+	v.Editable = false
+	v.TitleColor = gocui.ColorYellow
+	v.BgColor = gocui.ColorBlack
+	v.FgColor = gocui.ColorYellow
+	v.Clear()
+	fmt.Fprintf(v, " Re-scan %s\n", displayPath(app, target))
+	fmt.Fprintf(v, " Matches are replaced; decisions are kept where the component is unchanged\n")
+	// The scanner makes about one API request per file
+	requests := 1
+	if target == "." {
+		requests = len(app.ScanData.Files)
+	} else if isDir {
+		requests = len(audit.FilesInDirectory(&app.ScanData, target, audit.FilterAll))
+	}
+	if warning := quotaWarning(app, requests); warning != "" {
+		fmt.Fprintf(v, " %s\n", warning)
+	} else {
+		fmt.Fprintf(v, "\n")
+	}
+	fmt.Fprintf(v, " ENTER: Scan  ESC: Cancel")
+
+	if _, err := g.SetCurrentView("rescan_dialog"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("rescan_dialog")
+	g.SetKeybinding("rescan_dialog", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		closeRescanDialog(g, app)
+		task := startTask(g, app, "Re-scan "+displayPath(app, target))
+		task.Message = "Scanning..."
+		if err := showProgressDialog(g, app, "RE-SCAN", task.Name, task); err != nil {
+			return err
+		}
+
+		go func() {
+			fresh, err := runRescan(task.ctx, app, target, isDir)
+			g.Update(func(g *gocui.Gui) error {
+				finishTask(g, app, task, err)
+				closeProgressDialog(g, app, task)
+				if task.Err == context.Canceled {
+					announce(app, "Re-scan cancelled, no matches changed")
+					return nil
+				}
+				if err != nil {
+					return showErrorDialog(g, app, "Re-scan", fmt.Sprintf("Re-scan failed: %v", err))
+				}
+
+				fresh.Exclude(app.ExcludePatterns)
+				fresh.KeepDirectory(app.RootDir)
+				updated := app.ScanData.Merge(fresh)
+				app.CurrentMatch = nil
+				if err := saveToFile(app); err != nil {
+					return showErrorDialog(g, app, "Save Error", fmt.Sprintf("Re-scan merged but saving failed: %v", err))
+				}
+				rebuildTree(app)
+				displayTree(g, app)
+				updateFileList(g, app)
+				updateStatus(g, app)
+				updateHelpBar(g, app)
+				startSourceVerification(g, app)
+				announce(app, "Re-scanned %d files in %s", len(updated), displayPath(app, target))
+				return nil
+			})
+		}()
+		return nil
+	})
+	g.SetKeybinding("rescan_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeRescanDialog(g, app)
+	})
+
+	return nil
+}
+
+func closeRescanDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("rescan_dialog")
+	g.DeleteView("rescan_dialog")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"os"
+	"os/user"
+	"strings"
+
+	"auditcmd/pkg/audit"
+)
+
+// reviewerIdentity returns the configured reviewer, falling back to the
+// login name so second opinions are never recorded anonymously
+func reviewerIdentity(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	return os.Getenv("USER")
+}
+
+// reviewInScope reports whether a file is in the re-audit queue. Outside
+// review mode every file is.
+func reviewInScope(app *AppState, filePath string) bool {
+	return !app.ReviewMode || audit.NeedsReview(app.ScanData.Files[filePath], app.Reviewer)
+}
+
+// reviewLabel describes the second opinion behind the latest decision,
+// e.g. " | Reviewed by alice, overriding IGNORED", or "" if there is none
+func reviewLabel(match *FileMatch) string {
+	previous := audit.ReviewedDecision(match)
+	if previous == nil {
+		return ""
+	}
+	latest := match.LatestDecision()
+	verdict := "overriding"
+	if strings.EqualFold(strings.TrimSpace(latest.Decision), strings.TrimSpace(previous.Decision)) {
+		verdict = "confirming"
+	}
+	return " | Reviewed by " + latest.Reviewer + ", " + verdict + " " + strings.ToUpper(previous.Decision)
+}
@@ -5,17 +5,20 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/awesome-gocui/gocui"
 )
 
 // ScrollableList represents a scrollable list component
 type ScrollableList struct {
-	Items           []string
-	SelectedIndex   int
-	ScrollOffset    int
-	ViewHeight      int
-	ShowScrollbar   bool
+	Items         []string
+	SelectedIndex int
+	ScrollOffset  int
+	ViewHeight    int
+	ShowScrollbar bool
+	Plain         bool        // Mark the selection with "> " instead of colour
+	View          *gocui.View // Where the list was last rendered; pages follow its height
 }
 
 // NewScrollableList creates a new scrollable list
@@ -38,11 +41,23 @@ func (sl *ScrollableList) SetItems(items []string) {
 	sl.adjustScroll()
 }
 
-// Navigate moves the selection up or down
+// syncHeight takes the height of the view the list is shown in, which
+// changes when the terminal or the panes are resized
+func (sl *ScrollableList) syncHeight() {
+	if sl.View == nil {
+		return
+	}
+	if _, height := sl.View.Size(); height > 0 {
+		sl.ViewHeight = height
+	}
+}
+
+// Navigate moves the selection up or down, or to the first or last item
 func (sl *ScrollableList) Navigate(direction string) {
 	if len(sl.Items) == 0 {
 		return
 	}
+	sl.syncHeight()
 
 	switch direction {
 	case "up":
@@ -53,17 +68,22 @@ func (sl *ScrollableList) Navigate(direction string) {
 		if sl.SelectedIndex < len(sl.Items)-1 {
 			sl.SelectedIndex++
 		}
+	case "first":
+		sl.SelectedIndex = 0
+	case "last":
+		sl.SelectedIndex = len(sl.Items) - 1
 	}
 	sl.adjustScroll()
 }
 
-// NavigatePage moves by a page
+// NavigatePage moves by a page of the view as it is on screen
 func (sl *ScrollableList) NavigatePage(direction string) {
 	if len(sl.Items) == 0 {
 		return
 	}
+	sl.syncHeight()
 
-	pageSize := sl.ViewHeight - 1
+	pageSize := max(sl.ViewHeight-1, 1)
 	switch direction {
 	case "up":
 		newIndex := sl.SelectedIndex - pageSize
@@ -81,6 +101,19 @@ func (sl *ScrollableList) NavigatePage(direction string) {
 	sl.adjustScroll()
 }
 
+// JumpToPercent selects the item percent of the way down the list, the
+// first at 0 and the last at 100
+func (sl *ScrollableList) JumpToPercent(percent int) {
+	if len(sl.Items) == 0 {
+		return
+	}
+	sl.syncHeight()
+
+	percent = min(max(percent, 0), 100)
+	sl.SelectedIndex = percent * (len(sl.Items) - 1) / 100
+	sl.adjustScroll()
+}
+
 // adjustScroll ensures the selected item is visible
 func (sl *ScrollableList) adjustScroll() {
 	if len(sl.Items) == 0 {
@@ -114,8 +147,9 @@ func (sl *ScrollableList) adjustScroll() {
 func (sl *ScrollableList) Render(v *gocui.View, isActive bool) {
 	v.Clear()
 	
-	_, viewHeight := v.Size()
-	sl.ViewHeight = viewHeight
+	sl.View = v
+	sl.syncHeight()
+	viewWidth, _ := v.Size()
 	
 	if len(sl.Items) == 0 {
 		return
@@ -129,36 +163,52 @@ func (sl *ScrollableList) Render(v *gocui.View, isActive bool) {
 		endIndex = len(sl.Items)
 	}
 	
+	// The scrollbar takes the rightmost column, next to the items
+	scrollbar := sl.ShowScrollbar && !sl.Plain && len(sl.Items) > sl.ViewHeight && viewWidth > 2
+	itemWidth := viewWidth
+	if scrollbar {
+		itemWidth--
+	}
+
 	for i := sl.ScrollOffset; i < endIndex; i++ {
-		item := sl.Items[i]
+		// Wide characters take two cells, so cut by width rather than length
+		item := fitWidth(sl.Items[i], itemWidth)
+
+		// Plain mode uses a text marker so the selection survives without colour
+		if sl.Plain {
+			marker := "  "
+			if i == sl.SelectedIndex {
+				marker = "> "
+			}
+			fmt.Fprintf(v, "%s%s\n", marker, fitWidth(stripANSI(sl.Items[i]), viewWidth-2))
+			continue
+		}
 		
 		// Highlight selected item if this pane is active
 		if i == sl.SelectedIndex && isActive {
-			fmt.Fprintf(v, "\033[43m\033[30m%s\033[0m\n", item)
-		} else {
-			fmt.Fprintf(v, "%s\n", item)
+			item = "\033[43m\033[30m" + item + "\033[0m"
 		}
-	}
-	
-	// Add scrollbar if needed
-	if sl.ShowScrollbar && len(sl.Items) > sl.ViewHeight {
-		sl.renderScrollbar(v)
+		if scrollbar {
+			item += strings.Repeat(" ", max(itemWidth-displayWidth(item), 0)) + sl.scrollbarCell(i-sl.ScrollOffset)
+		}
+		fmt.Fprintf(v, "%s\n", item)
 	}
 }
 
-// renderScrollbar draws a simple scrollbar on the right side
-func (sl *ScrollableList) renderScrollbar(v *gocui.View) {
-	viewWidth, viewHeight := v.Size()
-	if viewWidth < 2 || viewHeight < 3 {
-		return
+// scrollbarCell is the scrollbar in row of the view: the thumb, sized and
+// placed like the visible part of the list, or the track
+func (sl *ScrollableList) scrollbarCell(row int) string {
+	total := len(sl.Items)
+	thumbSize := max(sl.ViewHeight*sl.ViewHeight/total, 1)
+	thumbStart := sl.ScrollOffset * sl.ViewHeight / total
+	if sl.ScrollOffset+sl.ViewHeight >= total {
+		// Keep the thumb at the bottom once the end is in view
+		thumbStart = sl.ViewHeight - thumbSize
 	}
-	
-	// Simple scroll indicator
-	totalItems := len(sl.Items)
-	if totalItems > sl.ViewHeight {
-		scrollInfo := fmt.Sprintf("[%d/%d]", sl.SelectedIndex+1, totalItems)
-		fmt.Fprintf(v, "\033[0;0H\033[K%s", scrollInfo) // Move to top right and show info
+	if row >= thumbStart && row < thumbStart+thumbSize {
+		return "\033[37m█\033[0m"
 	}
+	return "\033[90m│\033[0m"
 }
 
 // GetSelectedItem returns the currently selected item
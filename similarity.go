@@ -0,0 +1,80 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// defaultSimilarityThreshold is the path similarity below which [m] shows a
+// match as a likely false positive, unless similarity_threshold is set
+const defaultSimilarityThreshold = 50
+
+// similarityMarker shows how much of the local path matches the OSS path
+func similarityMarker(app *AppState, similarity int) string {
+	if similarity < 0 {
+		return ""
+	}
+	if app.Accessible {
+		return fmt.Sprintf(" [path %d%%]", similarity)
+	}
+	if similarity < app.SimilarityThreshold {
+		return fmt.Sprintf(" \033[31m%d%%\033[0m", similarity)
+	}
+	return fmt.Sprintf(" \033[90m%d%%\033[0m", similarity)
+}
+
+// lowSimilarity reports whether a file's match path is below the threshold
+func lowSimilarity(app *AppState, filePath string) bool {
+	similarity := audit.MatchSimilarity(filePath, app.ScanData.Files[filePath])
+	return similarity >= 0 && similarity < app.SimilarityThreshold
+}
+
+// sortBySimilarity orders files from the least to the most similar match
+// path, keeping files without a match last
+func sortBySimilarity(app *AppState, files []string) []string {
+	sorted := make([]string, len(files))
+	copy(sorted, files)
+	similarity := make(map[string]int, len(files))
+	for _, filePath := range files {
+		similarity[filePath] = audit.MatchSimilarity(filePath, app.ScanData.Files[filePath])
+		if similarity[filePath] < 0 {
+			similarity[filePath] = 101
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return similarity[sorted[i]] < similarity[sorted[j]]
+	})
+	return sorted
+}
+
+// cycleSimilarityMode switches the file list between path order, least
+// similar match paths first, and only the matches below the similarity
+// threshold
+func cycleSimilarityMode(g *gocui.Gui, app *AppState) error {
+	switch app.SimilarityMode {
+	case "":
+		app.SimilarityMode = "sort"
+	case "sort":
+		app.SimilarityMode = "low"
+	default:
+		app.SimilarityMode = ""
+	}
+
+	refreshScope(g, app)
+	switch app.SimilarityMode {
+	case "sort":
+		announce(app, "Files ordered by path similarity, least similar first")
+	case "low":
+		announce(app, "Showing only matches with path similarity below %d%%, %d items", app.SimilarityThreshold, len(app.TreeList.Items))
+	default:
+		announce(app, "Files ordered by path, %d items", len(app.TreeList.Items))
+	}
+	return nil
+}
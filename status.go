@@ -5,8 +5,13 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
 
 	"github.com/awesome-gocui/gocui"
 )
@@ -18,24 +23,58 @@ func updateStatus(g *gocui.Gui, app *AppState) error {
 	}
 
 	v.Clear()
+	requestProvenance(g, app)
 
+	var out strings.Builder
 	if app.CurrentMatch != nil {
-		displayFileStatus(v, app.CurrentMatch)
+		displayFileStatus(&out, app, app.CurrentMatch)
+	} else if match := highlightedMatch(app); match != nil {
+		displayFileStatus(&out, app, match)
 	} else if app.TreeState != nil && app.TreeState.selectedNode != nil {
 		// Show directory status for both directory nodes and PURL nodes
-		displayDirectoryStatus(v, app)
+		displayDirectoryStatus(&out, app)
+	}
+
+	text := out.String()
+	if app.Accessible {
+		text = stripANSI(text)
 	}
+	fmt.Fprint(v, text)
 
 	return nil
 }
 
-func displayFileStatus(v *gocui.View, match *FileMatch) {
+// highlightedMatch returns the match of the file highlighted in the Files
+// pane or selected in the tree, so its details follow the selection before
+// it is opened
+func highlightedMatch(app *AppState) *FileMatch {
+	if file := selectedTreeFile(app); file != "" {
+		return audit.FirstValidMatch(app.ScanData.Files[file])
+	}
+	if app.ActivePane != "files" || app.ViewMode != "list" {
+		return nil
+	}
+	if app.SelectedFileIndex < 0 || app.SelectedFileIndex >= len(app.CurrentFileList) {
+		return nil
+	}
+	return audit.FirstValidMatch(app.ScanData.Files[app.CurrentFileList[app.SelectedFileIndex]])
+}
+
+func displayFileStatus(v io.Writer, app *AppState, match *FileMatch) {
 	// Line 1: Type, component
 	component := ""
 	if len(match.Purl) > 0 {
-		component = match.Purl[0]
+		component = displayPURL(app, match.Purl[0])
+	}
+	if app.Offline {
+		fmt.Fprintf(v, "%s | ", offlineBanner)
 	}
 	fmt.Fprintf(v, "\033[1mType:\033[0m \033[37m%s\033[0m | \033[1mComponent:\033[0m \033[37m%s\033[0m", strings.ToUpper(match.ID), component)
+	if len(match.Purl) > 0 {
+		if countries := provenanceCountries(app, match.Purl[0]); countries != "" {
+			fmt.Fprintf(v, " | \033[1mOrigin:\033[0m \033[37m%s\033[0m", countries)
+		}
+	}
 	
 	// Add licenses to line 1
 	if len(match.Licenses) > 0 {
@@ -46,20 +85,48 @@ func displayFileStatus(v *gocui.View, match *FileMatch) {
 		licenses := strings.Join(licenseNames, ", ")
 		fmt.Fprintf(v, " | \033[1mLicenses:\033[0m \033[37m%s\033[0m", licenses)
 	}
+	if score, percent := audit.BestQuality(match); score != "" {
+		color := "37"
+		if percent < app.QualityThreshold {
+			color = "31"
+		}
+		fmt.Fprintf(v, " | \033[1mQuality:\033[0m \033[%sm%s\033[0m", color, score)
+	}
+	if conflicts := audit.LicenseConflicts(app.ProjectLicense, match); len(conflicts) > 0 {
+		fmt.Fprintf(v, " | \033[31mINCOMPATIBLE with %s: %s\033[0m", app.ProjectLicense, strings.Join(conflicts, "; "))
+	}
+	if reasons := audit.StaleReasons(match, app.Stale, time.Now()); len(reasons) > 0 {
+		fmt.Fprintf(v, " | \033[33mSTALE COMPONENT: %s\033[0m", strings.Join(reasons, ", "))
+	}
+	if candidates := candidateSummary(app, focusedFile(app)); candidates != "" {
+		fmt.Fprintf(v, " | \033[33mCONFLICTING MATCHES: %s\033[0m", candidates)
+	}
 	fmt.Fprintf(v, "\n")
 	
 	// Line 2: Audit status
-	auditStatus := "PENDING"
+	auditStatus := strings.ToUpper(stateLabel(app, audit.StatusPending))
 	assessment := ""
-	if len(match.AuditCmd) > 0 {
-		latest := match.AuditCmd[len(match.AuditCmd)-1]
-		auditStatus = strings.ToUpper(latest.Decision)
+	if latest := match.LatestDecision(); latest != nil {
+		auditStatus = strings.ToUpper(decisionLabel(app, latest.Decision))
 		if latest.Assessment != "" {
-			assessment = " (" + latest.Assessment + ")"
+			assessment = " (" + oneLine(latest.Assessment) + ")"
+		}
+		if decided := formatTimestamp(app, latest.Timestamp); decided != "" {
+			assessment += " on " + decided
 		}
 	}
 	
-	fmt.Fprintf(v, "\033[1mAudit:\033[0m \033[37m%s%s\033[0m", auditStatus, assessment)
+	fmt.Fprintf(v, "\033[1mAudit:\033[0m \033[37m%s%s%s\033[0m", auditStatus, assessment, reviewLabel(match)+ignoreListLabel(match)+checklistLabel(match))
+
+	// The status field other SCANOSS tools read and write; it only differs
+	// from the audit state when another tool changed it since the last save
+	if status := strings.TrimSpace(match.Status); status != "" {
+		if audit.StatusAgrees(match) {
+			fmt.Fprintf(v, " | \033[1mStatus field:\033[0m \033[37m%s\033[0m", status)
+		} else {
+			fmt.Fprintf(v, " | \033[1mStatus field:\033[0m \033[33m%s, set by another tool\033[0m", status)
+		}
+	}
 	
 	// Add Lines field for snippet matches
 	if match.ID == "snippet" {
@@ -71,70 +138,186 @@ func displayFileStatus(v *gocui.View, match *FileMatch) {
 	
 	// Add Path field showing the full matched file path
 	if match.File != "" {
-		fmt.Fprintf(v, " | \033[1mPath:\033[0m \033[37m%s\033[0m", match.File)
+		fmt.Fprintf(v, " | \033[1mPath:\033[0m \033[37m%s\033[0m", displayPath(app, match.File))
+	}
+
+	// Warn when the local file no longer matches what was scanned
+	if kind := app.Drift[focusedFile(app)]; kind != "" {
+		fmt.Fprintf(v, " | \033[1mLocal:\033[0m \033[31m%s since scan\033[0m", kind)
+	}
+
+	// Add issues filed for this finding
+	if tickets := match.Tickets(); len(tickets) > 0 {
+		fmt.Fprintf(v, " | \033[1mIssues:\033[0m \033[37m%s\033[0m", strings.Join(tickets, ", "))
 	}
 	
 	fmt.Fprintf(v, "\n")
 }
 
-func displayDirectoryStatus(v *gocui.View, app *AppState) {
-	totalFilesInData := len(app.ScanData.Files)
-	matchingFiles := 0
-	fileMatches := 0
-	snippetMatches := 0
-	pendingFiles := 0
-	identifiedFiles := 0
-	ignoredFiles := 0
-
-	// Count files with valid matches (file or snippet)
-	for _, matches := range app.ScanData.Files {
-		for _, match := range matches {
-			// Only count files with id = "file" or "snippet"
-			if match.ID != "file" && match.ID != "snippet" {
-				continue
-			}
-			
-			matchingFiles++
-			
-			// Count by match type
-			if match.ID == "file" {
-				fileMatches++
-			} else if match.ID == "snippet" {
-				snippetMatches++
-			}
-			
-			if len(match.AuditCmd) > 0 {
-				latest := match.AuditCmd[len(match.AuditCmd)-1]
-				if latest.Decision == "identified" {
-					identifiedFiles++
-				} else if latest.Decision == "ignored" {
-					ignoredFiles++
-				} else {
-					pendingFiles++
-				}
-			} else {
-				pendingFiles++
+// oneLine puts a comment written in the multi-line editor on one line, its
+// lines joined by a space, for places that show it in a single line
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// uniqueSorted returns the distinct non-empty values, sorted
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool)
+	unique := make([]string, 0)
+	for _, value := range values {
+		if value != "" && !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+// displayPURLStatus describes the selected component in PURL mode: its
+// versions, licenses and release dates, and the audit state of its files
+func displayPURLStatus(v io.Writer, app *AppState, node *TreeNode) {
+	var versions, licenses, released []string
+	for _, filePath := range node.Files {
+		match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+		if match == nil {
+			continue
+		}
+		versions = append(versions, match.Version)
+		released = append(released, match.ReleaseDate)
+		for _, license := range match.Licenses {
+			licenses = append(licenses, license.Name)
+		}
+	}
+	versions, licenses, released = uniqueSorted(versions), uniqueSorted(licenses), uniqueSorted(released)
+
+	// Line 1: Component details
+	if app.Offline {
+		fmt.Fprintf(v, "%s | ", offlineBanner)
+	}
+	fmt.Fprintf(v, "\033[1mComponent:\033[0m \033[37m%s\033[0m", displayPURL(app, node.Name))
+	if countries := provenanceCountries(app, node.Name); countries != "" {
+		fmt.Fprintf(v, " | \033[1mOrigin:\033[0m \033[37m%s\033[0m", countries)
+	}
+	if app.TreeViewType == "upstream" && len(node.Files) > 0 {
+		if match := audit.FirstValidMatch(app.ScanData.Files[node.Files[0]]); match != nil {
+			fmt.Fprintf(v, " | \033[1mUpstream file:\033[0m \033[37m%s\033[0m", displayPath(app, match.File))
+		}
+	}
+	if len(versions) > 0 {
+		fmt.Fprintf(v, " | \033[1mVersions:\033[0m \033[37m%s\033[0m", strings.Join(versions, ", "))
+	}
+	if len(licenses) > 0 {
+		fmt.Fprintf(v, " | \033[1mLicenses:\033[0m \033[37m%s\033[0m", strings.Join(licenses, ", "))
+	}
+	switch len(released) {
+	case 0:
+	case 1:
+		fmt.Fprintf(v, " | \033[1mReleased:\033[0m \033[37m%s\033[0m", released[0])
+	default:
+		fmt.Fprintf(v, " | \033[1mReleased:\033[0m \033[37m%s to %s\033[0m", released[0], released[len(released)-1])
+	}
+	if stats := audit.ComponentStats(&app.ScanData, node.Files); stats != (URLStats{}) {
+		fmt.Fprintf(v, " | \033[1mSize:\033[0m \033[37m%s\033[0m", componentSize(stats))
+	}
+
+	// Line 2: Audit state of the component's files
+	summary := audit.SummarizeFiles(&app.ScanData, node.Files)
+	fmt.Fprintf(v, "\n\033[1mFiles:\033[0m \033[37m%d\033[0m (\033[37m%d file / %d snippet\033[0m) | \033[1m%s:\033[0m \033[37m%d\033[0m | \033[1m%s:\033[0m \033[37m%d\033[0m | \033[1m%s:\033[0m \033[37m%d\033[0m | \033[1m%s:\033[0m \033[37m%d\033[0m", summary.MatchingFiles, summary.FileMatches, summary.SnippetMatches, stateTitle(app, audit.StatusPending), summary.Pending, stateTitle(app, audit.StatusIdentified), summary.Identified, stateTitle(app, audit.StatusIgnored), summary.Ignored, stateTitle(app, audit.StatusDeferred), summary.Deferred)
+}
+
+// componentSize describes url_stats, e.g. "1,204 files indexed (980
+// source, 24 ignored), 5.6 MB"
+func componentSize(stats URLStats) string {
+	size := fmt.Sprintf("%s files indexed (%s source, %s ignored)", thousands(stats.IndexedFiles), thousands(stats.SourceFiles), thousands(stats.IgnoredFiles))
+	if stats.PackageSize > 0 {
+		size += ", " + byteSize(stats.PackageSize)
+	}
+	return size
+}
+
+// thousands formats n with "," separators
+func thousands(n int) string {
+	digits := strconv.Itoa(n)
+	for i := len(digits) - 3; i > 0 && digits[i-1] != '-'; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits
+}
+
+// byteSize formats a size in bytes as B, KB, MB or GB
+func byteSize(size int) string {
+	value := float64(size)
+	for _, unit := range []string{"B", "KB", "MB"} {
+		if value < 1024 {
+			if unit == "B" {
+				return fmt.Sprintf("%d B", size)
 			}
-			break // Only count first valid match per file
+			return fmt.Sprintf("%.1f %s", value, unit)
 		}
+		value /= 1024
+	}
+	return fmt.Sprintf("%.1f GB", value)
+}
+
+func displayDirectoryStatus(v io.Writer, app *AppState) {
+	if groupedView(app) {
+		displayPURLStatus(v, app, app.TreeState.selectedNode)
+		return
 	}
 
-	// Calculate no match files
-	noMatchFiles := totalFilesInData - matchingFiles
+	summary := audit.Summarize(&app.ScanData)
 
 	// Line 1: File counts overview
-	fmt.Fprintf(v, "\033[1mTotal Files:\033[0m \033[37m%d\033[0m | \033[1mMatches:\033[0m \033[37m%d\033[0m (\033[37m%d file / %d snippet\033[0m) | \033[1mNo Match:\033[0m \033[37m%d\033[0m", totalFilesInData, matchingFiles, fileMatches, snippetMatches, noMatchFiles)
+	fmt.Fprintf(v, "\033[1mTotal Files:\033[0m \033[37m%d\033[0m | \033[1mMatches:\033[0m \033[37m%d\033[0m (\033[37m%d file / %d snippet\033[0m) | \033[1mNo Match:\033[0m \033[37m%d\033[0m", summary.TotalFiles, summary.MatchingFiles, summary.FileMatches, summary.SnippetMatches, summary.NoMatchFiles)
+	if summary.DependencyMatches > 0 {
+		fmt.Fprintf(v, " | \033[1mDependencies:\033[0m \033[37m%d\033[0m", summary.DependencyMatches)
+	}
+	if app.Delta != nil {
+		fmt.Fprintf(v, " | \033[1mSince baseline:\033[0m \033[37m%d new / %d removed / %d unchanged\033[0m", app.DeltaCounts[audit.DeltaNew], app.DeltaCounts[audit.DeltaRemoved], app.DeltaCounts[audit.DeltaUnchanged])
+		if app.DeltaOnly {
+			fmt.Fprintf(v, " (new only)")
+		}
+	}
+	if app.DriftChecked {
+		fmt.Fprintf(v, " | \033[1mChanged locally:\033[0m \033[37m%d\033[0m", len(app.Drift))
+		if app.DriftOnly {
+			fmt.Fprintf(v, " (shown only)")
+		}
+	}
 	
 	// Line 2: Audit status breakdown and API status
 	apiStatus := "API key \033[1mOK\033[0m"
-	if app.APIKey == "" {
+	if !hasAPIKey(app) {
 		apiStatus = "API key \033[1mNO\033[0m"
 	}
+	if app.Offline {
+		apiStatus = offlineBanner
+	}
+	apiStatus += quotaStatus(app)
 	viewLabel := strings.Title(app.ViewFilter)
+	if app.ViewFilter == audit.FilterPending || app.ViewFilter == audit.FilterDeferred {
+		viewLabel = stateTitle(app, app.ViewFilter)
+	}
 	if app.ViewFilter == "" {
 		viewLabel = "All"
 	}
-	fmt.Fprintf(v, "\n\033[1mPending:\033[0m \033[37m%d\033[0m | \033[1mIdentified:\033[0m \033[37m%d\033[0m | \033[1mIgnored:\033[0m \033[37m%d\033[0m | \033[1mView:\033[0m \033[37m%s\033[0m | %s", pendingFiles, identifiedFiles, ignoredFiles, viewLabel, apiStatus)
+	if app.HideIdentified {
+		viewLabel += fmt.Sprintf(", %s/%s hidden", stateLabel(app, audit.StatusIdentified), stateLabel(app, audit.StatusIgnored))
+	}
+	if app.AssessmentFilter != "" {
+		viewLabel += fmt.Sprintf(", assessment %q", app.AssessmentFilter)
+	}
+	if app.ReviewMode {
+		viewLabel += ", review by " + app.Reviewer
+	}
+	if len(app.Excluded) > 0 {
+		viewLabel += fmt.Sprintf(", %d excluded", len(app.Excluded))
+	}
+	if len(app.Duplicates) > 0 {
+		viewLabel += fmt.Sprintf(", %d duplicate paths merged", len(app.Duplicates))
+	}
+	fmt.Fprintf(v, "\n\033[1m%s:\033[0m \033[37m%d\033[0m | \033[1m%s:\033[0m \033[37m%d\033[0m | \033[1m%s:\033[0m \033[37m%d\033[0m | \033[1m%s:\033[0m \033[37m%d\033[0m | \033[1mView:\033[0m \033[37m%s\033[0m | %s", stateTitle(app, audit.StatusPending), summary.Pending, stateTitle(app, audit.StatusIdentified), summary.Identified, stateTitle(app, audit.StatusIgnored), summary.Ignored, stateTitle(app, audit.StatusDeferred), summary.Deferred, viewLabel, apiStatus)
 }
 
 // formatOSSLines formats the oss_lines field for display in the status pane
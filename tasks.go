@@ -0,0 +1,275 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// maxFinishedTasks is how many finished tasks the Tasks popup keeps
+const maxFinishedTasks = 20
+
+// backgroundTask is a named operation running off the GUI goroutine: an
+// export, a re-scan, a prefetch or an API lookup. The Tasks popup lists
+// the running ones with their progress and the recently finished ones with
+// their errors. Tasks are only touched on the GUI goroutine.
+type backgroundTask struct {
+	Name     string
+	Message  string // What the task is doing now
+	Done     int
+	Total    int   // 0 while the amount of work isn't known
+	Err      error // Why the task failed, once finished
+	Started  time.Time
+	Finished time.Time // Zero while the task runs
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+func (t *backgroundTask) running() bool {
+	return t.Finished.IsZero()
+}
+
+// startTask registers a long-running operation the user waits for, such
+// as an export or a re-scan, which ESC cancels. The operation runs under
+// the task's context, stops as soon as it can once that is cancelled, and
+// reports back with finishTask.
+func startTask(g *gocui.Gui, app *AppState, name string) *backgroundTask {
+	task := startBackgroundTask(g, app, name)
+	app.Task = task
+	return task
+}
+
+// startBackgroundTask registers an operation that runs while the user
+// carries on, such as a prefetch or an API lookup. It can be cancelled from
+// the Tasks popup.
+func startBackgroundTask(g *gocui.Gui, app *AppState, name string) *backgroundTask {
+	ctx, cancel := context.WithCancel(context.Background())
+	task := &backgroundTask{Name: name, Started: time.Now(), ctx: ctx, cancel: cancel}
+	app.Tasks = append(app.Tasks, task)
+	refreshTasks(g, app)
+	return task
+}
+
+// updateTask records what a task is doing and how far it got. A negative
+// done keeps the counts as they are.
+func updateTask(g *gocui.Gui, app *AppState, task *backgroundTask, message string, done, total int) {
+	task.Message = message
+	if done >= 0 {
+		task.Done, task.Total = done, total
+	}
+	if app.Progress != nil && app.Progress.task == task {
+		drawProgressDialog(g, app)
+	}
+	drawTasksDialog(g, app)
+}
+
+// reportTask is updateTask for the goroutine a task runs on
+func reportTask(g *gocui.Gui, app *AppState, task *backgroundTask, message string, done, total int) {
+	g.Update(func(g *gocui.Gui) error {
+		updateTask(g, app, task, message, done, total)
+		return nil
+	})
+}
+
+// finishTask records the outcome of a task. Tasks whose context was
+// cancelled are reported as cancelled, whatever err says.
+func finishTask(g *gocui.Gui, app *AppState, task *backgroundTask, err error) {
+	if !task.running() {
+		return
+	}
+	task.Finished = time.Now()
+	if task.ctx.Err() != nil {
+		err = context.Canceled
+	}
+	task.Err = err
+	task.cancel()
+	if app.Task == task {
+		app.Task = nil
+	}
+
+	// Drop the oldest finished tasks beyond maxFinishedTasks
+	finished := 0
+	kept := make([]*backgroundTask, 0, len(app.Tasks))
+	for i := len(app.Tasks) - 1; i >= 0; i-- {
+		if !app.Tasks[i].running() {
+			if finished++; finished > maxFinishedTasks {
+				continue
+			}
+		}
+		kept = append([]*backgroundTask{app.Tasks[i]}, kept...)
+	}
+	app.Tasks = kept
+	refreshTasks(g, app)
+}
+
+// cancelTask asks the operation the user waits for to stop, reporting
+// whether one was running. The operation cleans up and calls finishTask
+// itself.
+func cancelTask(app *AppState) bool {
+	if app.Task == nil {
+		return false
+	}
+	if app.Task.ctx.Err() == nil {
+		app.Task.cancel()
+		announce(app, "Cancelling %s...", app.Task.Name)
+	}
+	return true
+}
+
+// runningTasks returns the tasks that haven't finished, oldest first
+func runningTasks(app *AppState) []*backgroundTask {
+	running := make([]*backgroundTask, 0)
+	for _, task := range app.Tasks {
+		if task.running() {
+			running = append(running, task)
+		}
+	}
+	return running
+}
+
+// taskStatus is the help bar summary of the running tasks, or ""
+func taskStatus(app *AppState) string {
+	switch n := len(runningTasks(app)); n {
+	case 0:
+		return ""
+	case 1:
+		return "1 task"
+	default:
+		return fmt.Sprintf("%d tasks", n)
+	}
+}
+
+// refreshTasks redraws what shows the tasks after one starts or finishes
+func refreshTasks(g *gocui.Gui, app *AppState) {
+	drawTasksDialog(g, app)
+	if _, err := g.View("help"); err == nil {
+		updateHelpBar(g, app)
+	}
+}
+
+// taskState describes the progress or the outcome of a task
+func taskState(task *backgroundTask) string {
+	switch {
+	case task.running() && task.ctx.Err() != nil:
+		return "cancelling..."
+	case task.running():
+		state := task.Message
+		if task.Total > 0 {
+			state += fmt.Sprintf(" %d%%", task.Done*100/task.Total)
+		}
+		if state == "" {
+			state = "running"
+		}
+		return strings.TrimSpace(state)
+	case task.Err == context.Canceled:
+		return "cancelled"
+	case task.Err != nil:
+		return "failed: " + task.Err.Error()
+	}
+	return "done"
+}
+
+// taskDuration is how long a task ran, or has been running, e.g. "0:05"
+func taskDuration(task *backgroundTask) string {
+	end := task.Finished
+	if task.running() {
+		end = time.Now()
+	}
+	seconds := int(end.Sub(task.Started).Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// showTasksDialog opens the Tasks popup
+func showTasksDialog(g *gocui.Gui, app *AppState) error {
+	if _, err := setDialogView(g, "tasks_dialog"); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	if _, err := g.SetCurrentView("tasks_dialog"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("tasks_dialog")
+	g.SetKeybinding("tasks_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeTasksDialog(g, app)
+	})
+	for n := 0; n < 9; n++ {
+		n := n
+		g.SetKeybinding("tasks_dialog", rune('1'+n), gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			running := runningTasks(app)
+			if n >= len(running) || running[n].ctx.Err() != nil {
+				return nil
+			}
+			running[n].cancel()
+			announce(app, "Cancelling %s...", running[n].Name)
+			return drawTasksDialog(g, app)
+		})
+	}
+
+	running := len(runningTasks(app))
+	announce(app, "Tasks: %d running, %d finished", running, len(app.Tasks)-running)
+	return drawTasksDialog(g, app)
+}
+
+// drawTasksDialog lists the running tasks, which 1-9 cancel, and then the
+// finished ones, newest first
+func drawTasksDialog(g *gocui.Gui, app *AppState) error {
+	v, err := g.View("tasks_dialog")
+	if err != nil {
+		return nil
+	}
+	v.Title = "Tasks"
+	v.Frame = true
+	v.Wrap = false
+	v.Clear()
+
+	running := runningTasks(app)
+	fmt.Fprintf(v, "\n Running\n")
+	if len(running) == 0 {
+		fmt.Fprintf(v, "   none\n")
+	}
+	for n, task := range running {
+		key := " "
+		if n < 9 {
+			key = fmt.Sprintf("%d", n+1)
+		}
+		fmt.Fprintf(v, "   %s  %-28s %5s  %s\n", key, task.Name, taskDuration(task), taskState(task))
+	}
+
+	fmt.Fprintf(v, "\n Finished\n")
+	finished := 0
+	for i := len(app.Tasks) - 1; i >= 0; i-- {
+		task := app.Tasks[i]
+		if task.running() {
+			continue
+		}
+		finished++
+		state := taskState(task)
+		if task.Err != nil && task.Err != context.Canceled && !app.Accessible {
+			state = "\033[31m" + state + "\033[0m"
+		}
+		fmt.Fprintf(v, "      %-28s %5s  %s\n", task.Name, taskDuration(task), state)
+	}
+	if finished == 0 {
+		fmt.Fprintf(v, "   none\n")
+	}
+	fmt.Fprintf(v, "\n 1-9: Cancel that task  ESC: Close")
+	return nil
+}
+
+func closeTasksDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("tasks_dialog")
+	g.DeleteView("tasks_dialog")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
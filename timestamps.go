@@ -0,0 +1,36 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import "time"
+
+// defaultTimestampFormat is used when timestamp_format isn't set
+const defaultTimestampFormat = "2006-01-02 15:04 MST"
+
+// formatTimestamp renders a stored (UTC) timestamp in the configured time
+// zone and format. Unknown times, such as decisions adopted from another
+// tool, render as "".
+func formatTimestamp(app *AppState, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	location := app.TimeZone
+	if location == nil {
+		location = time.Local
+	}
+	layout := app.TimestampFormat
+	if layout == "" {
+		layout = defaultTimestampFormat
+	}
+	return t.In(location).Format(layout)
+}
+
+// timestampLayout returns the Go layout for a timestamp_format value:
+// "rfc3339" or a layout written with Go's reference time
+func timestampLayout(value string) string {
+	if value == "rfc3339" {
+		return time.RFC3339
+	}
+	return value
+}
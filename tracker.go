@@ -0,0 +1,230 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Default templates used when the config doesn't provide its own
+const (
+	defaultTicketTitle = "OSS review: {path} matches {purl}"
+	defaultTicketBody  = "File: {path}\nComponent: {purl}\nLicense: {license}\nMatched file: {file}\nLines: {lines} (OSS lines {oss_lines})\nDecision: {decision}\nAssessment: {assessment}\nLink: {deeplink}\n\nCreated by auditcmd from {results}"
+)
+
+// TrackerConfig describes where findings are filed as issues
+type TrackerConfig struct {
+	Type          string // "github" or "jira"
+	URL           string // API base URL
+	Project       string // owner/repo for GitHub, project key for Jira
+	User          string // Jira user for basic auth (optional)
+	Token         string
+	IssueType     string // Jira issue type, defaults to "Task"
+	TitleTemplate string
+	BodyTemplate  string
+}
+
+// Configured reports whether enough settings are present to create issues
+func (t TrackerConfig) Configured() bool {
+	return (t.Type == "github" || t.Type == "jira") && t.Project != "" && t.Token != ""
+}
+
+// Label names the target for dialogs, e.g. "GitHub issue in owner/repo"
+func (t TrackerConfig) Label() string {
+	if t.Type == "jira" {
+		return fmt.Sprintf("Jira issue in %s", t.Project)
+	}
+	return fmt.Sprintf("GitHub issue in %s", t.Project)
+}
+
+// createdTicket identifies the issue returned by the tracker
+type createdTicket struct {
+	ID  string
+	URL string
+}
+
+// createTicket files an issue and returns its ID and browser URL
+func createTicket(tracker TrackerConfig, title, body string) (*createdTicket, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	switch tracker.Type {
+	case "github":
+		baseURL := tracker.URL
+		if baseURL == "" {
+			baseURL = "https://api.github.com"
+		}
+		payload := map[string]string{"title": title, "body": body}
+		url := fmt.Sprintf("%s/repos/%s/issues", strings.TrimRight(baseURL, "/"), tracker.Project)
+
+		var result struct {
+			Number  int    `json:"number"`
+			HTMLURL string `json:"html_url"`
+		}
+		if err := postJSON(client, url, payload, "Bearer "+tracker.Token, "", &result); err != nil {
+			return nil, err
+		}
+		return &createdTicket{ID: fmt.Sprintf("%s#%d", tracker.Project, result.Number), URL: result.HTMLURL}, nil
+
+	case "jira":
+		if tracker.URL == "" {
+			return nil, fmt.Errorf("tracker_url is required for Jira")
+		}
+		issueType := tracker.IssueType
+		if issueType == "" {
+			issueType = "Task"
+		}
+		payload := map[string]interface{}{
+			"fields": map[string]interface{}{
+				"project":     map[string]string{"key": tracker.Project},
+				"summary":     title,
+				"description": body,
+				"issuetype":   map[string]string{"name": issueType},
+			},
+		}
+		baseURL := strings.TrimRight(tracker.URL, "/")
+		auth := "Bearer " + tracker.Token
+
+		var result struct {
+			Key string `json:"key"`
+		}
+		if err := postJSON(client, baseURL+"/rest/api/2/issue", payload, auth, tracker.User, &result); err != nil {
+			return nil, err
+		}
+		return &createdTicket{ID: result.Key, URL: baseURL + "/browse/" + result.Key}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported tracker_type %q (use github or jira)", tracker.Type)
+}
+
+// postJSON sends payload and decodes the response into result. When user is
+// set, HTTP basic auth with the token as password is used instead of auth.
+func postJSON(client *http.Client, url string, payload interface{}, auth, user string, result interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if user != "" {
+		req.SetBasicAuth(user, strings.TrimPrefix(auth, "Bearer "))
+	} else {
+		req.Header.Set("Authorization", auth)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("API error %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, result)
+}
+
+// showTicketDialog asks for confirmation before filing an issue for the
+// selected file
+func showTicketDialog(g *gocui.Gui, app *AppState) error {
+	if app.ActivePane != "files" {
+		return nil
+	}
+	filePath := focusedFile(app)
+	match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+	if match == nil {
+		return nil
+	}
+	if !app.Tracker.Configured() {
+		return showErrorDialog(g, app, "Issue Tracker", "No issue tracker configured. Set tracker_type, tracker_project and tracker_token in "+getConfigFilePath()+".")
+	}
+
+	v, err := setDialogView(g, "ticket_dialog")
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = "CREATE Issue"
+	v.Frame = true
+	v.Editable = false
+	v.TitleColor = gocui.ColorYellow
+	v.BgColor = gocui.ColorBlack
+	v.FgColor = gocui.ColorYellow
+	v.Clear()
+	fmt.Fprintf(v, " %s\n", app.Tracker.Label())
+	fmt.Fprintf(v, " File: %s\n", displayPath(app, filePath))
+	if tickets := match.Tickets(); len(tickets) > 0 {
+		fmt.Fprintf(v, " Existing: %s\n", strings.Join(tickets, ", "))
+	} else {
+		fmt.Fprintf(v, "\n")
+	}
+	fmt.Fprintf(v, " ENTER: Create  ESC: Cancel")
+
+	if _, err := g.SetCurrentView("ticket_dialog"); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings("ticket_dialog")
+	g.SetKeybinding("ticket_dialog", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		g.DeleteKeybindings("ticket_dialog")
+		v.Clear()
+		fmt.Fprintf(v, " %s\n File: %s\n\n Creating issue...", app.Tracker.Label(), displayPath(app, filePath))
+
+		fields := findingFields(app, filePath, match, true)
+		title := expandTemplate(app.Tracker.TitleTemplate, fields, func(s string) string { return s })
+		body := expandTemplate(app.Tracker.BodyTemplate, fields, func(s string) string { return s })
+		tracker := app.Tracker
+
+		go func() {
+			ticket, err := createTicket(tracker, title, body)
+			g.Update(func(g *gocui.Gui) error {
+				closeTicketDialog(g, app)
+				if err != nil {
+					return showErrorDialog(g, app, "Issue Tracker", fmt.Sprintf("Failed to create issue: %v", err))
+				}
+				match.AddNote("issue created", ticket.ID, ticket.URL)
+				announce(app, "Created issue %s for %s", ticket.ID, displayPath(app, filePath))
+				if err := saveToFile(app); err != nil {
+					return showErrorDialog(g, app, "Save Error", fmt.Sprintf("Issue %s created but saving failed: %v", ticket.ID, err))
+				}
+				return nil
+			})
+		}()
+		return nil
+	})
+	g.SetKeybinding("ticket_dialog", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return closeTicketDialog(g, app)
+	})
+
+	return nil
+}
+
+func closeTicketDialog(g *gocui.Gui, app *AppState) error {
+	g.DeleteKeybindings("ticket_dialog")
+	g.DeleteView("ticket_dialog")
+
+	if app.ActivePane == "tree" {
+		g.SetCurrentView("tree")
+	} else {
+		g.SetCurrentView("files")
+	}
+	return nil
+}
@@ -5,9 +5,12 @@ package main
 
 import (
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 
+	"auditcmd/pkg/audit"
+
 	"github.com/awesome-gocui/gocui"
 )
 
@@ -23,20 +26,18 @@ func initTreeState(app *AppState) {
 	if app.TreeViewType == "purls" {
 		// In PURL mode, select first PURL if available
 		if len(app.PURLRanking) > 0 {
-			app.TreeState.selectedNode = &TreeNode{
-				Name:  app.PURLRanking[0].PURL,
-				Path:  "purl_0",
-				IsDir: false,
-				Files: app.PURLRanking[0].Files,
-			}
+			app.TreeState.selectedNode = purlNode(app.PURLRanking[0])
 		}
+	} else if app.TreeViewType == "upstream" {
+		// Selected below, once the groups with files to show are known
+		app.TreeState.selectedNode = nil
 	} else {
 		// In directory mode, intelligently select initial directory
 		if len(app.FileTree.Children) > 0 {
 			selectedNode := app.FileTree.Children[0] // Default to first child
 			
 			// If we're in "matched" or "pending" mode, try to find a directory with matching files
-			if app.ViewFilter == "matched" || app.ViewFilter == "pending" {
+			if app.ViewFilter != "all" {
 				for _, child := range app.FileTree.Children {
 					if child.IsDir {
 						fileCount := countFilesInDirectory(child.Path)
@@ -55,6 +56,24 @@ func initTreeState(app *AppState) {
 	}
 	
 	updateTreeDisplay(app)
+	if app.TreeState.selectedNode == nil && len(app.TreeState.displayLines) > 0 {
+		app.TreeState.selectedNode = app.TreeState.displayLines[0].Node
+	}
+}
+
+// startInView sets the tree view given with --view. Like switching with
+// the keys, the component views show matched files rather than all files,
+// unless --filter asked for all of them. It returns a warning when the view
+// can't be shown.
+func startInView(app *AppState, view string, filterGiven bool) string {
+	if view == "upstream" && len(app.UpstreamGroups) == 0 {
+		return "no open source file is matched by more than one local file; starting in the directory view"
+	}
+	app.TreeViewType = view
+	if groupedView(app) && app.ViewFilter == audit.FilterAll && !filterGiven {
+		app.ViewFilter = audit.FilterMatched
+	}
+	return ""
 }
 
 func updateTreeDisplay(app *AppState) {
@@ -62,7 +81,14 @@ func updateTreeDisplay(app *AppState) {
 	
 	if app.TreeViewType == "purls" {
 		buildPURLDisplay(app)
+	} else if app.TreeViewType == "upstream" {
+		buildUpstreamDisplay(app)
 	} else {
+		app.TreeState.pendingDirs = nil
+		if app.TreeOrder == "pending" {
+			app.TreeState.pendingDirs = pendingByDirectory(app)
+		}
+		app.TreeState.selectedNode = displayedDir(app, app.TreeState.selectedNode)
 		buildTreeDisplay(app.FileTree, 0, app.TreeState)
 	}
 	
@@ -89,12 +115,26 @@ func updateTreeDisplay(app *AppState) {
 
 func buildTreeDisplay(node *TreeNode, indent int, state *TreeState) {
 	if node.Name == "Root" {
-		for _, child := range node.Children {
+		children := node.Children
+		if state.pendingDirs != nil {
+			children = sortTreeChildren(children, state)
+		}
+		for _, child := range children {
 			buildTreeDisplay(child, indent, state)
 		}
 		return
 	}
 
+	// With flatten_dirs a chain of single subdirectories shares one line,
+	// which stands for the directory at its bottom
+	displayName := node.Name
+	if globalApp != nil && globalApp.Redact && node.Path != "" {
+		displayName = redactComponent(node.Name)
+	}
+	if node.IsDir && globalApp != nil && globalApp.FlattenDirs {
+		displayName, node = dirLabel(globalApp, node)
+	}
+
 	prefix := strings.Repeat("  ", indent)
 	symbol := ""
 	
@@ -109,12 +149,14 @@ func buildTreeDisplay(node *TreeNode, indent int, state *TreeState) {
 	}
 
 	// Add file count for directories based on audited filter setting
-	displayName := node.Name
 	fileCount := 0
 	if node.IsDir {
 		fileCount = countFilesInDirectory(node.Path)
 		if fileCount > 0 {
-			displayName = fmt.Sprintf("%s (%d)", node.Name, fileCount)
+			displayName = fmt.Sprintf("%s (%d)", displayName, fileCount)
+		}
+		if pending := state.pendingDirs[node.Path]; state.pendingDirs != nil && fileCount > 0 && globalApp.ViewFilter != "pending" {
+			displayName = fmt.Sprintf("%s [%d pending]", displayName, pending)
 		}
 	}
 
@@ -131,77 +173,241 @@ func buildTreeDisplay(node *TreeNode, indent int, state *TreeState) {
 	})
 
 	if node.IsDir && state.expandedDirs[node.Path] {
-		sortedChildren := make([]*TreeNode, len(node.Children))
-		copy(sortedChildren, node.Children)
-		sort.Slice(sortedChildren, func(i, j int) bool {
-			if sortedChildren[i].IsDir != sortedChildren[j].IsDir {
-				return sortedChildren[i].IsDir
-			}
-			return sortedChildren[i].Name < sortedChildren[j].Name
-		})
-
-		for _, child := range sortedChildren {
+		for _, child := range sortTreeChildren(node.Children, state) {
 			buildTreeDisplay(child, indent+1, state)
 		}
+		if globalApp != nil && globalApp.TreeFiles {
+			appendTreeFiles(globalApp, node, indent+1, state)
+		}
 	}
 }
 
-func buildPURLDisplay(app *AppState) {
-	for i, purlEntry := range app.PURLRanking {
-		// Calculate count based on HideIdentified setting
-		count := 0
-		for _, filePath := range purlEntry.Files {
-			matches, exists := app.ScanData.Files[filePath]
-			if !exists {
-				continue
-			}
-			
-			// Find the first valid match (file or snippet)
-			for _, match := range matches {
-				if match.ID == "file" || match.ID == "snippet" {
-					isProcessed := len(match.AuditCmd) > 0
-					
-					switch app.ViewFilter {
-					case "matched":
-						// Count all files with valid matches
-						count++
-					case "pending":
-						// Count only unprocessed files
-						if !isProcessed {
-							count++
-						}
-					case "all":
-						// Count all files with valid matches
-						count++
-					default:
-						count++
-					}
-					break // Only count first valid match per file
-				}
+// sortTreeChildren orders directories before files, then by name or, when
+// ordering by pending work, by pending files descending
+func sortTreeChildren(children []*TreeNode, state *TreeState) []*TreeNode {
+	sorted := make([]*TreeNode, len(children))
+	copy(sorted, children)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].IsDir != sorted[j].IsDir {
+			return sorted[i].IsDir
+		}
+		if state.pendingDirs != nil && sorted[i].IsDir {
+			pi, pj := state.pendingDirs[sorted[i].Path], state.pendingDirs[sorted[j].Path]
+			if pi != pj {
+				return pi > pj
 			}
 		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// pendingByDirectory counts the pending files in every directory, including
+// those in its subdirectories, in one pass over the results
+func pendingByDirectory(app *AppState) map[string]int {
+	counts := make(map[string]int)
+	for filePath, matches := range app.ScanData.Files {
+		if !audit.MatchesFilter(matches, audit.FilterPending) || !inScope(app, filePath) {
+			continue
+		}
+		dir := path.Dir(audit.NormalizePath(filePath))
+		for dir != "." && dir != "/" {
+			counts[dir]++
+			dir = path.Dir(dir)
+		}
+	}
+	return counts
+}
+
+// sortPURLRankingByPending orders the PURL ranking by pending files in
+// scope, most first, then by matched files, and returns the pending counts
+// by PURL
+func sortPURLRankingByPending(app *AppState) map[string]int {
+	pending := make(map[string]int, len(app.PURLRanking))
+	for _, entry := range app.PURLRanking {
+		pending[entry.PURL] = audit.CountFiles(&app.ScanData, filterScope(app, entry.Files), audit.FilterPending)
+	}
+	sort.SliceStable(app.PURLRanking, func(i, j int) bool {
+		a, b := app.PURLRanking[i], app.PURLRanking[j]
+		if pending[a.PURL] != pending[b.PURL] {
+			return pending[a.PURL] > pending[b.PURL]
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.PURL < b.PURL
+	})
+	return pending
+}
+
+// refreshPendingOrder redraws a tree ordered by pending work after a
+// decision, so components and directories move as their work gets done
+func refreshPendingOrder(g *gocui.Gui, app *AppState) {
+	if app.TreeState == nil {
+		return
+	}
+	switch {
+	case app.TreeViewType == "purls" && app.PURLOrder == "pending":
+	case app.TreeViewType == "directories" && app.TreeOrder == "pending":
+	default:
+		return
+	}
+	updateTreeDisplay(app)
+	displayTree(g, app)
+}
+
+// togglePURLOrder switches the PURL view between most pending files first
+// and the order it had before, most matched files or largest component
+func togglePURLOrder(g *gocui.Gui, app *AppState) error {
+	if app.PURLOrder == "pending" {
+		app.PURLOrder = app.PURLOrderBefore
+		if app.PURLOrder == "" {
+			app.PURLOrder = "files"
+		}
+	} else {
+		app.PURLOrderBefore = app.PURLOrder
+		app.PURLOrder = "pending"
+	}
+	savePURLOrder(app.PURLOrder)
+	buildPURLRanking(app)
+
+	updateTreeDisplay(app)
+	displayTree(g, app)
+	switch app.PURLOrder {
+	case "pending":
+		announce(app, "Components ordered by pending files")
+	case "size":
+		announce(app, "Components ordered by package size")
+	default:
+		announce(app, "Components ordered by matched files")
+	}
+	return nil
+}
+
+// toggleTreeOrder switches the directory tree between alphabetical order and
+// most pending work first, or the PURL view, see togglePURLOrder
+func toggleTreeOrder(g *gocui.Gui, app *AppState) error {
+	if app.TreeViewType == "purls" {
+		return togglePURLOrder(g, app)
+	}
+	if app.TreeViewType != "directories" {
+		return nil
+	}
+	if app.TreeOrder == "pending" {
+		app.TreeOrder = "name"
+	} else {
+		app.TreeOrder = "pending"
+	}
+	saveTreeOrder(app.TreeOrder)
+
+	updateTreeDisplay(app)
+	displayTree(g, app)
+	if app.TreeOrder == "pending" {
+		announce(app, "Directories ordered by pending files")
+	} else {
+		announce(app, "Directories ordered by name")
+	}
+	return nil
+}
+
+// purlNode returns the tree node for a ranked component. Components
+// matched in several versions expand, like directories, to one node per
+// version.
+func purlNode(entry PURLRankEntry) *TreeNode {
+	return &TreeNode{
+		Name:  entry.PURL,
+		Path:  "purl:" + entry.PURL,
+		IsDir: len(entry.Versions) > 1,
+		Files: entry.Files,
+	}
+}
+
+// purlVersionNode returns the tree node for one version of a component,
+// named purl@version so the status pane can describe it
+func purlVersionNode(parent *TreeNode, version PURLVersion) *TreeNode {
+	name := parent.Name
+	if version.Version != "" {
+		name += "@" + version.Version
+	}
+	return &TreeNode{
+		Name:   name,
+		Path:   parent.Path + "@" + version.Version,
+		Files:  version.Files,
+		Parent: parent,
+	}
+}
+
+// appendPURLLine adds a component or version line to the tree. Nodes are
+// rebuilt with the view, so the selection follows the node's path.
+func appendPURLLine(app *AppState, node *TreeNode, indent int, line string) {
+	if selected := app.TreeState.selectedNode; selected != nil && selected.Path == node.Path {
+		app.TreeState.selectedNode = node
+	}
+	app.TreeState.displayLines = append(app.TreeState.displayLines, TreeDisplayLine{
+		Node:   node,
+		Indent: indent,
+		Line:   line,
+	})
+}
+
+func buildPURLDisplay(app *AppState) {
+	var pending map[string]int
+	if app.PURLOrder == "pending" {
+		pending = sortPURLRankingByPending(app)
+	}
+	for _, purlEntry := range app.PURLRanking {
+		// Calculate count based on the view filter
+		count := audit.CountFiles(&app.ScanData, filterScope(app, purlEntry.Files), app.ViewFilter)
 		
 		// Skip PURLs with zero files based on view filter
 		if count == 0 {
 			continue
 		}
 		
-		displayName := fmt.Sprintf("%s (%d)", purlEntry.PURL, count)
-		
-		// Create a fake TreeNode for PURL entries
-		purlNode := &TreeNode{
-			Name:  purlEntry.PURL,
-			Path:  fmt.Sprintf("purl_%d", i),
-			IsDir: false,
-			Files: purlEntry.Files,
+		node := purlNode(purlEntry)
+		if !node.IsDir {
+			displayName := fmt.Sprintf("%s (%d)", displayPURL(app, purlEntry.PURL), count)
+			if app.PURLOrder == "size" && purlEntry.Stats.PackageSize > 0 {
+				displayName += " " + byteSize(purlEntry.Stats.PackageSize)
+			}
+			if pending != nil && app.ViewFilter != "pending" {
+				displayName += fmt.Sprintf(" [%d pending]", pending[purlEntry.PURL])
+			}
+			appendPURLLine(app, node, 0, "    "+displayName)
+			continue
+		}
+
+		children := make([]TreeDisplayLine, 0, len(purlEntry.Versions))
+		for _, version := range purlEntry.Versions {
+			versionCount := audit.CountFiles(&app.ScanData, filterScope(app, version.Files), app.ViewFilter)
+			if versionCount == 0 {
+				continue
+			}
+			child := purlVersionNode(node, version)
+			children = append(children, TreeDisplayLine{
+				Node:   child,
+				Indent: 1,
+				Line:   fmt.Sprintf("      %s (%d)", displayPURL(app, child.Name), versionCount),
+			})
+		}
+
+		symbol := "[+] "
+		if app.TreeState.expandedDirs[node.Path] {
+			symbol = "[-] "
+		}
+		displayName := fmt.Sprintf("%s (%d in %d versions)", displayPURL(app, purlEntry.PURL), count, len(children))
+		if app.PURLOrder == "size" && purlEntry.Stats.PackageSize > 0 {
+			displayName += " " + byteSize(purlEntry.Stats.PackageSize)
+		}
+		if pending != nil && app.ViewFilter != "pending" {
+			displayName += fmt.Sprintf(" [%d pending]", pending[purlEntry.PURL])
+		}
+		appendPURLLine(app, node, 0, symbol+displayName)
+		if app.TreeState.expandedDirs[node.Path] {
+			for _, child := range children {
+				appendPURLLine(app, child.Node, child.Indent, child.Line)
+			}
 		}
-		
-		line := fmt.Sprintf("    %s", displayName)
-		app.TreeState.displayLines = append(app.TreeState.displayLines, TreeDisplayLine{
-			Node:   purlNode,
-			Indent: 0,
-			Line:   line,
-		})
 	}
 }
 
@@ -225,7 +431,12 @@ func navigateTree(g *gocui.Gui, app *AppState, direction string) error {
 
 	// Use custom scrollable list for navigation
 	app.TreeList.Navigate(direction)
-	
+	return showTreeSelection(g, app)
+}
+
+// showTreeSelection follows the selection of the tree list after it moved:
+// the files of the selected node are listed and the move is announced
+func showTreeSelection(g *gocui.Gui, app *AppState) error {
 	// Update selected node based on new index
 	newIndex := app.TreeList.GetSelectedIndex()
 	if newIndex >= 0 && newIndex < len(app.TreeState.displayLines) {
@@ -240,11 +451,15 @@ func navigateTree(g *gocui.Gui, app *AppState, direction string) error {
 	
 	updateFileList(g, app)
 	updateStatus(g, app)
+	announceTreeSelection(app)
 	
 	return nil
 }
 
 func toggleTreeNode(g *gocui.Gui, app *AppState) error {
+	if file := treeFile(app, app.TreeState.selectedNode); file != "" {
+		return openTreeFile(g, app, file)
+	}
 	if app.TreeState.selectedNode == nil || !app.TreeState.selectedNode.IsDir {
 		return nil
 	}
@@ -255,6 +470,7 @@ func toggleTreeNode(g *gocui.Gui, app *AppState) error {
 	updateTreeDisplay(app)
 	displayTree(g, app)
 	updateFileList(g, app)
+	announceTreeSelection(app)
 	
 	return nil
 }
@@ -270,48 +486,10 @@ func countFilesInDirectory(dirPath string) int {
 	if globalApp == nil {
 		return 0
 	}
-	
-	count := 0
-	
-	for filePath, matches := range globalApp.ScanData.Files {
-		// Check if file is in this directory or subdirectories
-		isInDirectory := false
-		if dirPath == "" {
-			// Root directory - only count files with no "/" (actual root files)
-			isInDirectory = !strings.Contains(filePath, "/")
-		} else {
-			// Check if file path starts with directory path
-			isInDirectory = strings.HasPrefix(filePath, dirPath+"/")
-		}
-		
-		if isInDirectory {
-			if globalApp.ViewFilter == "all" {
-				// For "all" view, count all files in directory (not just matched ones)
-				count++
-			} else {
-				// For other views, only count files with valid matches
-				for _, match := range matches {
-					if match.ID == "file" || match.ID == "snippet" {
-						isProcessed := len(match.AuditCmd) > 0
-						
-						switch globalApp.ViewFilter {
-						case "matched":
-							// Count all files with valid matches
-							count++
-						case "pending":
-							// Count only unprocessed files
-							if !isProcessed {
-								count++
-							}
-						default:
-							count++
-						}
-						break // Only count first valid match per file
-					}
-				}
-			}
-		}
+
+	// Count files in this directory or subdirectories that pass the view filter
+	if scopeActive(globalApp) {
+		return len(getFilesInDirectory(globalApp, dirPath))
 	}
-	
-	return count
-}
\ No newline at end of file
+	return audit.CountFilesInDirectory(&globalApp.ScanData, dirPath, globalApp.ViewFilter)
+}
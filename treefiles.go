@@ -0,0 +1,196 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// treeFileKey identifies a file leaf by its directory node, as a file at the
+// top is listed under both "." and "All Files" when the scan has no
+// directories
+type treeFileKey struct {
+	dir  *TreeNode
+	file string
+}
+
+// treeFile returns the file of a file leaf of the directory tree, or "" for
+// directories, PURLs and upstream groups
+func treeFile(app *AppState, node *TreeNode) string {
+	if node == nil || node.IsDir || groupedView(app) || len(node.Files) != 1 {
+		return ""
+	}
+	return node.Files[0]
+}
+
+// selectedTreeFile returns the file selected in the tree pane, or "" when
+// the tree pane doesn't have a file selected
+func selectedTreeFile(app *AppState) string {
+	if app.ActivePane != "tree" || app.TreeState == nil {
+		return ""
+	}
+	return treeFile(app, app.TreeState.selectedNode)
+}
+
+// directFiles returns the files of the view directly in dirPath, not in its
+// subdirectories
+func directFiles(app *AppState, dirPath string) []string {
+	dir := path.Join("/", dirPath)
+	files := make([]string, 0)
+	for _, filePath := range getFilesInDirectory(app, dirPath) {
+		if path.Dir("/"+audit.NormalizePath(filePath)) == dir {
+			files = append(files, filePath)
+		}
+	}
+	return files
+}
+
+// appendTreeFiles lists the files of an expanded directory below its
+// subdirectories, with their status markers. Leaves are kept between
+// rebuilds so the selection survives a redraw.
+func appendTreeFiles(app *AppState, dir *TreeNode, indent int, state *TreeState) {
+	if state.fileNodes == nil {
+		state.fileNodes = make(map[treeFileKey]*TreeNode)
+	}
+	prefix := strings.Repeat("  ", indent)
+	for _, filePath := range directFiles(app, dir.Path) {
+		key := treeFileKey{dir: dir, file: filePath}
+		leaf := state.fileNodes[key]
+		if leaf == nil {
+			leaf = &TreeNode{
+				Name:   path.Base(audit.NormalizePath(filePath)),
+				Path:   audit.NormalizePath(filePath),
+				Parent: dir,
+				Files:  []string{filePath},
+			}
+			state.fileNodes[key] = leaf
+		}
+		name := path.Base(audit.NormalizePath(displayPath(app, filePath)))
+		state.displayLines = append(state.displayLines, TreeDisplayLine{
+			Node:   leaf,
+			Indent: indent,
+			Line:   fmt.Sprintf("%s    %s%s", prefix, statusMarker(app, audit.FileStatus(app.ScanData.Files[filePath])), name),
+		})
+	}
+}
+
+// selectTreeFileInList highlights the file of the selected leaf in the Files
+// pane, which shows the files of its directory, so the list actions apply to it
+func selectTreeFileInList(app *AppState, filePath string) {
+	for i, listed := range app.CurrentFileList {
+		if listed == filePath {
+			app.FileList.SelectedIndex = i
+			app.FileList.adjustScroll()
+			return
+		}
+	}
+}
+
+// toggleTreeFiles shows or hides files as leaves of the directory tree and
+// remembers the choice in the config
+func toggleTreeFiles(g *gocui.Gui, app *AppState) error {
+	app.TreeFiles = !app.TreeFiles
+	if err := saveTreeFiles(app.TreeFiles); err != nil {
+		// Don't fail the toggle if the config can't be saved
+	}
+
+	// The selected leaf goes away with the files; keep its directory selected
+	if node := app.TreeState.selectedNode; !app.TreeFiles && treeFile(app, node) != "" {
+		app.TreeState.selectedNode = node.Parent
+	}
+	refreshScope(g, app)
+	if app.TreeFiles {
+		announce(app, "Files shown in the tree, %d items", len(app.TreeList.Items))
+	} else {
+		announce(app, "Only directories shown in the tree, %d items", len(app.TreeList.Items))
+	}
+	return nil
+}
+
+// refreshTreeFiles redraws the leaves after a decision changed a status
+// marker, or hid the file under the view filter. A hidden leaf gives the
+// selection to its directory.
+func refreshTreeFiles(g *gocui.Gui, app *AppState) {
+	if !app.TreeFiles || groupedView(app) || app.TreeState == nil {
+		return
+	}
+	updateTreeDisplay(app)
+	if node := app.TreeState.selectedNode; treeFile(app, node) != "" {
+		visible := false
+		for _, line := range app.TreeState.displayLines {
+			if line.Node == node {
+				visible = true
+				break
+			}
+		}
+		if !visible {
+			app.TreeState.selectedNode = node.Parent
+		}
+	}
+	refreshScope(g, app)
+}
+
+// openTreeFile shows the content of the selected leaf. ESC comes back to the
+// tree rather than to the file list.
+func openTreeFile(g *gocui.Gui, app *AppState, filePath string) error {
+	app.ActivePane = "files"
+	app.ContentFromTree = true
+	app.ViewMode = "content"
+	app.CurrentFile = filePath
+	announce(app, "Viewing %s, %s. Escape returns to the tree", displayPath(app, filePath), fileAuditState(app, filePath))
+	return openFileContent(g, app, filePath)
+}
+
+// returnToTree selects the leaf of the file last viewed, which may differ
+// from the opened one after moving with [ and ], and focuses the tree
+func returnToTree(app *AppState) {
+	app.ContentFromTree = false
+	app.ActivePane = "tree"
+	for _, line := range app.TreeState.displayLines {
+		if treeFile(app, line.Node) == app.CurrentFile {
+			app.TreeState.selectedNode = line.Node
+			break
+		}
+	}
+	updateTreeDisplay(app)
+}
+
+// showTreeDecisionDialog opens the accept, ignore or defer dialog for the
+// file selected in the tree, or for the selected upstream group or component
+func showTreeDecisionDialog(g *gocui.Gui, app *AppState, decision string) error {
+	file := selectedTreeFile(app)
+	if file == "" {
+		return showGroupDecisionDialog(g, app, decision)
+	}
+	app.CurrentMatch = audit.FirstValidMatch(app.ScanData.Files[file])
+	switch decision {
+	case audit.DecisionIgnored:
+		return showIgnoreDialog(g, app)
+	case audit.DecisionDeferred:
+		return showDeferDialog(g, app)
+	}
+	return chooseCandidate(g, app, file, func() error {
+		return showAcceptDialog(g, app)
+	})
+}
+
+// quickTreeDecision is the quick accept or ignore of the file selected in
+// the tree, or of the selected upstream group or component
+func quickTreeDecision(g *gocui.Gui, app *AppState, decision string) error {
+	if selectedTreeFile(app) == "" {
+		return quickGroupDecision(g, app, decision)
+	}
+	if decision == audit.DecisionIgnored {
+		return quickIgnore(g, app)
+	}
+	return chooseCandidate(g, app, selectedTreeFile(app), func() error {
+		return quickAccept(g, app)
+	})
+}
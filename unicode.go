@@ -0,0 +1,96 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/text/unicode/norm"
+)
+
+// displayWidth is how many terminal cells s takes up, ignoring colour codes:
+// wide characters such as CJK take two
+func displayWidth(s string) int {
+	return runewidth.StringWidth(stripANSI(s))
+}
+
+// fitWidth cuts s to width cells, marking the cut with "…". Colour codes
+// are kept and reset after the cut.
+func fitWidth(s string, width int) string {
+	if width <= 0 || displayWidth(s) <= width {
+		return s
+	}
+	var fitted strings.Builder
+	used := 0
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			if loc := ansiEscape.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+				fitted.WriteString(s[i : i+loc[1]])
+				i += loc[1]
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if used+runewidth.RuneWidth(r) > width-1 {
+			break
+		}
+		fitted.WriteRune(r)
+		used += runewidth.RuneWidth(r)
+		i += size
+	}
+	fitted.WriteString("…\033[0m")
+	return fitted.String()
+}
+
+// tailWidth is the end of s that fits in width cells
+func tailWidth(s string, width int) string {
+	runes := []rune(s)
+	used, start := 0, len(runes)
+	for start > 0 && used+runewidth.RuneWidth(runes[start-1]) <= width {
+		used += runewidth.RuneWidth(runes[start-1])
+		start--
+	}
+	return string(runes[start:])
+}
+
+// dropCells removes the first n cells of s. A wide character cut in half
+// leaves a space.
+func dropCells(s string, n int) string {
+	used := 0
+	for i, r := range s {
+		if used >= n {
+			return s[i:]
+		}
+		used += runewidth.RuneWidth(r)
+		if used > n {
+			return strings.Repeat(" ", used-n) + s[i+len(string(r)):]
+		}
+	}
+	return ""
+}
+
+// cellSafe prepares text from paths, PURLs and file contents for the
+// terminal grid: combining marks are composed with the character before
+// them where Unicode has a precomposed form. Other marks and joiners, such
+// as Devanagari signs or ZWNJ, are kept: they take no cell, so widths stay
+// right. Only bidirectional overrides are dropped, as they would reorder
+// what the terminal shows.
+func cellSafe(s string) string {
+	isZeroWidth := func(r rune) bool {
+		return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf)
+	}
+	if !strings.ContainsFunc(s, isZeroWidth) {
+		return s
+	}
+	s = norm.NFC.String(s)
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Bidi_Control, r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"path"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// groupedView reports whether the tree lists groups of files (components or
+// upstream files) rather than directories
+func groupedView(app *AppState) bool {
+	return app.TreeViewType == "purls" || app.TreeViewType == "upstream"
+}
+
+// upstreamNode returns the tree node for an upstream group. Its name is the
+// PURL so the status pane can describe the component.
+func upstreamNode(app *AppState, index int) *TreeNode {
+	group := app.UpstreamGroups[index]
+	return &TreeNode{
+		Name:  group.PURL,
+		Path:  fmt.Sprintf("upstream_%d", index),
+		IsDir: false,
+		Files: group.Files,
+	}
+}
+
+// upstreamLabel is the tree line of an upstream group
+func upstreamLabel(app *AppState, group UpstreamGroup) string {
+	return fmt.Sprintf("%s %s", displayPURL(app, group.PURL), displayPath(app, group.File))
+}
+
+func buildUpstreamDisplay(app *AppState) {
+	for i, group := range app.UpstreamGroups {
+		// Calculate count based on the view filter
+		count := audit.CountFiles(&app.ScanData, filterScope(app, group.Files), app.ViewFilter)
+		if count == 0 {
+			continue
+		}
+
+		node := upstreamNode(app, i)
+		if selected := app.TreeState.selectedNode; selected != nil && selected.Path == node.Path {
+			node = selected
+		}
+		app.TreeState.displayLines = append(app.TreeState.displayLines, TreeDisplayLine{
+			Node:   node,
+			Indent: 0,
+			Line:   fmt.Sprintf("    %s (%d)", upstreamLabel(app, group), count),
+		})
+	}
+}
+
+// toggleUpstreamView switches between the directory tree and the upstream
+// view, which groups local files copied from the same open source file
+func toggleUpstreamView(g *gocui.Gui, app *AppState) error {
+	if app.TreeViewType == "upstream" {
+		app.TreeViewType = "directories"
+		if len(app.FileTree.Children) > 0 {
+			app.TreeState.selectedNode = app.FileTree.Children[0]
+		} else {
+			app.TreeState.selectedNode = app.FileTree
+		}
+	} else {
+		if len(app.UpstreamGroups) == 0 {
+			return showErrorDialog(g, app, "Upstream Files", "No open source file is matched by more than one local file.")
+		}
+		app.TreeViewType = "upstream"
+		if app.ViewFilter == "all" {
+			app.ViewFilter = "matched"
+		}
+		app.TreeState.selectedNode = nil
+		app.TreeList.SelectedIndex = 0
+	}
+
+	updateTreeDisplay(app)
+	if app.TreeState.selectedNode == nil && len(app.TreeState.displayLines) > 0 {
+		app.TreeState.selectedNode = app.TreeState.displayLines[0].Node
+	}
+	displayTree(g, app)
+	updateFileList(g, app)
+	updateStatus(g, app)
+	updateHelpBar(g, app)
+	announce(app, "Showing %s, %d items", app.TreeViewType, len(app.TreeList.Items))
+	return nil
+}
+
+// selectedGroupFiles returns the files of the selected upstream group, or
+// of the selected component or version, when the tree pane is focused in
+// the upstream or PURL view. A component can span hundreds of files decided
+// long ago, so only those the PURL view lists are decided together.
+func selectedGroupFiles(app *AppState) []string {
+	if !groupedView(app) || app.ActivePane != "tree" || app.TreeState.selectedNode == nil {
+		return nil
+	}
+	if app.TreeViewType == "upstream" {
+		return app.TreeState.selectedNode.Files
+	}
+	files := make([]string, 0)
+	for _, filePath := range filterScope(app, app.TreeState.selectedNode.Files) {
+		if matches := app.ScanData.Files[filePath]; audit.FirstValidMatch(matches) != nil && audit.MatchesFilter(matches, app.ViewFilter) {
+			files = append(files, filePath)
+		}
+	}
+	return files
+}
+
+// groupLabel describes the files selectedGroupFiles returns, e.g. "12 files
+// of pkg:github/madler/zlib@1.2.11"
+func groupLabel(app *AppState, files []string) string {
+	if app.TreeViewType == "purls" {
+		return fmt.Sprintf("%d files of %s", len(files), displayPURL(app, app.TreeState.selectedNode.Name))
+	}
+	match := audit.FirstValidMatch(app.ScanData.Files[files[0]])
+	return fmt.Sprintf("%d files matching %s", len(files), path.Base(audit.NormalizePath(match.File)))
+}
+
+// showGroupDecisionDialog opens the accept, ignore or defer dialog for every
+// file of the selected upstream group, component or version
+func showGroupDecisionDialog(g *gocui.Gui, app *AppState, decision string) error {
+	files := selectedGroupFiles(app)
+	if len(files) == 0 {
+		return nil
+	}
+	app.DecisionGroup = files
+	app.CurrentMatch = audit.FirstValidMatch(app.ScanData.Files[files[0]])
+	var err error
+	switch decision {
+	case audit.DecisionIgnored:
+		err = showIgnoreDialog(g, app)
+	case audit.DecisionDeferred:
+		err = showDeferDialog(g, app)
+	default:
+		err = showAcceptDialog(g, app)
+	}
+	if err != nil {
+		return err
+	}
+	if v, err := g.View("audit_dialog"); err == nil {
+		v.Title = fmt.Sprintf("%s %s", v.Title, groupLabel(app, files))
+	}
+	return nil
+}
+
+// decideGroup records one decision for every file of an upstream group or
+// component
+func decideGroup(g *gocui.Gui, app *AppState, files []string, decision, assessment string, checklist []audit.ChecklistItem) error {
+	completed := completedDirs(app)
+	decisions := make([]savedDecision, 0, len(files))
+	for _, filePath := range files {
+		match := audit.FirstValidMatch(app.ScanData.Files[filePath])
+		if match == nil {
+			continue
+		}
+		decisions = append(decisions, savedDecision{filePath, match, withChecklist(match, recordDecision(app, match, decision, assessment), checklist)})
+	}
+
+	if err := saveToFile(app); err != nil {
+		return showErrorDialog(g, app, "Save Error", fmt.Sprintf("Error saving audit decisions: %v", err))
+	}
+	detail := fmt.Sprintf("Marking %d files as %s", len(decisions), decisionLabel(app, decision))
+	return afterDecisionsSaved(g, app, "DECISIONS", detail, decisions, func(g *gocui.Gui) error {
+		announce(app, "Marked %d files as %s", len(decisions), decisionLabel(app, decision))
+		return offerCollapseCompleted(g, app, completed)
+	})
+}
+
+// quickGroupDecision decides the selected upstream group, component or
+// version without a comment
+func quickGroupDecision(g *gocui.Gui, app *AppState, decision string) error {
+	files := selectedGroupFiles(app)
+	if len(files) == 0 {
+		return nil
+	}
+	return decideGroup(g, app, files, decision, "", nil)
+}
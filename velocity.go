@@ -0,0 +1,98 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"auditcmd/pkg/audit"
+)
+
+// velocitySessions is how many recent sessions the statistics list and base
+// the velocity on
+const velocitySessions = 5
+
+// projectStatePath is where the state of an audit, such as the session
+// history, is kept next to the results file
+func projectStatePath(resultsPath string) string {
+	return resultsPath + ".state.json"
+}
+
+// loadProjectState reads the session history of the results file and starts
+// a new session. Only sessions with decisions are stored.
+func loadProjectState(app *AppState) error {
+	app.SessionStart = time.Now().UTC()
+	state, err := audit.LoadProjectState(projectStatePath(app.FilePath))
+	if err != nil {
+		app.ProjectState = &audit.ProjectState{}
+		return err
+	}
+	app.ProjectState = state
+	return nil
+}
+
+// recordSessionDecision counts saved decisions in the current session and
+// writes the history
+func recordSessionDecision(app *AppState, count int) error {
+	if app.ProjectState == nil || count == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	if !app.SessionRecorded {
+		app.ProjectState.Sessions = append(app.ProjectState.Sessions, audit.AuditSession{Started: app.SessionStart})
+		app.SessionRecorded = true
+	}
+	session := &app.ProjectState.Sessions[len(app.ProjectState.Sessions)-1]
+	session.Ended = now
+	session.Decisions += count
+	return audit.SaveProjectState(projectStatePath(app.FilePath), app.ProjectState)
+}
+
+// shortDuration formats d as "1h05m", "20m" or "<1m"
+func shortDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// writeVelocity prints the recent sessions, whether the pace is going up or
+// down, and how long the pending files would take at that pace
+func writeVelocity(w io.Writer, app *AppState, pending int) {
+	fmt.Fprintf(w, "\n \033[1mVelocity\033[0m  (recent sessions)\n")
+	if app.ProjectState == nil || len(app.ProjectState.Sessions) == 0 {
+		fmt.Fprintf(w, "   No sessions recorded yet\n")
+		return
+	}
+
+	sessions := app.ProjectState.Sessions
+	if len(sessions) > velocitySessions {
+		sessions = sessions[len(sessions)-velocitySessions:]
+	}
+	for _, session := range sessions {
+		fmt.Fprintf(w, "   %s  %5d decisions in %-6s %6.0f/h\n", formatTimestamp(app, session.Started), session.Decisions, shortDuration(session.Duration()), session.Rate())
+	}
+
+	if count := len(app.ProjectState.Sessions); count > 1 {
+		earlier := &audit.ProjectState{Sessions: app.ProjectState.Sessions[:count-1]}
+		if previous := earlier.Velocity(velocitySessions); previous > 0 {
+			change := (app.ProjectState.Sessions[count-1].Rate() - previous) / previous * 100
+			fmt.Fprintf(w, "   Trend: %+.0f%% against the sessions before\n", change)
+		}
+	}
+
+	rate := app.ProjectState.Velocity(velocitySessions)
+	switch {
+	case pending == 0:
+		fmt.Fprintf(w, "   Remaining in the audit: nothing pending\n")
+	case rate > 0:
+		fmt.Fprintf(w, "   Remaining in the audit: %d pending, about %s at %.0f decisions/hour\n", pending, shortDuration(app.ProjectState.EstimateRemaining(pending, velocitySessions)), rate)
+	}
+}
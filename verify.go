@@ -0,0 +1,69 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// startSourceVerification hashes the local files in the background and
+// refreshes the views once the results are known
+func startSourceVerification(g *gocui.Gui, app *AppState) {
+	if app.SourceDir == "" || app.Dependencies != nil {
+		return
+	}
+
+	// Hash from a copy of the file map so re-scans can update the results meanwhile
+	scan := &audit.ScanResult{Files: make(map[string][]FileMatch, len(app.ScanData.Files))}
+	for filePath, matches := range app.ScanData.Files {
+		scan.Files[filePath] = matches
+	}
+	sourceDir := app.SourceDir
+	go func() {
+		drift := audit.VerifySource(scan, sourceDir)
+		g.Update(func(g *gocui.Gui) error {
+			app.Drift = drift
+			app.DriftChecked = true
+			updateFileList(g, app)
+			updateStatus(g, app)
+			if len(drift) > 0 {
+				announce(app, "%d files differ from the scanned source", len(drift))
+			}
+			return nil
+		})
+	}()
+}
+
+// driftMarker flags files whose local copy no longer matches the scan
+func driftMarker(app *AppState, filePath string) string {
+	kind := app.Drift[filePath]
+	if kind == "" {
+		return ""
+	}
+	if app.Accessible {
+		return " [" + kind + " locally]"
+	}
+	return " \033[31m!" + kind + "\033[0m"
+}
+
+// toggleDriftOnly switches between all files and only those that drifted
+func toggleDriftOnly(g *gocui.Gui, app *AppState) error {
+	if app.SourceDir == "" {
+		return showErrorDialog(g, app, "Source Verification", "No source directory configured. Start auditcmd with --source <dir> to check local files against the scan.")
+	}
+	if !app.DriftChecked {
+		return showErrorDialog(g, app, "Source Verification", "Local files are still being verified, try again in a moment.")
+	}
+	app.DriftOnly = !app.DriftOnly
+
+	refreshScope(g, app)
+	if app.DriftOnly {
+		announce(app, "Showing only files changed since the scan, %d items", len(app.TreeList.Items))
+	} else {
+		announce(app, "Showing all files, %d items", len(app.TreeList.Items))
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"auditcmd/pkg/audit"
+)
+
+// vocabularyStates are the states that label.<state> and icon.<state> can
+// rename. Decisions are always saved with their standard names; labels and
+// icons only change what auditors see and what exports say.
+var vocabularyStates = []string{audit.StatusIdentified, audit.StatusIgnored, audit.StatusDeferred, audit.StatusPending}
+
+// defaultIcons are the status markers of the file list
+var defaultIcons = map[string]string{
+	audit.StatusIdentified: "✓",
+	audit.StatusIgnored:    "✗",
+	audit.StatusDeferred:   "~",
+	audit.StatusPending:    "?",
+	audit.StatusNoMatch:    "-",
+}
+
+// addConfigVocabulary records a label.<state> or icon.<state> entry
+func addConfigVocabulary(config *Config, entries map[string]string, key, state, value string) {
+	if !slices.Contains(vocabularyStates, state) {
+		config.Warnings = append(config.Warnings, fmt.Sprintf("unknown state in %s (use %s)", key, strings.Join(vocabularyStates, ", ")))
+		return
+	}
+	if value == "" {
+		config.Warnings = append(config.Warnings, fmt.Sprintf("empty %s", key))
+		return
+	}
+	entries[state] = value
+}
+
+// configVocabularyLines writes labels or icons back as config lines
+func configVocabularyLines(prefix string, entries map[string]string) string {
+	states := make([]string, 0, len(entries))
+	for state := range entries {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+	var lines strings.Builder
+	for _, state := range states {
+		fmt.Fprintf(&lines, "%s.%s=%s\n", prefix, state, entries[state])
+	}
+	return lines.String()
+}
+
+// stateLabel is what a file state is called, e.g. "approved" instead of
+// "identified" with label.identified=approved
+func stateLabel(app *AppState, status string) string {
+	if label := app.Labels[status]; label != "" {
+		return label
+	}
+	if status == audit.StatusNoMatch {
+		return "no match"
+	}
+	return status
+}
+
+// stateTitle is stateLabel capitalized, for counters such as "Pending: 3".
+// Labels may start with any letter, e.g. "édité".
+func stateTitle(app *AppState, status string) string {
+	label := stateLabel(app, status)
+	first, size := utf8.DecodeRuneInString(label)
+	if size == 0 {
+		return label
+	}
+	return string(unicode.ToTitle(first)) + label[size:]
+}
+
+// decisionLabel is stateLabel for a recorded decision, which may be
+// spelled in any case
+func decisionLabel(app *AppState, decision string) string {
+	if label := app.Labels[strings.ToLower(strings.TrimSpace(decision))]; label != "" {
+		return label
+	}
+	return decision
+}
+
+// decisionVerb is the action of a decision dialog: "Accept", "Ignore" and
+// "Defer", or "Mark approved" once the decision has a label
+func decisionVerb(app *AppState, decision string) string {
+	if label := app.Labels[decision]; label != "" {
+		return "Mark " + label
+	}
+	switch decision {
+	case audit.DecisionIgnored:
+		return "Ignore"
+	case audit.DecisionDeferred:
+		return "Defer"
+	}
+	return "Accept"
+}
+
+// decisionTitle is the title of a decision dialog
+func decisionTitle(app *AppState, decision string) string {
+	if app.Labels[decision] != "" {
+		return strings.ToUpper(decisionVerb(app, decision))
+	}
+	if decision == audit.DecisionDeferred {
+		return "DEFER Decision"
+	}
+	return strings.ToUpper(decisionVerb(app, decision)) + " Identification"
+}
+
+// stateIcon is the marker of a file state in the file list
+func stateIcon(app *AppState, status string) string {
+	if icon := app.Icons[status]; icon != "" {
+		return icon
+	}
+	if icon, ok := defaultIcons[status]; ok {
+		return icon
+	}
+	return defaultIcons[audit.StatusNoMatch]
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 SCANOSS
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"auditcmd/pkg/audit"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// wfpForFile returns the fingerprint of a scanned file, if a .wfp was given
+func wfpForFile(app *AppState, filePath string) *audit.WFPFile {
+	if app.WFP == nil {
+		return nil
+	}
+	return app.WFP[audit.NormalizePath(filePath)]
+}
+
+// writeRangePairs prints which local lines correspond to which OSS lines for
+// a snippet match, with fingerprint coverage when a .wfp file was loaded
+func writeRangePairs(v io.Writer, app *AppState, filePath string, match *FileMatch) {
+	if match.ID != "snippet" {
+		return
+	}
+	pairs := audit.PairLineRanges(match)
+	if len(pairs) == 0 {
+		return
+	}
+
+	parts := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		parts = append(parts, fmt.Sprintf("local %s <-> OSS %s", orDash(pair.Local), orDash(pair.OSS)))
+	}
+	fmt.Fprintf(v, "Matched ranges: %s\n", strings.Join(parts, " | "))
+
+	if wfp := wfpForFile(app, filePath); wfp != nil {
+		localLines := parseOSSLines(match.Lines)
+		fingerprinted := 0
+		for _, line := range localLines {
+			if wfp.HasSnippet(line) {
+				fingerprinted++
+			}
+		}
+		fmt.Fprintf(v, "Fingerprint: %d of %d matched local lines carry snippet hashes", fingerprinted, len(localLines))
+		if match.SourceHash != "" && !strings.EqualFold(wfp.MD5, match.SourceHash) {
+			fmt.Fprintf(v, " (WARNING: .wfp MD5 differs from source_hash, fingerprint is from another version)")
+		}
+		fmt.Fprintf(v, "\n")
+	}
+	fmt.Fprintf(v, "\n")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// displayLocalContent shows the scanned file from --source, highlighting the
+// local side of the match. Lines with a snippet hash in the .wfp are marked
+// with "#" after the line number.
+func displayLocalContent(v io.Writer, app *AppState, filePath string, match *FileMatch) error {
+	data, err := os.ReadFile(filepath.Join(app.SourceDir, filepath.FromSlash(audit.NormalizePath(filePath))))
+	if err != nil {
+		fmt.Fprintf(v, "Local file not available: %v", err)
+		return nil
+	}
+	if app.ContentHex || looksBinary(data) {
+		writeHexDump(v, data)
+		return nil
+	}
+
+	writeRangePairs(v, app, filePath, match)
+
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	highlightLines := parseOSSLines(match.Lines)
+	wfp := wfpForFile(app, filePath)
+
+	for i, line := range strings.Split(content, "\n") {
+		lineNum := i + 1
+		line = escapeControls(line)
+
+		shouldHighlight := match.ID == "file"
+		if match.ID == "snippet" && highlightLines != nil {
+			shouldHighlight = highlightLines[0] == -1 || contains(highlightLines, lineNum)
+		}
+
+		separator := ":"
+		if wfp != nil && wfp.HasSnippet(lineNum) {
+			separator = "#"
+		}
+
+		if shouldHighlight && app.Accessible {
+			fmt.Fprintf(v, "%4d*%s %s\n", lineNum, separator, line)
+		} else if shouldHighlight {
+			fmt.Fprintf(v, "\033[43m\033[30m%4d%s %s\033[0m\n", lineNum, separator, line)
+		} else {
+			fmt.Fprintf(v, "%4d%s %s\n", lineNum, separator, line)
+		}
+	}
+	return nil
+}
+
+// contentSideLabel is appended to the content pane title for the local copy
+func contentSideLabel(app *AppState) string {
+	if app.ContentSide == "local" {
+		return " (local)"
+	}
+	return ""
+}
+
+// toggleContentSide switches the content view between the matched open
+// source file and the local scanned file
+func toggleContentSide(g *gocui.Gui, app *AppState) error {
+	if app.ViewMode != "content" || app.CurrentFile == "" {
+		return nil
+	}
+	if app.SourceDir == "" {
+		return showErrorDialog(g, app, "Local View", "No source directory configured. Start auditcmd with --source <dir> to view the scanned files.")
+	}
+
+	if app.ContentSide == "local" {
+		app.ContentSide = "oss"
+	} else {
+		app.ContentSide = "local"
+	}
+	if err := displayFileContent(g, app, app.CurrentFile); err != nil {
+		return err
+	}
+	updatePaneTitles(g, app)
+	announce(app, "Showing %s copy of %s", app.ContentSide, displayPath(app, app.CurrentFile))
+	return nil
+}